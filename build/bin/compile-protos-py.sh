@@ -10,8 +10,9 @@ echo "** generate python bindings"
 proto_imports=".:${GOPATH}/src/github.com/gogo/protobuf/protobuf:${GOPATH}/src/github.com/gogo/protobuf:${GOPATH}/src/github.com/envoyproxy/protoc-gen-validate:${GOPATH}/src"
 
 OUTPUTPATH="python/onos_e2_sm"
-rm -rf "$OUTPUTPATH"
 mkdir -p "$OUTPUTPATH"
+# keep the hand-written modules, e.g. the codec.py wrapper of libe2sm
+find "$OUTPUTPATH" -mindepth 1 -maxdepth 1 ! -name codec.py -exec rm -rf {} +
 
 cd servicemodels
 
@@ -24,3 +25,10 @@ protoc "-I=$proto_imports" \
     e2sm_rc_pre/v2/e2sm_rc_pre_v2.proto \
     e2sm_mho/v1/e2sm_mho.proto \
     e2sm_kpm/v1beta1/e2sm_kpm_ies.proto
+
+cd ..
+
+# betterproto client and server bindings of the CodecService of onos-e2-sm serve
+protoc "-I=$proto_imports" \
+    "--python_betterproto_out=$OUTPUTPATH" \
+    api/codec/v1/codec.proto
@@ -78,4 +78,10 @@ cp -r github.com/onosproject/onos-e2-sm/* .
 rm -rf github.com
 
 cp -r servicemodels/github.com/onosproject/onos-e2-sm/* .
-rm -rf servicemodels/github.com
\ No newline at end of file
+rm -rf servicemodels/github.com
+
+# the protoc-go image has no protoc-gen-go-grpc, needed for the CodecService of onos-e2-sm serve
+command -v protoc-gen-go-grpc > /dev/null || go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.1.0
+protoc -I=$proto_imports \
+  --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. \
+  api/codec/v1/codec.proto
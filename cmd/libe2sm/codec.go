@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+)
+
+// decode decodes an APER encoded PDU into the protobuf encoding of its message
+func decode(model string, pduType string, asn1Bytes []byte) ([]byte, error) {
+	sm, kind, err := getCodec(model, pduType)
+	if err != nil {
+		return nil, err
+	}
+	return kind.Decode(sm, asn1Bytes)
+}
+
+// encode encodes the protobuf encoding of a PDU message in APER
+func encode(model string, pduType string, protoBytes []byte) ([]byte, error) {
+	sm, kind, err := getCodec(model, pduType)
+	if err != nil {
+		return nil, err
+	}
+	return kind.Encode(sm, protoBytes)
+}
+
+func getCodec(model string, pduType string) (codec.ServiceModel, codec.PduKind, error) {
+	sm, err := codec.GetServiceModel(model)
+	if err != nil {
+		return nil, codec.PduKind{}, err
+	}
+	kind, err := codec.GetPduKind(pduType)
+	if err != nil {
+		return nil, codec.PduKind{}, err
+	}
+	return sm, kind, nil
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCodec(t *testing.T) {
+	// A control header of E2SM-RC-PRE, see the build test of onos-e2-sm
+	asn1Bytes, err := hex.DecodeString("3412f410abd4bc0001")
+	assert.NilError(t, err)
+
+	protoBytes, err := decode("e2sm_rc_pre/v2_go", "control-header", asn1Bytes)
+	assert.NilError(t, err)
+	assert.Assert(t, len(protoBytes) > 0)
+	encoded, err := encode("1.3.6.1.4.1.53148.1.2.2.100", "ControlHeader", protoBytes)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, asn1Bytes, encoded)
+
+	_, err = decode("e2sm_unknown", "control-header", asn1Bytes)
+	assert.ErrorContains(t, err, `unknown service model "e2sm_unknown"`)
+	_, err = decode("e2sm_rc_pre", "control", asn1Bytes)
+	assert.ErrorContains(t, err, `unknown PDU type "control"`)
+	_, err = decode("e2sm_rc_pre", "control-header", []byte{0xff})
+	assert.Assert(t, err != nil)
+	_, err = encode("e2sm_rc_pre", "control", protoBytes)
+	assert.ErrorContains(t, err, `unknown PDU type "control"`)
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// libe2sm exports the codecs of the pure-Go service models as a C library, for the processes which are not
+// written in Go, e.g. the Python xApps (see python/onos_e2_sm/codec.py):
+//
+//	go build -buildmode=c-shared -o libe2sm.so ./cmd/libe2sm
+//
+// The models are <name>/<version>, <name>, the module name or the OID, as in the onos-e2-sm command, and the PDU
+// types are e.g. indication-message or IndicationMessage. The protobuf encodings are those of the messages of
+// the Go service models, e.g. servicemodels/e2sm_kpm_v2_go. The functions return 0 on success, setting *out
+// and *outLen, or -1 setting *err; both *out and *err are to be freed with e2sm_free.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+//export e2sm_decode
+func e2sm_decode(model *C.char, pduType *C.char, asn1Bytes unsafe.Pointer, asn1Len C.size_t,
+	out *unsafe.Pointer, outLen *C.size_t, err **C.char) C.int {
+	protoBytes, e := decode(C.GoString(model), C.GoString(pduType), C.GoBytes(asn1Bytes, C.int(asn1Len)))
+	return result(protoBytes, e, out, outLen, err)
+}
+
+//export e2sm_encode
+func e2sm_encode(model *C.char, pduType *C.char, protoBytes unsafe.Pointer, protoLen C.size_t,
+	out *unsafe.Pointer, outLen *C.size_t, err **C.char) C.int {
+	asn1Bytes, e := encode(C.GoString(model), C.GoString(pduType), C.GoBytes(protoBytes, C.int(protoLen)))
+	return result(asn1Bytes, e, out, outLen, err)
+}
+
+//export e2sm_free
+func e2sm_free(p unsafe.Pointer) {
+	C.free(p)
+}
+
+// result copies the result of a codec into the C heap
+func result(bytes []byte, e error, out *unsafe.Pointer, outLen *C.size_t, err **C.char) C.int {
+	if e != nil {
+		*err = C.CString(e.Error())
+		return -1
+	}
+	*out = C.CBytes(bytes)
+	*outLen = C.size_t(len(bytes))
+	return 0
+}
+
+func main() {}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// onos-e2-sm-plugin-loader loads a service model plugin for the inspect-plugin command of onos-e2-sm, see
+// pkg/pluginloader. It only links the standard library, so that it can open the plugins built against any
+// version of the dependencies, as long as it is built with the same Go version:
+//
+//	onos-e2-sm-plugin-loader <file.so>
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/onosproject/onos-e2-sm/pkg/pluginloader"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <file.so>\n", os.Args[0])
+		os.Exit(2)
+	}
+	if err := pluginloader.Run(os.Args[1], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,508 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// aperConstraints are the constraints of a field, from the aper tag of its Go struct field
+type aperConstraints struct {
+	ValueLB  *int64
+	ValueUB  *int64
+	ValueExt bool
+	SizeLB   *int64
+	SizeUB   *int64
+	SizeExt  bool
+	Optional bool
+}
+
+// empty returns whether no value or size is constrained
+func (c aperConstraints) empty() bool {
+	return c.ValueLB == nil && c.ValueUB == nil && c.SizeLB == nil && c.SizeUB == nil
+}
+
+// parseAperTag parses an aper tag, e.g. "optional,sizeLB:1,sizeUB:160,sizeExt"
+func parseAperTag(tag string) aperConstraints {
+	c := aperConstraints{}
+	for _, option := range strings.Split(tag, ",") {
+		parts := strings.SplitN(option, ":", 2)
+		var bound *int64
+		if len(parts) == 2 {
+			if value, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+				bound = &value
+			}
+		}
+		switch parts[0] {
+		case "valueLB":
+			c.ValueLB = bound
+		case "valueUB":
+			c.ValueUB = bound
+		case "valueExt":
+			c.ValueExt = true
+		case "sizeLB":
+			c.SizeLB = bound
+		case "sizeUB":
+			c.SizeUB = bound
+		case "sizeExt":
+			c.SizeExt = true
+		case "optional":
+			c.Optional = true
+		}
+	}
+	return c
+}
+
+// getAperConstraints returns the constraints of a field of a message
+func getAperConstraints(m protoreflect.Message, fd protoreflect.FieldDescriptor) aperConstraints {
+	v := reflect.ValueOf(m.Interface())
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return aperConstraints{}
+	}
+	t := v.Elem().Type()
+	if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		// The alternatives of a CHOICE are tagged in their wrapper types
+		scratch := m.New()
+		scratch.Set(fd, scratch.NewField(fd))
+		sv := reflect.ValueOf(scratch.Interface()).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("protobuf_oneof") == string(oneof.Name()) {
+				t = sv.Field(i).Elem().Elem().Type()
+				break
+			}
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		for _, option := range strings.Split(t.Field(i).Tag.Get("protobuf"), ",") {
+			if option == "name="+string(fd.Name()) {
+				return parseAperTag(t.Field(i).Tag.Get("aper"))
+			}
+		}
+	}
+	return aperConstraints{}
+}
+
+// pduBuilder builds a PDU by prompting for its components, following its protobuf descriptor
+type pduBuilder struct {
+	in  *bufio.Reader
+	out io.Writer
+	// root is the name of the PDU, prompted for instead of an empty path
+	root string
+}
+
+func newPduBuilder(in io.Reader, out io.Writer, root string) *pduBuilder {
+	return &pduBuilder{in: bufio.NewReader(in), out: out, root: root}
+}
+
+func (b *pduBuilder) label(path string) string {
+	if path == "" {
+		return b.root
+	}
+	return path
+}
+
+// ask prompts for a value until parse accepts it, an empty answer being the default value
+func (b *pduBuilder) ask(prompt string, defaultValue string, parse func(string) error) error {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(b.out, "%s [%s]: ", prompt, defaultValue)
+		} else {
+			fmt.Fprintf(b.out, "%s: ", prompt)
+		}
+		line, err := b.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				fmt.Fprintln(b.out)
+				return fmt.Errorf("unexpected end of input at %s", prompt)
+			}
+			return err
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = defaultValue
+		}
+		if err := parse(answer); err != nil {
+			fmt.Fprintf(b.out, "  %v\n", err)
+			continue
+		}
+		return nil
+	}
+}
+
+// buildMessage builds a message, the constraints being the ones of the field of the message
+func (b *pduBuilder) buildMessage(path string, m protoreflect.Message, c aperConstraints) error {
+	desc := m.Descriptor()
+	switch {
+	case isBitString(desc):
+		return b.ask(fmt.Sprintf("%s (BIT STRING%s)", b.label(path), formatSize(c)), "", func(answer string) error {
+			if err := checkSize(len(answer), c); err != nil {
+				return err
+			}
+			return setBitString(m, answer)
+		})
+	case isDefinedType(desc), isSequenceOf(desc):
+		// The constraints of a defined type are either the ones of its value or the ones of the field using it
+		fd := desc.Fields().Get(0)
+		if own := getAperConstraints(m, fd); !own.empty() {
+			c = own
+		}
+		return b.buildValue(path, m, fd, c)
+	}
+
+	built := make(map[protoreflect.Name]bool)
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			if !built[oneof.Name()] {
+				built[oneof.Name()] = true
+				if err := b.buildChoice(path, m, oneof); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := b.buildField(joinPath(path, asn1Identifier(fd)), m, fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildChoice prompts for the alternative of a CHOICE and builds it
+func (b *pduBuilder) buildChoice(path string, m protoreflect.Message, oneof protoreflect.OneofDescriptor) error {
+	alternatives := oneof.Fields()
+	names := make([]string, alternatives.Len())
+	for i := range names {
+		names[i] = asn1Identifier(alternatives.Get(i))
+	}
+	var chosen protoreflect.FieldDescriptor
+	prompt := fmt.Sprintf("%s (CHOICE %s)", b.label(path), strings.Join(names, ", "))
+	err := b.ask(prompt, names[0], func(answer string) error {
+		if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(names) {
+			chosen = alternatives.Get(index - 1)
+			return nil
+		}
+		for i, name := range names {
+			// The json_name of the alternative, e.g. eUTRA_CGI for eUTRA-CGI, is accepted as well
+			if normalizeName(name) == normalizeName(answer) {
+				chosen = alternatives.Get(i)
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %s, or their number", strings.Join(names, ", "))
+	})
+	if err != nil {
+		return err
+	}
+	return b.buildValue(joinPath(path, asn1Identifier(chosen)), m, chosen, getAperConstraints(m, chosen))
+}
+
+// buildField prompts for whether to include an optional field, and builds it
+func (b *pduBuilder) buildField(path string, m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	c := getAperConstraints(m, fd)
+	optional := c.Optional || strings.HasSuffix(fd.JSONName(), ":OPTIONAL") || fd.ContainingOneof() != nil
+	if optional && fd.HasPresence() {
+		include := false
+		err := b.ask(fmt.Sprintf("include %s? (OPTIONAL, y/n)", path), "n", func(answer string) error {
+			var err error
+			include, err = parseYesNo(answer)
+			return err
+		})
+		if err != nil || !include {
+			return err
+		}
+	}
+	return b.buildValue(path, m, fd, c)
+}
+
+// buildValue builds the value of a field
+func (b *pduBuilder) buildValue(path string, m protoreflect.Message, fd protoreflect.FieldDescriptor, c aperConstraints) error {
+	if fd.IsList() {
+		count := 0
+		defaultCount := int64(1)
+		if c.SizeLB != nil {
+			defaultCount = *c.SizeLB
+		}
+		err := b.ask(fmt.Sprintf("%s (SEQUENCE%s OF, number of items)", b.label(path), formatSize(c)), fmt.Sprint(defaultCount), func(answer string) error {
+			var err error
+			if count, err = strconv.Atoi(answer); err != nil || count < 0 {
+				return fmt.Errorf("expected a number of items")
+			}
+			return checkSize(count, c)
+		})
+		if err != nil {
+			return err
+		}
+		list := m.Mutable(fd).List()
+		for i := 0; i < count; i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if fd.Kind() == protoreflect.MessageKind {
+				item := list.NewElement()
+				if err := b.buildMessage(itemPath, item.Message(), aperConstraints{}); err != nil {
+					return err
+				}
+				list.Append(item)
+				continue
+			}
+			item, err := b.askScalar(itemPath, fd, aperConstraints{ValueLB: c.ValueLB, ValueUB: c.ValueUB, ValueExt: c.ValueExt})
+			if err != nil {
+				return err
+			}
+			list.Append(item)
+		}
+		return nil
+	}
+	if fd.Kind() == protoreflect.MessageKind {
+		return b.buildMessage(path, m.Mutable(fd).Message(), c)
+	}
+	value, err := b.askScalar(path, fd, c)
+	if err != nil {
+		return err
+	}
+	m.Set(fd, value)
+	return nil
+}
+
+// askScalar prompts for a scalar value within its constraints
+func (b *pduBuilder) askScalar(path string, fd protoreflect.FieldDescriptor, c aperConstraints) (protoreflect.Value, error) {
+	var value protoreflect.Value
+	label := b.label(path)
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = enumIdentifier(fd.Enum(), values.Get(i))
+		}
+		err := b.ask(fmt.Sprintf("%s (ENUMERATED %s)", label, strings.Join(names, ", ")), names[0], func(answer string) error {
+			for i, name := range names {
+				if strings.EqualFold(name, answer) || answer == fmt.Sprint(values.Get(i).Number()) {
+					value = protoreflect.ValueOfEnum(values.Get(i).Number())
+					return nil
+				}
+			}
+			return fmt.Errorf("expected one of %s", strings.Join(names, ", "))
+		})
+		return value, err
+	case protoreflect.BoolKind:
+		err := b.ask(fmt.Sprintf("%s (BOOLEAN)", label), "false", func(answer string) error {
+			v, err := strconv.ParseBool(answer)
+			value = protoreflect.ValueOfBool(v)
+			return err
+		})
+		return value, err
+	case protoreflect.StringKind:
+		err := b.ask(fmt.Sprintf("%s (string%s)", label, formatSize(c)), "", func(answer string) error {
+			value = protoreflect.ValueOfString(answer)
+			return checkSize(len(answer), c)
+		})
+		return value, err
+	case protoreflect.BytesKind:
+		err := b.ask(fmt.Sprintf("%s (OCTET STRING%s, hexadecimal)", label, formatSize(c)), "", func(answer string) error {
+			octets, err := parseHex([]byte(answer))
+			if err != nil {
+				return fmt.Errorf("invalid hexadecimal: %v", err)
+			}
+			value = protoreflect.ValueOfBytes(octets)
+			return checkSize(len(octets), c)
+		})
+		return value, err
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		err := b.ask(fmt.Sprintf("%s (REAL)", label), "0", func(answer string) error {
+			v, err := strconv.ParseFloat(answer, 64)
+			if fd.Kind() == protoreflect.FloatKind {
+				value = protoreflect.ValueOfFloat32(float32(v))
+			} else {
+				value = protoreflect.ValueOfFloat64(v)
+			}
+			return err
+		})
+		return value, err
+	}
+
+	lb, ub := integerRange(fd.Kind())
+	if c.ValueLB != nil && *c.ValueLB > lb {
+		lb = *c.ValueLB
+	}
+	if c.ValueUB != nil && *c.ValueUB < ub && !c.ValueExt {
+		ub = *c.ValueUB
+	}
+	if lb > ub {
+		// Constraints beyond the range of the protobuf type can't be satisfied, and are ignored
+		lb, ub = integerRange(fd.Kind())
+	}
+	defaultValue := int64(0)
+	if lb > 0 {
+		defaultValue = lb
+	}
+	err := b.ask(fmt.Sprintf("%s (INTEGER%s)", label, formatRange(c)), fmt.Sprint(defaultValue), func(answer string) error {
+		v, err := strconv.ParseInt(answer, 10, 64)
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		if v < lb || v > ub {
+			return fmt.Errorf("expected an integer in %d..%d", lb, ub)
+		}
+		switch fd.Kind() {
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+			value = protoreflect.ValueOfInt32(int32(v))
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			value = protoreflect.ValueOfUint32(uint32(v))
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			value = protoreflect.ValueOfUint64(uint64(v))
+		default:
+			value = protoreflect.ValueOfInt64(v)
+		}
+		return nil
+	})
+	return value, err
+}
+
+// integerRange returns the range of the values of an integer kind
+func integerRange(kind protoreflect.Kind) (int64, int64) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return math.MinInt32, math.MaxInt32
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return 0, math.MaxUint32
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return 0, math.MaxInt64
+	}
+	return math.MinInt64, math.MaxInt64
+}
+
+func parseYesNo(answer string) (bool, error) {
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected y or n")
+}
+
+// checkSize checks a size against the SIZE constraint, unless it is extensible
+func checkSize(size int, c aperConstraints) error {
+	if c.SizeExt {
+		return nil
+	}
+	if c.SizeLB != nil && int64(size) < *c.SizeLB || c.SizeUB != nil && int64(size) > *c.SizeUB {
+		if c.SizeLB != nil && c.SizeUB != nil && *c.SizeLB == *c.SizeUB {
+			return fmt.Errorf("expected a size of %d", *c.SizeLB)
+		}
+		return fmt.Errorf("expected a size in %s", formatBounds(c.SizeLB, c.SizeUB, c.SizeExt))
+	}
+	return nil
+}
+
+// formatSize formats a SIZE constraint, e.g. " (SIZE 1..160,...)"
+func formatSize(c aperConstraints) string {
+	if c.SizeLB == nil && c.SizeUB == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (SIZE %s)", formatBounds(c.SizeLB, c.SizeUB, c.SizeExt))
+}
+
+// formatRange formats a value constraint, e.g. " 0..4095"
+func formatRange(c aperConstraints) string {
+	if c.ValueLB == nil && c.ValueUB == nil {
+		return ""
+	}
+	return " " + formatBounds(c.ValueLB, c.ValueUB, c.ValueExt)
+}
+
+func formatBounds(lb, ub *int64, extensible bool) string {
+	bounds := "MIN..MAX"
+	switch {
+	case lb != nil && ub != nil && *lb == *ub:
+		bounds = fmt.Sprint(*lb)
+	case lb != nil && ub != nil:
+		bounds = fmt.Sprintf("%d..%d", *lb, *ub)
+	case lb != nil:
+		bounds = fmt.Sprintf("%d..MAX", *lb)
+	case ub != nil:
+		bounds = fmt.Sprintf("MIN..%d", *ub)
+	}
+	if extensible {
+		bounds += ",..."
+	}
+	return bounds
+}
+
+// buildPdu prompts for the components of a PDU of a service model
+func buildPdu(sm codec.ServiceModel, kind codec.PduKind, in io.Reader, out io.Writer) (proto.Message, error) {
+	msg, err := newPduMessage(sm, kind)
+	if err != nil {
+		return nil, err
+	}
+	builder := newPduBuilder(in, out, getASN1TypeName(sm, kind))
+	if err := builder.buildMessage("", msg.ProtoReflect(), aperConstraints{}); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func getBuildCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Builds a PDU of a service model interactively and prints it in APER and JSON",
+		Long: `Builds a PDU of a service model by prompting for its components, following its protobuf descriptor:
+the alternative of each CHOICE, whether to include each OPTIONAL component, the number of items of each
+SEQUENCE OF and each value, within the ranges and sizes of the aper tags of the Go structs. An empty
+answer takes the value in brackets. The prompts are written to stderr, and the PDU is printed to stdout,
+in hexadecimal APER and then in JSON; if the PDU cannot be encoded, the JSON is printed anyway.`,
+		Example: `  onos-e2-sm build --model e2sm_rsm --type ControlMessage
+  onos-e2-sm build -m e2sm_rc_pre/v2_go -t control-header < answers.txt`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modelFlag, _ := cmd.Flags().GetString("model")
+			typeFlag, _ := cmd.Flags().GetString("type")
+			sm, err := codec.GetServiceModel(modelFlag)
+			if err != nil {
+				return err
+			}
+			kind, err := codec.GetPduKind(typeFlag)
+			if err != nil {
+				return err
+			}
+			msg, err := buildPdu(sm, kind, cmd.InOrStdin(), cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+
+			jsonBytes, err := marshalPdu(sm, kind, formatJSON, msg, false)
+			if err != nil {
+				return err
+			}
+			hexBytes, err := marshalPdu(sm, kind, formatAper, msg, false)
+			if err != nil {
+				_, _ = cmd.OutOrStdout().Write(jsonBytes)
+				return fmt.Errorf("cannot encode the %s in APER: %v", getASN1TypeName(sm, kind), err)
+			}
+			if _, err := cmd.OutOrStdout().Write(hexBytes); err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(jsonBytes)
+			return err
+		},
+	}
+	cmd.Flags().StringP("model", "m", "", "the service model, as listed by the models command")
+	cmd.Flags().StringP("type", "t", "", "the PDU kind, e.g. control-message or ControlMessage")
+	_ = cmd.MarkFlagRequired("model")
+	_ = cmd.MarkFlagRequired("type")
+	return cmd
+}
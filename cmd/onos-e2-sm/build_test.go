@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	e2smrsm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+	"gotest.tools/assert"
+)
+
+func TestGetAperConstraints(t *testing.T) {
+	config := (&e2smrsm.SliceConfig{}).ProtoReflect()
+	c := getAperConstraints(config, config.Descriptor().Fields().ByName("slice_description"))
+	assert.Assert(t, c.Optional)
+	assert.Equal(t, int64(1), *c.SizeLB)
+	assert.Equal(t, int64(160), *c.SizeUB)
+	assert.Assert(t, c.SizeExt)
+	assert.Equal(t, " (SIZE 1..160,...)", formatSize(c))
+
+	// The alternatives of a CHOICE are tagged in their wrapper types
+	formats := (&e2smrsm.EventDefinitionFormats{}).ProtoReflect()
+	c = getAperConstraints(formats, formats.Descriptor().Fields().ByName("event_definition_format1"))
+	assert.Assert(t, c.ValueExt)
+	assert.Assert(t, c.empty())
+}
+
+func TestBuildPdu(t *testing.T) {
+	sm, err := codec.GetServiceModel("e2sm_rsm")
+	assert.NilError(t, err)
+	kind, err := codec.GetPduKind("ControlMessage")
+	assert.NilError(t, err)
+
+	answers := strings.Join([]string{
+		"sliceCreate",
+		"0",     // out of range, asked again
+		"42",    // sliceID
+		"maybe", // not a yes or no, asked again
+		"y",     // sliceDescription
+		"gold",
+		"qosBased",
+		"n", // weight
+		"y", // qosLevel
+		"7",
+		"",        // scheduleInfo
+		"ulSlice", // sliceType
+	}, "\n") + "\n"
+	prompts := &bytes.Buffer{}
+	msg, err := buildPdu(sm, kind, strings.NewReader(answers), prompts)
+	assert.NilError(t, err, prompts.String())
+	assert.Assert(t, strings.Contains(prompts.String(), "sliceCreate.sliceID (INTEGER 1..4294967295) [1]: "), prompts.String())
+	assert.Assert(t, strings.Contains(prompts.String(), "  expected an integer in 1..4294967295\n"), prompts.String())
+	assert.Assert(t, strings.Contains(prompts.String(), "  expected y or n\n"), prompts.String())
+
+	create := msg.(*e2smrsm.E2SmRsmControlMessage).GetSliceCreate()
+	assert.Equal(t, int64(42), create.GetSliceId().GetValue())
+	assert.Equal(t, "gold", create.GetSliceDescription())
+	assert.Equal(t, e2smrsm.SchedulerType_SCHEDULER_TYPE_QOS_BASED, create.GetSliceConfigParameters().GetSchedulerType())
+	assert.Assert(t, create.GetSliceConfigParameters().Weight == nil)
+	assert.Equal(t, int32(7), create.GetSliceConfigParameters().GetQosLevel())
+	assert.Equal(t, e2smrsm.SliceType_SLICE_TYPE_UL_SLICE, create.GetSliceType())
+
+	_, err = buildPdu(sm, kind, strings.NewReader("sliceDelete\n"), prompts)
+	assert.ErrorContains(t, err, "unexpected end of input at sliceDelete.sliceID")
+}
+
+func TestBuildCmd(t *testing.T) {
+	cmd := getBuildCmd()
+	out, prompts := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(prompts)
+	cmd.SetIn(strings.NewReader(strings.Join([]string{
+		"", // controlHeader_Format1
+		"y",
+		"eUTRA_CGI",
+		"12f410",
+		"1010101111010100101111000000",
+		"", // rc_command
+		"y",
+		"1",
+	}, "\n") + "\n"))
+	cmd.SetArgs([]string{"-m", "e2sm_rc_pre/v2_go", "-t", "control-header"})
+	assert.NilError(t, cmd.Execute(), prompts.String())
+	lines := strings.SplitN(out.String(), "\n", 2)
+	assert.Equal(t, "3412f410abd4bc0001", lines[0])
+	assert.Assert(t, strings.Contains(lines[1], `"eUTRA_CGI"`), lines[1])
+}
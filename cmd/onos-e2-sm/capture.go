@@ -0,0 +1,414 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+)
+
+// The capture files are read without any packet library: pcap and pcapng files, the Ethernet, Linux cooked,
+// null and raw IP link types, IPv4 (not fragmented) and IPv6, and the DATA chunks of SCTP.
+
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLoop     = 108
+	linkTypeSll      = 113
+	linkTypeIPv4     = 228
+	linkTypeIPv6     = 229
+	linkTypeSll2     = 276
+
+	ipProtocolSctp = 132
+
+	sctpChunkData = 0
+)
+
+// packet is a packet of a capture file
+type packet struct {
+	Number   int
+	Time     time.Time
+	LinkType uint16
+	Data     []byte
+}
+
+// readCapture reads the packets of a pcap or pcapng file
+func readCapture(data []byte) ([]packet, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("not a pcap or pcapng file")
+	}
+	switch {
+	case bytes.Equal(data[:4], []byte{0x0a, 0x0d, 0x0d, 0x0a}):
+		return readPcapng(data)
+	case binary.LittleEndian.Uint32(data) == 0xa1b2c3d4 || binary.LittleEndian.Uint32(data) == 0xa1b23c4d:
+		return readPcap(data, binary.LittleEndian)
+	case binary.BigEndian.Uint32(data) == 0xa1b2c3d4 || binary.BigEndian.Uint32(data) == 0xa1b23c4d:
+		return readPcap(data, binary.BigEndian)
+	}
+	return nil, fmt.Errorf("not a pcap or pcapng file (magic number %x)", data[:4])
+}
+
+func readPcap(data []byte, order binary.ByteOrder) ([]packet, error) {
+	if len(data) < 24 {
+		return nil, fmt.Errorf("truncated pcap header")
+	}
+	nanoseconds := order.Uint32(data) == 0xa1b23c4d
+	linkType := uint16(order.Uint32(data[20:]))
+	packets := make([]packet, 0)
+	for offset := 24; offset < len(data); {
+		if offset+16 > len(data) {
+			return packets, fmt.Errorf("truncated pcap record header at offset %d", offset)
+		}
+		seconds, fraction := order.Uint32(data[offset:]), order.Uint32(data[offset+4:])
+		length := int(order.Uint32(data[offset+8:]))
+		offset += 16
+		if offset+length > len(data) {
+			return packets, fmt.Errorf("truncated pcap record at offset %d", offset)
+		}
+		if !nanoseconds {
+			fraction *= 1000
+		}
+		packets = append(packets, packet{
+			Number:   len(packets) + 1,
+			Time:     time.Unix(int64(seconds), int64(fraction)).UTC(),
+			LinkType: linkType,
+			Data:     data[offset : offset+length],
+		})
+		offset += length
+	}
+	return packets, nil
+}
+
+// pcapngInterface is an interface of a pcapng section
+type pcapngInterface struct {
+	LinkType uint16
+	// Resolution is the duration of a timestamp unit
+	Resolution time.Duration
+	// Units is the number of timestamp units per second, when it is not a whole number of nanoseconds
+	Units uint64
+}
+
+func readPcapng(data []byte) ([]packet, error) {
+	var order binary.ByteOrder = binary.LittleEndian
+	interfaces := make([]pcapngInterface, 0)
+	packets := make([]packet, 0)
+	for offset := 0; offset < len(data); {
+		if offset+12 > len(data) {
+			return packets, fmt.Errorf("truncated pcapng block at offset %d", offset)
+		}
+		blockType := order.Uint32(data[offset:])
+		if blockType == 0x0a0d0d0a {
+			// The section header defines the byte order of the section
+			if binary.BigEndian.Uint32(data[offset+8:]) == 0x1a2b3c4d {
+				order = binary.BigEndian
+			} else {
+				order = binary.LittleEndian
+			}
+			interfaces = interfaces[:0]
+		}
+		length := int(order.Uint32(data[offset+4:]))
+		if length < 12 || offset+length > len(data) {
+			return packets, fmt.Errorf("invalid pcapng block length %d at offset %d", length, offset)
+		}
+		body := data[offset+8 : offset+length-4]
+		offset += length
+
+		switch blockType {
+		case 1: // Interface description
+			if len(body) < 8 {
+				return packets, fmt.Errorf("truncated pcapng interface description")
+			}
+			iface := pcapngInterface{LinkType: order.Uint16(body), Resolution: time.Microsecond}
+			for options := body[8:]; len(options) >= 4; {
+				code, optionLength := order.Uint16(options), int(order.Uint16(options[2:]))
+				if 4+optionLength > len(options) {
+					break
+				}
+				if code == 9 && optionLength >= 1 { // if_tsresol
+					iface.Resolution, iface.Units = parseTimestampResolution(options[4])
+				}
+				options = options[4+(optionLength+3)/4*4:]
+			}
+			interfaces = append(interfaces, iface)
+		case 6: // Enhanced packet
+			if len(body) < 20 {
+				return packets, fmt.Errorf("truncated pcapng enhanced packet")
+			}
+			id := int(order.Uint32(body))
+			if id >= len(interfaces) {
+				return packets, fmt.Errorf("pcapng packet of unknown interface %d", id)
+			}
+			timestamp := uint64(order.Uint32(body[4:]))<<32 | uint64(order.Uint32(body[8:]))
+			captured := int(order.Uint32(body[12:]))
+			if 20+captured > len(body) {
+				return packets, fmt.Errorf("truncated pcapng enhanced packet")
+			}
+			packets = append(packets, packet{
+				Number:   len(packets) + 1,
+				Time:     interfaces[id].time(timestamp),
+				LinkType: interfaces[id].LinkType,
+				Data:     body[20 : 20+captured],
+			})
+		case 3: // Simple packet, of the first interface
+			if len(body) < 4 || len(interfaces) == 0 {
+				return packets, fmt.Errorf("invalid pcapng simple packet")
+			}
+			packets = append(packets, packet{
+				Number:   len(packets) + 1,
+				LinkType: interfaces[0].LinkType,
+				Data:     body[4:],
+			})
+		}
+	}
+	return packets, nil
+}
+
+// parseTimestampResolution parses the if_tsresol option: a power of 10, or of 2 if the high bit is set
+func parseTimestampResolution(value byte) (time.Duration, uint64) {
+	exponent := uint(value & 0x7f)
+	if value&0x80 != 0 {
+		return 0, 1 << exponent
+	}
+	resolution := time.Second
+	for i := uint(0); i < exponent && resolution > 1; i++ {
+		resolution /= 10
+	}
+	return resolution, 0
+}
+
+func (i pcapngInterface) time(timestamp uint64) time.Time {
+	if i.Units != 0 {
+		seconds := timestamp / i.Units
+		return time.Unix(int64(seconds), int64((timestamp%i.Units)*uint64(time.Second)/i.Units)).UTC()
+	}
+	units := uint64(time.Second / i.Resolution)
+	return time.Unix(int64(timestamp/units), int64(timestamp%units)*int64(i.Resolution)).UTC()
+}
+
+// ipPacket is the SCTP payload of an IP packet
+type ipPacket struct {
+	Src     net.IP
+	Dst     net.IP
+	Payload []byte
+}
+
+// errNotSctp is returned for the packets which do not carry SCTP
+var errNotSctp = fmt.Errorf("not an SCTP packet")
+
+// decodeLink returns the SCTP payload of a captured packet
+func decodeLink(p packet) (ipPacket, error) {
+	data := p.Data
+	var etherType uint16
+	switch p.LinkType {
+	case linkTypeEthernet:
+		if len(data) < 14 {
+			return ipPacket{}, fmt.Errorf("truncated Ethernet frame")
+		}
+		etherType, data = binary.BigEndian.Uint16(data[12:]), data[14:]
+		for (etherType == 0x8100 || etherType == 0x88a8) && len(data) >= 4 {
+			etherType, data = binary.BigEndian.Uint16(data[2:]), data[4:]
+		}
+	case linkTypeSll:
+		if len(data) < 16 {
+			return ipPacket{}, fmt.Errorf("truncated Linux cooked header")
+		}
+		etherType, data = binary.BigEndian.Uint16(data[14:]), data[16:]
+	case linkTypeSll2:
+		if len(data) < 20 {
+			return ipPacket{}, fmt.Errorf("truncated Linux cooked v2 header")
+		}
+		etherType, data = binary.BigEndian.Uint16(data), data[20:]
+	case linkTypeNull, linkTypeLoop:
+		if len(data) < 4 {
+			return ipPacket{}, fmt.Errorf("truncated loopback header")
+		}
+		data = data[4:]
+	case linkTypeRaw, linkTypeIPv4, linkTypeIPv6:
+	default:
+		return ipPacket{}, fmt.Errorf("unsupported link type %d", p.LinkType)
+	}
+	if len(data) == 0 {
+		return ipPacket{}, errNotSctp
+	}
+	switch {
+	case etherType == 0x0800 || (etherType == 0 && data[0]>>4 == 4):
+		return decodeIPv4(data)
+	case etherType == 0x86dd || (etherType == 0 && data[0]>>4 == 6):
+		return decodeIPv6(data)
+	}
+	return ipPacket{}, errNotSctp
+}
+
+func decodeIPv4(data []byte) (ipPacket, error) {
+	if len(data) < 20 {
+		return ipPacket{}, fmt.Errorf("truncated IPv4 header")
+	}
+	headerLength := int(data[0]&0x0f) * 4
+	totalLength := int(binary.BigEndian.Uint16(data[2:]))
+	if headerLength < 20 || totalLength < headerLength || totalLength > len(data) {
+		return ipPacket{}, fmt.Errorf("invalid IPv4 header")
+	}
+	if data[9] != ipProtocolSctp {
+		return ipPacket{}, errNotSctp
+	}
+	if fragment := binary.BigEndian.Uint16(data[6:]); fragment&0x3fff != 0 {
+		return ipPacket{}, fmt.Errorf("fragmented IPv4 packets are not supported")
+	}
+	return ipPacket{Src: net.IP(data[12:16]), Dst: net.IP(data[16:20]), Payload: data[headerLength:totalLength]}, nil
+}
+
+func decodeIPv6(data []byte) (ipPacket, error) {
+	if len(data) < 40 {
+		return ipPacket{}, fmt.Errorf("truncated IPv6 header")
+	}
+	payloadLength := int(binary.BigEndian.Uint16(data[4:]))
+	if 40+payloadLength > len(data) {
+		return ipPacket{}, fmt.Errorf("truncated IPv6 packet")
+	}
+	src, dst := net.IP(data[8:24]), net.IP(data[24:40])
+	next, payload := data[6], data[40:40+payloadLength]
+	for {
+		switch next {
+		case ipProtocolSctp:
+			return ipPacket{Src: src, Dst: dst, Payload: payload}, nil
+		case 0, 43, 60: // Hop-by-hop, routing and destination options
+			if len(payload) < 8 || len(payload) < 8+int(payload[1])*8 {
+				return ipPacket{}, fmt.Errorf("truncated IPv6 extension header")
+			}
+			next, payload = payload[0], payload[8+int(payload[1])*8:]
+		case 44:
+			return ipPacket{}, fmt.Errorf("fragmented IPv6 packets are not supported")
+		default:
+			return ipPacket{}, errNotSctp
+		}
+	}
+}
+
+// sctpMessage is a user message reassembled from SCTP DATA chunks
+type sctpMessage struct {
+	// Packet is the number of the packet of the last chunk of the message
+	Packet  int
+	Time    time.Time
+	Src     string
+	Dst     string
+	Stream  uint16
+	PPID    uint32
+	Payload []byte
+}
+
+// Association identifies the SCTP association of the message, the same in both directions
+func (m sctpMessage) Association() string {
+	if m.Src < m.Dst {
+		return m.Src + "-" + m.Dst
+	}
+	return m.Dst + "-" + m.Src
+}
+
+// sctpFragment is a DATA chunk of a fragmented user message
+type sctpFragment struct {
+	TSN   uint32
+	Begin bool
+	End   bool
+	Data  []byte
+}
+
+// sctpReassembler reassembles the user messages of the DATA chunks of SCTP packets. Retransmitted
+// chunks are dropped, and the fragments of a message are reassembled on their TSN.
+type sctpReassembler struct {
+	seen      map[string]bool
+	fragments map[string][]sctpFragment
+}
+
+func newSctpReassembler() *sctpReassembler {
+	return &sctpReassembler{
+		seen:      make(map[string]bool),
+		fragments: make(map[string][]sctpFragment),
+	}
+}
+
+// add adds the DATA chunks of an SCTP packet, and returns the user messages it completes
+func (r *sctpReassembler) add(p packet, ip ipPacket) ([]sctpMessage, error) {
+	if len(ip.Payload) < 12 {
+		return nil, fmt.Errorf("truncated SCTP common header")
+	}
+	src := net.JoinHostPort(ip.Src.String(), fmt.Sprint(binary.BigEndian.Uint16(ip.Payload)))
+	dst := net.JoinHostPort(ip.Dst.String(), fmt.Sprint(binary.BigEndian.Uint16(ip.Payload[2:])))
+
+	messages := make([]sctpMessage, 0)
+	for chunks := ip.Payload[12:]; len(chunks) >= 4; {
+		chunkType, flags := chunks[0], chunks[1]
+		length := int(binary.BigEndian.Uint16(chunks[2:]))
+		if length < 4 || length > len(chunks) {
+			return messages, fmt.Errorf("invalid SCTP chunk length %d", length)
+		}
+		chunk := chunks[:length]
+		if padded := (length + 3) / 4 * 4; padded < len(chunks) {
+			chunks = chunks[padded:]
+		} else {
+			chunks = nil
+		}
+		if chunkType != sctpChunkData {
+			continue
+		}
+		if len(chunk) < 16 {
+			return messages, fmt.Errorf("truncated SCTP DATA chunk")
+		}
+		tsn := binary.BigEndian.Uint32(chunk[4:])
+		stream := binary.BigEndian.Uint16(chunk[8:])
+		ppid := binary.BigEndian.Uint32(chunk[12:])
+		data := chunk[16:]
+
+		key := fmt.Sprintf("%s>%s/%d", src, dst, tsn)
+		if r.seen[key] {
+			continue
+		}
+		r.seen[key] = true
+
+		message := sctpMessage{Packet: p.Number, Time: p.Time, Src: src, Dst: dst, Stream: stream, PPID: ppid}
+		begin, end := flags&0x02 != 0, flags&0x01 != 0
+		if begin && end {
+			message.Payload = data
+			messages = append(messages, message)
+			continue
+		}
+		streamKey := fmt.Sprintf("%s>%s/%d", src, dst, stream)
+		fragments := append(r.fragments[streamKey], sctpFragment{TSN: tsn, Begin: begin, End: end, Data: data})
+		payload, remaining := reassemble(fragments)
+		r.fragments[streamKey] = remaining
+		if payload != nil {
+			message.Payload = payload
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// reassemble returns the payload of the first message of which all the fragments are present,
+// and the fragments of the other messages
+func reassemble(fragments []sctpFragment) ([]byte, []sctpFragment) {
+	sort.Slice(fragments, func(i, j int) bool {
+		// Serial number arithmetic, the TSNs wrap around
+		return int32(fragments[i].TSN-fragments[j].TSN) < 0
+	})
+	for first := range fragments {
+		if !fragments[first].Begin {
+			continue
+		}
+		payload := append([]byte{}, fragments[first].Data...)
+		for last := first + 1; last < len(fragments) && fragments[last].TSN == fragments[last-1].TSN+1 && !fragments[last].Begin; last++ {
+			payload = append(payload, fragments[last].Data...)
+			if fragments[last].End {
+				remaining := append(append([]sctpFragment{}, fragments[:first]...), fragments[last+1:]...)
+				return payload, remaining
+			}
+		}
+	}
+	return nil, fragments
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"unicode"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	formatAper = "aper"
+	formatXer  = "xer"
+	formatJSON = "json"
+)
+
+var formats = []string{formatAper, formatXer, formatJSON}
+
+// readInput reads the file at path, or the standard input if path is "-"
+func readInput(cmd *cobra.Command, path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(cmd.InOrStdin())
+	}
+	return ioutil.ReadFile(path)
+}
+
+// parseHex decodes hexadecimal bytes, ignoring whitespaces and an optional 0x prefix
+func parseHex(data []byte) ([]byte, error) {
+	text := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, string(data))
+	return hex.DecodeString(strings.TrimPrefix(text, "0x"))
+}
+
+// unmarshalPdu decodes a PDU in the given format into its protobuf message.
+// APER is read as hexadecimal, unless raw is set.
+func unmarshalPdu(sm codec.ServiceModel, kind codec.PduKind, format string, data []byte, raw bool) (proto.Message, error) {
+	switch format {
+	case formatAper:
+		if !raw {
+			asn1Bytes, err := parseHex(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hexadecimal APER: %v", err)
+			}
+			data = asn1Bytes
+		}
+		return decodePdu(sm, kind, data)
+	case formatXer:
+		msg, err := newPduMessage(sm, kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := xerDecode(data, msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	case formatJSON:
+		msg, err := newPduMessage(sm, kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := protojson.Unmarshal(data, msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(formats, ", "))
+}
+
+// marshalPdu encodes the protobuf message of a PDU in the given format.
+// APER is written as hexadecimal, unless raw is set.
+func marshalPdu(sm codec.ServiceModel, kind codec.PduKind, format string, msg proto.Message, raw bool) ([]byte, error) {
+	switch format {
+	case formatAper:
+		asn1Bytes, err := encodePdu(sm, kind, msg)
+		if err != nil {
+			return nil, err
+		}
+		if raw {
+			return asn1Bytes, nil
+		}
+		return []byte(hex.EncodeToString(asn1Bytes) + "\n"), nil
+	case formatXer:
+		return xerEncode(msg, getASN1TypeName(sm, kind))
+	case formatJSON:
+		jsonBytes, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		return append(jsonBytes, '\n'), nil
+	}
+	return nil, fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(formats, ", "))
+}
+
+func getConvertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "convert <file>",
+		Short: "Converts a PDU of a service model between APER, XER and JSON",
+		Long: `Converts a PDU of a service model between APER (hexadecimal, or binary with --raw), XER and
+the protobuf JSON encoding, using the codecs of the service models compiled into the CLI. The file
+is read from the standard input if it is "-".
+
+XER is derived from the protobuf messages of the service model: the ASN.1 identifiers are their
+JSON names, with '-' in place of '_', and the SEQUENCE OF items are named after the ASN.1 types of
+the .proto files. It reads the XER of the CGo service models, whose type names may have a suffix,
+e.g. -KPMv2. The ENUMERATED values it writes are derived from the protobuf enum values, and may differ
+from the identifiers of the ASN.1 module.`,
+		Example: `  onos-e2-sm convert --model e2sm_rsm/v1_go --type indication-message --from aper --to json message.hex
+  onos-e2-sm convert --model e2sm_kpm/v2_go --type indication-header --from xer --to aper header.xml`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modelFlag, _ := cmd.Flags().GetString("model")
+			typeFlag, _ := cmd.Flags().GetString("type")
+			from, _ := cmd.Flags().GetString("from")
+			to, _ := cmd.Flags().GetString("to")
+			raw, _ := cmd.Flags().GetBool("raw")
+
+			sm, err := codec.GetServiceModel(modelFlag)
+			if err != nil {
+				return err
+			}
+			kind, err := codec.GetPduKind(typeFlag)
+			if err != nil {
+				return err
+			}
+			data, err := readInput(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			msg, err := unmarshalPdu(sm, kind, from, data, raw)
+			if err != nil {
+				return fmt.Errorf("cannot decode the %s %s: %v", from, kind.Name, err)
+			}
+			out, err := marshalPdu(sm, kind, to, msg, raw)
+			if err != nil {
+				return fmt.Errorf("cannot encode the %s in %s: %v", kind.Name, to, err)
+			}
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+	cmd.Flags().StringP("model", "m", "", "the service model, as listed by the models command")
+	cmd.Flags().StringP("type", "t", "", "the PDU kind, e.g. indication-message")
+	cmd.Flags().String("from", formatAper, "the input format: "+strings.Join(formats, ", "))
+	cmd.Flags().String("to", formatJSON, "the output format: "+strings.Join(formats, ", "))
+	cmd.Flags().Bool("raw", false, "read and write APER as binary instead of hexadecimal")
+	_ = cmd.MarkFlagRequired("model")
+	_ = cmd.MarkFlagRequired("type")
+	return cmd
+}
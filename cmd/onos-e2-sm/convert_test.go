@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"gotest.tools/assert"
+)
+
+// An indication header as encoded by the XER encoder of the CGo e2sm_kpm_v2
+const kpmIndicationHeaderXer = `<E2SM-KPM-IndicationHeader>
+    <indicationHeader-formats>
+        <indicationHeader-Format1>
+            <colletStartTime>21 22 23 24</colletStartTime>
+            <fileFormatversion>txt</fileFormatversion>
+            <senderName>ONF</senderName>
+            <senderType>someType</senderType>
+            <vendorName>onf</vendorName>
+            <kpmNodeID>
+                <gNB>
+                    <global-gNB-ID>
+                        <plmn-id>37 34 37</plmn-id>
+                        <gnb-id>
+                            <gnb-ID>
+                                1101010010111100000010
+                            </gnb-ID>
+                        </gnb-id>
+                    </global-gNB-ID>
+                    <gNB-CU-UP-ID>12345</gNB-CU-UP-ID>
+                    <gNB-DU-ID>6789</gNB-DU-ID>
+                </gNB>
+            </kpmNodeID>
+        </indicationHeader-Format1>
+    </indicationHeader-formats>
+</E2SM-KPM-IndicationHeader>`
+
+func TestConvertRoundTrip(t *testing.T) {
+	for _, sm := range codec.ServiceModels {
+		for _, kind := range codec.PduKinds {
+			vector, ok := getPduVector(string(sm.ServiceModelData().OID), kind)
+			if !ok {
+				continue
+			}
+			msg, err := unmarshalPdu(sm, kind, formatAper, []byte(hex.EncodeToString(vector)), false)
+			assert.NilError(t, err, "%s %s", codec.ModelName(sm), kind.Name)
+			for _, format := range []string{formatXer, formatJSON} {
+				data, err := marshalPdu(sm, kind, format, msg, false)
+				assert.NilError(t, err, "%s %s", codec.ModelName(sm), kind.Name)
+				decoded, err := unmarshalPdu(sm, kind, format, data, false)
+				assert.NilError(t, err, "%s %s %s:\n%s", codec.ModelName(sm), kind.Name, format, data)
+				asn1Bytes, err := marshalPdu(sm, kind, formatAper, decoded, true)
+				assert.NilError(t, err, "%s %s %s", codec.ModelName(sm), kind.Name, format)
+				assert.DeepEqual(t, vector, asn1Bytes)
+			}
+		}
+	}
+}
+
+func TestConvertCmd(t *testing.T) {
+	cmd := getConvertCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader(kpmIndicationHeaderXer))
+	cmd.SetArgs([]string{"--model", "e2sm_kpm/v2_go", "--type", "indication-header", "--from", "xer", "--to", "aper", "-"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, "1f21222324187478740000034f4e4640736f6d6554797065066f6e660c37343700d4bc08803039201a85\n", out.String())
+
+	cmd = getConvertCmd()
+	out.Reset()
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader("1f21222324187478740000034f4e4640736f6d6554797065066f6e660c37343700d4bc08803039201a85"))
+	cmd.SetArgs([]string{"-m", "e2sm_kpm_v2_go.so.2.0", "-t", "indication-header", "--to", "xer", "-"})
+	assert.NilError(t, cmd.Execute())
+	assert.Assert(t, strings.HasPrefix(out.String(), "<E2SM-KPM-IndicationHeader>\n    <indicationHeader-formats>\n"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "<plmn-id>37 34 37</plmn-id>"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "<gnb-ID>1101010010111100000010</gnb-ID>"), out.String())
+
+	cmd = getConvertCmd()
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader("00"))
+	cmd.SetArgs([]string{"-m", "e2sm_kpm/v2_go", "-t", "control-header", "-"})
+	assert.ErrorContains(t, cmd.Execute(), "e2sm_kpm/v2_go does not implement the control-header PDU")
+}
@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// differenceKind is the kind of a difference between two PDUs
+type differenceKind string
+
+const (
+	changed  differenceKind = "~"
+	added    differenceKind = "+"
+	removed  differenceKind = "-"
+	switched differenceKind = "*"
+)
+
+// difference is a difference between two PDUs at a field path, e.g. measData[0].measRecord[1].integer
+type difference struct {
+	Kind differenceKind
+	Path string
+	Old  string
+	New  string
+}
+
+func (d difference) String() string {
+	switch d.Kind {
+	case added:
+		return fmt.Sprintf("%s %s: %s", d.Kind, d.Path, d.New)
+	case removed:
+		return fmt.Sprintf("%s %s: %s", d.Kind, d.Path, d.Old)
+	case switched:
+		return fmt.Sprintf("%s %s: CHOICE %s -> %s", d.Kind, d.Path, d.Old, d.New)
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", d.Kind, d.Path, d.Old, d.New)
+	}
+}
+
+// diffPdus returns the differences between two messages, the paths being made of the ASN.1 identifiers
+func diffPdus(a, b proto.Message) []difference {
+	return diffMessages("", a.ProtoReflect(), b.ProtoReflect())
+}
+
+func diffMessages(path string, a, b protoreflect.Message) []difference {
+	desc := a.Descriptor()
+	if isLeaf(desc) {
+		if oldValue, newValue := formatMessage(a), formatMessage(b); oldValue != newValue {
+			return []difference{{Kind: changed, Path: path, Old: oldValue, New: newValue}}
+		}
+		return nil
+	}
+	if isSequenceOf(desc) {
+		fd := desc.Fields().Get(0)
+		return diffLists(path, fd, a.Get(fd).List(), b.Get(fd).List())
+	}
+
+	differences := make([]difference, 0)
+	diffedOneofs := make(map[protoreflect.Name]bool)
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		oneof := fd.ContainingOneof()
+		// The optional fields of proto3 are in a synthetic oneof of their own
+		if oneof == nil || oneof.IsSynthetic() {
+			differences = append(differences, diffFields(joinPath(path, asn1Identifier(fd)), a, b, fd)...)
+			continue
+		}
+		if diffedOneofs[oneof.Name()] {
+			continue
+		}
+		diffedOneofs[oneof.Name()] = true
+		fa, fb := a.WhichOneof(oneof), b.WhichOneof(oneof)
+		switch {
+		case fa == nil && fb == nil:
+		case fa == nil:
+			differences = append(differences, difference{Kind: added, Path: joinPath(path, asn1Identifier(fb)), New: formatField(b, fb)})
+		case fb == nil:
+			differences = append(differences, difference{Kind: removed, Path: joinPath(path, asn1Identifier(fa)), Old: formatField(a, fa)})
+		case fa != fb:
+			differences = append(differences, difference{Kind: switched, Path: choicePath(path), Old: asn1Identifier(fa), New: asn1Identifier(fb)})
+		default:
+			differences = append(differences, diffFields(joinPath(path, asn1Identifier(fa)), a, b, fa)...)
+		}
+	}
+	return differences
+}
+
+func diffFields(path string, a, b protoreflect.Message, fd protoreflect.FieldDescriptor) []difference {
+	if fd.IsList() {
+		return diffLists(path, fd, a.Get(fd).List(), b.Get(fd).List())
+	}
+	if fd.HasPresence() {
+		switch {
+		case !a.Has(fd) && !b.Has(fd):
+			return nil
+		case !a.Has(fd):
+			return []difference{{Kind: added, Path: path, New: formatField(b, fd)}}
+		case !b.Has(fd):
+			return []difference{{Kind: removed, Path: path, Old: formatField(a, fd)}}
+		}
+	}
+	if fd.Kind() == protoreflect.MessageKind {
+		return diffMessages(path, a.Get(fd).Message(), b.Get(fd).Message())
+	}
+	if oldValue, newValue := formatField(a, fd), formatField(b, fd); oldValue != newValue {
+		return []difference{{Kind: changed, Path: path, Old: oldValue, New: newValue}}
+	}
+	return nil
+}
+
+// diffLists aligns the items of two lists on their longest common subsequence: the items in between
+// are compared pairwise, and the remaining ones are added or removed
+func diffLists(path string, fd protoreflect.FieldDescriptor, a, b protoreflect.List) []difference {
+	equal := func(i, j int) bool {
+		if fd.Kind() == protoreflect.MessageKind {
+			return proto.Equal(a.Get(i).Message().Interface(), b.Get(j).Message().Interface())
+		}
+		return formatValue(fd, a.Get(i)) == formatValue(fd, b.Get(j))
+	}
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, a.Len()+1)
+	for i := range lengths {
+		lengths[i] = make([]int, b.Len()+1)
+	}
+	for i := a.Len() - 1; i >= 0; i-- {
+		for j := b.Len() - 1; j >= 0; j-- {
+			if equal(i, j) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	differences := make([]difference, 0)
+	unmatchedA, unmatchedB := make([]int, 0), make([]int, 0)
+	flush := func() {
+		n := len(unmatchedA)
+		if len(unmatchedB) < n {
+			n = len(unmatchedB)
+		}
+		for k := 0; k < n; k++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, unmatchedB[k])
+			if fd.Kind() == protoreflect.MessageKind {
+				differences = append(differences, diffMessages(itemPath, a.Get(unmatchedA[k]).Message(), b.Get(unmatchedB[k]).Message())...)
+			} else {
+				differences = append(differences, difference{Kind: changed, Path: itemPath,
+					Old: formatValue(fd, a.Get(unmatchedA[k])), New: formatValue(fd, b.Get(unmatchedB[k]))})
+			}
+		}
+		for _, i := range unmatchedA[n:] {
+			differences = append(differences, difference{Kind: removed, Path: fmt.Sprintf("%s[%d]", path, i), Old: formatValue(fd, a.Get(i))})
+		}
+		for _, j := range unmatchedB[n:] {
+			differences = append(differences, difference{Kind: added, Path: fmt.Sprintf("%s[%d]", path, j), New: formatValue(fd, b.Get(j))})
+		}
+		unmatchedA, unmatchedB = unmatchedA[:0], unmatchedB[:0]
+	}
+	i, j := 0, 0
+	for i < a.Len() || j < b.Len() {
+		switch {
+		case i < a.Len() && j < b.Len() && equal(i, j):
+			flush()
+			i++
+			j++
+		case j >= b.Len() || (i < a.Len() && lengths[i+1][j] >= lengths[i][j+1]):
+			unmatchedA = append(unmatchedA, i)
+			i++
+		default:
+			unmatchedB = append(unmatchedB, j)
+			j++
+		}
+	}
+	flush()
+	return differences
+}
+
+// isLeaf returns whether a message is compared as a single value, i.e. a BIT STRING or a defined type of one
+func isLeaf(desc protoreflect.MessageDescriptor) bool {
+	if isBitString(desc) {
+		return true
+	}
+	if !isDefinedType(desc) {
+		return false
+	}
+	fd := desc.Fields().Get(0)
+	return fd.Kind() != protoreflect.MessageKind || isLeaf(fd.Message())
+}
+
+// choicePath returns the path of a CHOICE, "." for the PDU itself
+func choicePath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatField(m protoreflect.Message, fd protoreflect.FieldDescriptor) string {
+	return formatValue(fd, m.Get(fd))
+}
+
+// formatValue formats a value as in XER for the leaves, and as compact JSON for the other messages
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return formatMessage(v.Message())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return enumIdentifier(fd.Enum(), ev)
+		}
+		return fmt.Sprint(v.Enum())
+	case protoreflect.BytesKind:
+		return formatOctetString(v.Bytes())
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	default:
+		return v.String()
+	}
+}
+
+func formatMessage(m protoreflect.Message) string {
+	desc := m.Descriptor()
+	switch {
+	case isBitString(desc):
+		return fmt.Sprintf("'%s'B", formatBitString(m))
+	case isDefinedType(desc):
+		return formatField(m, desc.Fields().Get(0))
+	}
+	jsonBytes, err := protojson.MarshalOptions{}.Marshal(m.Interface())
+	if err != nil {
+		return err.Error()
+	}
+	// protojson randomly adds spaces to its output, which must not be relied upon
+	return strings.Join(strings.Fields(string(jsonBytes)), " ")
+}
+
+// writeDifferences prints the differences, one per line
+func writeDifferences(out io.Writer, differences []difference) error {
+	for _, d := range differences {
+		if _, err := fmt.Fprintln(out, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Decodes two PDUs of a service model and prints their differences field by field",
+		Long: `Decodes two PDUs of a service model and prints their differences by field path, the path being made
+of the ASN.1 identifiers of the components and of the indexes of the SEQUENCE OF items:
+  ~ a value changed
+  + a component or a SEQUENCE OF item was added
+  - a component or a SEQUENCE OF item was removed
+  * another alternative of a CHOICE was chosen
+The items of SEQUENCE OF are aligned on the ones both PDUs have in common, so that an item inserted
+in a list is reported as such and not as a change of all the items following it.`,
+		Example:      `  onos-e2-sm diff --model e2sm_kpm/v2_go --type indication-message before.hex after.hex`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modelFlag, _ := cmd.Flags().GetString("model")
+			typeFlag, _ := cmd.Flags().GetString("type")
+			from, _ := cmd.Flags().GetString("from")
+			raw, _ := cmd.Flags().GetBool("raw")
+			exitCode, _ := cmd.Flags().GetBool("exit-code")
+
+			sm, err := codec.GetServiceModel(modelFlag)
+			if err != nil {
+				return err
+			}
+			kind, err := codec.GetPduKind(typeFlag)
+			if err != nil {
+				return err
+			}
+			pdus := make([]proto.Message, 0, len(args))
+			for _, path := range args {
+				data, err := readInput(cmd, path)
+				if err != nil {
+					return err
+				}
+				pdu, err := unmarshalPdu(sm, kind, from, data, raw)
+				if err != nil {
+					return fmt.Errorf("cannot decode the %s %s of %s: %v", from, kind.Name, path, err)
+				}
+				pdus = append(pdus, pdu)
+			}
+
+			differences := diffPdus(pdus[0], pdus[1])
+			if err := writeDifferences(cmd.OutOrStdout(), differences); err != nil {
+				return err
+			}
+			if exitCode && len(differences) > 0 {
+				return fmt.Errorf("%d differences", len(differences))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringP("model", "m", "", "the service model, as listed by the models command")
+	cmd.Flags().StringP("type", "t", "", "the PDU kind, e.g. indication-message")
+	cmd.Flags().String("from", formatAper, "the format of both PDUs: "+strings.Join(formats, ", "))
+	cmd.Flags().Bool("raw", false, "read APER as binary instead of hexadecimal")
+	cmd.Flags().Bool("exit-code", false, "fail if the PDUs differ")
+	_ = cmd.MarkFlagRequired("model")
+	_ = cmd.MarkFlagRequired("type")
+	return cmd
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestDiffPdus(t *testing.T) {
+	sm, err := codec.GetServiceModel("e2sm_kpm/v2_go")
+	assert.NilError(t, err)
+	kind, err := codec.GetPduKind("indication-message")
+	assert.NilError(t, err)
+	vector, ok := getPduVector(string(sm.ServiceModelData().OID), kind)
+	assert.Assert(t, ok)
+	before, err := decodePdu(sm, kind, vector)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(diffPdus(before, before)))
+
+	after := proto.Clone(before).(*e2smkpmv2.E2SmKpmIndicationMessage)
+	format1 := after.GetIndicationMessageFormats().GetIndicationMessageFormat1()
+	format1.GranulPeriod.Value = 42
+	records := format1.GetMeasData().GetValue()[0].GetMeasRecord()
+	records.Value = append([]*e2smkpmv2.MeasurementRecordItem{{
+		MeasurementRecordItem: &e2smkpmv2.MeasurementRecordItem_Integer{Integer: 7},
+	}}, records.Value...)
+	records.Value[2].MeasurementRecordItem = &e2smkpmv2.MeasurementRecordItem_Real{Real: 1.5}
+	format1.MeasData.Value[0].IncompleteFlag = nil
+
+	differences := diffPdus(before, after)
+	lines := make([]string, 0, len(differences))
+	for _, d := range differences {
+		lines = append(lines, d.String())
+	}
+	assert.DeepEqual(t, []string{
+		"~ indicationMessage-formats.indicationMessage-Format1.granulPeriod: 21 -> 42",
+		`+ indicationMessage-formats.indicationMessage-Format1.measData[0].measRecord[0]: {"integer":"7"}`,
+		"* indicationMessage-formats.indicationMessage-Format1.measData[0].measRecord[2]: CHOICE noValue -> real",
+		"- indicationMessage-formats.indicationMessage-Format1.measData[0].incompleteFlag: true",
+	}, lines)
+}
+
+func TestDiffCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onos-e2-sm-diff")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	a, b := filepath.Join(dir, "a.hex"), filepath.Join(dir, "b.hex")
+	assert.NilError(t, ioutil.WriteFile(a, []byte("2812f410abd4bc00\n"), 0644))
+	assert.NilError(t, ioutil.WriteFile(b, []byte("2812f410acd4bc00\n"), 0644))
+
+	cmd := getDiffCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"-m", "e2sm_rc_pre/v2_go", "-t", "indication-header", a, b})
+	assert.NilError(t, cmd.Execute())
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, 1, len(lines), out.String())
+	assert.Assert(t, strings.HasPrefix(lines[0], "~ indicationHeader-Format1.cgi.eUTRA-CGI.eUTRACellIdentity: "), lines[0])
+
+	cmd = getDiffCmd()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"-m", "e2sm_rc_pre/v2_go", "-t", "indication-header", "--exit-code", a, b})
+	assert.ErrorContains(t, cmd.Execute(), "1 differences")
+}
@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+)
+
+// The E2AP is kept in onos-e2t: the PDUs are only decoded here as far as needed to extract the E2SM
+// octet strings of the RIC indications and controls, and the RAN functions of the E2 setups and
+// service updates. The other procedures and information elements are skipped.
+
+const (
+	e2apPPID = 70
+
+	e2apInitiatingMessage   = 0
+	e2apSuccessfulOutcome   = 1
+	e2apUnsuccessfulOutcome = 2
+
+	e2apProcedureE2setup          = 1
+	e2apProcedureRICcontrol       = 4
+	e2apProcedureRICindication    = 5
+	e2apProcedureRICserviceUpdate = 7
+
+	e2apIERANfunctionID        = 5
+	e2apIERANfunctionItem      = 8
+	e2apIERANfunctionsAdded    = 10
+	e2apIERANfunctionsModified = 12
+	e2apIERICcontrolHeader     = 22
+	e2apIERICcontrolMessage    = 23
+	e2apIERICindicationHeader  = 25
+	e2apIERICindicationMessage = 26
+	e2apIERICcontrolOutcome    = 32
+
+	e2apMaxRANfunctionOIDSize = 1000
+	e2apMaxOfRANfunctionID    = 256
+)
+
+var e2apProcedureNames = map[int]string{
+	e2apProcedureE2setup:          "E2setup",
+	2:                             "ErrorIndication",
+	3:                             "Reset",
+	e2apProcedureRICcontrol:       "RICcontrol",
+	e2apProcedureRICindication:    "RICindication",
+	6:                             "RICserviceQuery",
+	e2apProcedureRICserviceUpdate: "RICserviceUpdate",
+	8:                             "RICsubscription",
+	9:                             "RICsubscriptionDelete",
+}
+
+// e2apPdu is the part of an E2AP PDU the service models are concerned with
+type e2apPdu struct {
+	// Type is the alternative of the E2AP-PDU: initiatingMessage, successfulOutcome or unsuccessfulOutcome
+	Type          int
+	ProcedureCode int
+	// RANfunctionID is the RAN function of the RIC indications and controls
+	RANfunctionID *int
+	// E2SM are the E2SM octet strings of the PDU, by PDU kind
+	E2SM map[string][]byte
+	// RANfunctions are the RAN functions added or modified by an E2 setup or service update
+	RANfunctions []e2apRANfunction
+}
+
+// Procedure returns the name of the procedure of the PDU
+func (p e2apPdu) Procedure() string {
+	name, ok := e2apProcedureNames[p.ProcedureCode]
+	if !ok {
+		name = fmt.Sprintf("procedure-%d", p.ProcedureCode)
+	}
+	switch p.Type {
+	case e2apSuccessfulOutcome:
+		return name + "/successfulOutcome"
+	case e2apUnsuccessfulOutcome:
+		return name + "/unsuccessfulOutcome"
+	}
+	return name
+}
+
+// e2apRANfunction is a RANfunction-Item of an E2 setup or service update
+type e2apRANfunction struct {
+	ID         int
+	Definition []byte
+	Revision   int
+	// OID is the OID of the service model, from E2AP v2 on
+	OID string
+}
+
+// decodeE2ap decodes an APER encoded E2AP PDU
+func decodeE2ap(data []byte) (e2apPdu, error) {
+	r := &aperReader{data: data}
+	pdu := e2apPdu{E2SM: make(map[string][]byte)}
+	if extended, err := r.readBit(); err != nil {
+		return pdu, err
+	} else if extended {
+		return pdu, fmt.Errorf("unknown E2AP-PDU extension")
+	}
+	choice, err := r.readBits(2)
+	if err != nil {
+		return pdu, err
+	}
+	pdu.Type = int(choice)
+	if pdu.ProcedureCode, err = r.readConstrained(255); err != nil {
+		return pdu, err
+	}
+	if _, err := r.readBits(2); err != nil { // criticality
+		return pdu, err
+	}
+	value, err := r.readOpenType()
+	if err != nil {
+		return pdu, err
+	}
+
+	// All the procedures are sequences of a protocol IE container
+	r = &aperReader{data: value}
+	if _, err := r.readBit(); err != nil {
+		return pdu, err
+	}
+	count, err := r.readConstrained(65535)
+	if err != nil {
+		return pdu, err
+	}
+	for i := 0; i < count; i++ {
+		id, value, err := r.readProtocolIE()
+		if err != nil {
+			return pdu, fmt.Errorf("protocol IE %d: %v", i, err)
+		}
+		if err := pdu.decodeIE(id, value); err != nil {
+			return pdu, fmt.Errorf("protocol IE %d: %v", id, err)
+		}
+	}
+	return pdu, nil
+}
+
+func (p *e2apPdu) decodeIE(id int, value []byte) error {
+	r := &aperReader{data: value}
+	switch id {
+	case e2apIERANfunctionID:
+		if p.ProcedureCode != e2apProcedureRICindication && p.ProcedureCode != e2apProcedureRICcontrol {
+			return nil
+		}
+		ranFunctionID, err := r.readConstrained(4095)
+		if err != nil {
+			return err
+		}
+		p.RANfunctionID = &ranFunctionID
+	case e2apIERICindicationHeader, e2apIERICindicationMessage, e2apIERICcontrolHeader, e2apIERICcontrolMessage, e2apIERICcontrolOutcome:
+		if p.ProcedureCode != e2apProcedureRICindication && p.ProcedureCode != e2apProcedureRICcontrol {
+			return nil
+		}
+		octets, err := r.readOctetString()
+		if err != nil {
+			return err
+		}
+		p.E2SM[e2apPduKinds[id]] = octets
+	case e2apIERANfunctionsAdded, e2apIERANfunctionsModified:
+		if p.ProcedureCode != e2apProcedureE2setup && p.ProcedureCode != e2apProcedureRICserviceUpdate || p.Type != e2apInitiatingMessage {
+			return nil
+		}
+		ranFunctions, err := r.readRANfunctionsList()
+		if err != nil {
+			return err
+		}
+		p.RANfunctions = append(p.RANfunctions, ranFunctions...)
+	}
+	return nil
+}
+
+// e2apPduKinds are the E2SM PDU kinds of the E2AP information elements
+var e2apPduKinds = map[int]string{
+	e2apIERICindicationHeader:  "indication-header",
+	e2apIERICindicationMessage: "indication-message",
+	e2apIERICcontrolHeader:     "control-header",
+	e2apIERICcontrolMessage:    "control-message",
+	e2apIERICcontrolOutcome:    "control-outcome",
+}
+
+// readProtocolIE reads a ProtocolIE-Field, returning its id and the encoding of its value
+func (r *aperReader) readProtocolIE() (int, []byte, error) {
+	id, err := r.readConstrained(65535)
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := r.readBits(2); err != nil { // criticality
+		return 0, nil, err
+	}
+	value, err := r.readOpenType()
+	return id, value, err
+}
+
+// readRANfunctionsList reads a RANfunctions-List of single containers of RANfunction-Item
+func (r *aperReader) readRANfunctionsList() ([]e2apRANfunction, error) {
+	count, err := r.readConstrained(e2apMaxOfRANfunctionID - 1)
+	if err != nil {
+		return nil, err
+	}
+	ranFunctions := make([]e2apRANfunction, 0, count+1)
+	for i := 0; i <= count; i++ {
+		id, value, err := r.readProtocolIE()
+		if err != nil {
+			return nil, err
+		}
+		if id != e2apIERANfunctionItem {
+			continue
+		}
+		ranFunction, err := decodeRANfunctionItem(value)
+		if err != nil {
+			return nil, fmt.Errorf("RANfunction-Item %d: %v", i, err)
+		}
+		ranFunctions = append(ranFunctions, ranFunction)
+	}
+	return ranFunctions, nil
+}
+
+func decodeRANfunctionItem(data []byte) (e2apRANfunction, error) {
+	r := &aperReader{data: data}
+	var ranFunction e2apRANfunction
+	if _, err := r.readBit(); err != nil {
+		return ranFunction, err
+	}
+	var err error
+	if ranFunction.ID, err = r.readConstrained(4095); err != nil {
+		return ranFunction, err
+	}
+	if ranFunction.Definition, err = r.readOctetString(); err != nil {
+		return ranFunction, err
+	}
+	if ranFunction.Revision, err = r.readConstrained(4095); err != nil {
+		return ranFunction, err
+	}
+	// E2AP v1 ends here, v2 adds the ranFunctionOID PrintableString (SIZE(1..1000,...))
+	if r.remaining() > 8 {
+		if oid, err := r.readPrintableString(1, e2apMaxRANfunctionOIDSize); err == nil {
+			ranFunction.OID = oid
+		}
+	}
+	return ranFunction, nil
+}
+
+// aperReader reads the ALIGNED variant of PER, as far as E2AP uses it
+type aperReader struct {
+	data []byte
+	// bit is the offset of the next bit to read
+	bit int
+}
+
+func (r *aperReader) remaining() int {
+	return len(r.data)*8 - r.bit
+}
+
+func (r *aperReader) readBit() (bool, error) {
+	bit, err := r.readBits(1)
+	return bit == 1, err
+}
+
+func (r *aperReader) readBits(n int) (uint64, error) {
+	if n > r.remaining() {
+		return 0, fmt.Errorf("unexpected end of APER data at bit %d", r.bit)
+	}
+	var value uint64
+	for i := 0; i < n; i++ {
+		value = value<<1 | uint64(r.data[r.bit/8]>>(7-r.bit%8)&1)
+		r.bit++
+	}
+	return value, nil
+}
+
+func (r *aperReader) align() {
+	r.bit = (r.bit + 7) / 8 * 8
+}
+
+func (r *aperReader) readOctets(n int) ([]byte, error) {
+	r.align()
+	if n*8 > r.remaining() {
+		return nil, fmt.Errorf("unexpected end of APER data, %d octets expected at octet %d", n, r.bit/8)
+	}
+	octets := r.data[r.bit/8 : r.bit/8+n]
+	r.bit += n * 8
+	return octets, nil
+}
+
+// readConstrained reads a constrained whole number in 0..ub
+func (r *aperReader) readConstrained(ub int) (int, error) {
+	switch {
+	case ub < 255:
+		bits := 0
+		for ub>>bits != 0 {
+			bits++
+		}
+		value, err := r.readBits(bits)
+		return int(value), err
+	case ub == 255:
+		octets, err := r.readOctets(1)
+		if err != nil {
+			return 0, err
+		}
+		return int(octets[0]), nil
+	case ub < 65536:
+		octets, err := r.readOctets(2)
+		if err != nil {
+			return 0, err
+		}
+		return int(octets[0])<<8 | int(octets[1]), nil
+	}
+	return 0, fmt.Errorf("unsupported constrained whole number range 0..%d", ub)
+}
+
+// readLength reads an unconstrained length determinant. ok is false for the fragments of 16K items
+// which are followed by other fragments.
+func (r *aperReader) readLength() (n int, ok bool, err error) {
+	octets, err := r.readOctets(1)
+	if err != nil {
+		return 0, false, err
+	}
+	switch {
+	case octets[0]&0x80 == 0:
+		return int(octets[0]), true, nil
+	case octets[0]&0x40 == 0:
+		next, err := r.readOctets(1)
+		if err != nil {
+			return 0, false, err
+		}
+		return int(octets[0]&0x3f)<<8 | int(next[0]), true, nil
+	}
+	fragments := int(octets[0] & 0x3f)
+	if fragments < 1 || fragments > 4 {
+		return 0, false, fmt.Errorf("invalid APER length fragment %#x", octets[0])
+	}
+	return fragments * 16384, false, nil
+}
+
+// readOctetString reads an unconstrained OCTET STRING
+func (r *aperReader) readOctetString() ([]byte, error) {
+	value := make([]byte, 0)
+	for {
+		n, last, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		octets, err := r.readOctets(n)
+		if err != nil {
+			return nil, err
+		}
+		value = append(value, octets...)
+		if last {
+			return value, nil
+		}
+	}
+}
+
+// readOpenType reads the encoding of an open type value
+func (r *aperReader) readOpenType() ([]byte, error) {
+	return r.readOctetString()
+}
+
+// readPrintableString reads an extensible PrintableString (SIZE(lb..ub,...)), whose characters are octet aligned
+func (r *aperReader) readPrintableString(lb, ub int) (string, error) {
+	extended, err := r.readBit()
+	if err != nil {
+		return "", err
+	}
+	var n int
+	if extended {
+		length, last, err := r.readLength()
+		if err != nil {
+			return "", err
+		}
+		if !last {
+			return "", fmt.Errorf("fragmented PrintableString")
+		}
+		n = length
+	} else {
+		length, err := r.readConstrained(ub - lb)
+		if err != nil {
+			return "", err
+		}
+		n = length + lb
+	}
+	octets, err := r.readOctets(n)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range octets {
+		if c < 0x20 || c > 0x7e {
+			return "", fmt.Errorf("invalid PrintableString character %#x", c)
+		}
+	}
+	return string(octets), nil
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// testIE is a protocol IE of a test E2AP PDU
+type testIE struct {
+	ID    int
+	Value []byte
+}
+
+// aperLength encodes an unconstrained length determinant of less than 16K
+func aperLength(n int) []byte {
+	if n < 128 {
+		return []byte{byte(n)}
+	}
+	return []byte{0x80 | byte(n>>8), byte(n)}
+}
+
+func aperUint16(n int) []byte {
+	return []byte{byte(n >> 8), byte(n)}
+}
+
+func aperOctetString(octets []byte) []byte {
+	return append(aperLength(len(octets)), octets...)
+}
+
+// aperProtocolIE encodes a ProtocolIE-Field of criticality ignore
+func aperProtocolIE(ie testIE) []byte {
+	encoded := append(aperUint16(ie.ID), 0x40)
+	return append(encoded, aperOctetString(ie.Value)...)
+}
+
+// encodeTestE2ap encodes an E2AP PDU, e.g. a RIC indication, out of its protocol IEs
+func encodeTestE2ap(choice int, procedureCode int, ies ...testIE) []byte {
+	value := append([]byte{0x00}, aperUint16(len(ies))...)
+	for _, ie := range ies {
+		value = append(value, aperProtocolIE(ie)...)
+	}
+	pdu := []byte{byte(choice << 5), byte(procedureCode), 0x40}
+	return append(pdu, aperOctetString(value)...)
+}
+
+// encodeTestRANfunctions encodes a RANfunctions-List, the OID being left out if empty as in E2AP v1
+func encodeTestRANfunctions(ranFunctions ...e2apRANfunction) []byte {
+	list := []byte{byte(len(ranFunctions) - 1)}
+	for _, ranFunction := range ranFunctions {
+		item := append([]byte{0x00}, aperUint16(ranFunction.ID)...)
+		item = append(item, aperOctetString(ranFunction.Definition)...)
+		item = append(item, aperUint16(ranFunction.Revision)...)
+		if ranFunction.OID != "" {
+			item = append(item, 0x00)
+			item = append(item, aperUint16(len(ranFunction.OID)-1)...)
+			item = append(item, ranFunction.OID...)
+		}
+		list = append(list, aperProtocolIE(testIE{ID: e2apIERANfunctionItem, Value: item})...)
+	}
+	return list
+}
+
+func TestDecodeE2ap(t *testing.T) {
+	header, message := []byte{0x01, 0x02}, bytes.Repeat([]byte{0xab}, 300)
+	pdu, err := decodeE2ap(encodeTestE2ap(e2apInitiatingMessage, e2apProcedureRICindication,
+		testIE{ID: 29, Value: []byte{0x00, 0x01, 0x00, 0x02}},
+		testIE{ID: e2apIERANfunctionID, Value: aperUint16(1234)},
+		testIE{ID: e2apIERICindicationHeader, Value: aperOctetString(header)},
+		testIE{ID: e2apIERICindicationMessage, Value: aperOctetString(message)},
+	))
+	assert.NilError(t, err)
+	assert.Equal(t, "RICindication", pdu.Procedure())
+	assert.Equal(t, 1234, *pdu.RANfunctionID)
+	assert.DeepEqual(t, header, pdu.E2SM["indication-header"])
+	assert.DeepEqual(t, message, pdu.E2SM["indication-message"])
+
+	ranFunctions := []e2apRANfunction{
+		{ID: 1, Definition: []byte{0x01}, Revision: 2},
+		{ID: 4095, Definition: []byte{0x02, 0x03}, Revision: 1, OID: "1.3.6.1.4.1.53148.1.2.2.2"},
+	}
+	pdu, err = decodeE2ap(encodeTestE2ap(e2apInitiatingMessage, e2apProcedureE2setup,
+		testIE{ID: e2apIERANfunctionsAdded, Value: encodeTestRANfunctions(ranFunctions...)},
+	))
+	assert.NilError(t, err)
+	assert.Equal(t, "E2setup", pdu.Procedure())
+	assert.DeepEqual(t, ranFunctions, pdu.RANfunctions)
+
+	// The RAN functions accepted by the RIC are not the ones of the E2 node
+	pdu, err = decodeE2ap(encodeTestE2ap(e2apSuccessfulOutcome, e2apProcedureE2setup,
+		testIE{ID: 9, Value: []byte{0x00}},
+	))
+	assert.NilError(t, err)
+	assert.Equal(t, "E2setup/successfulOutcome", pdu.Procedure())
+	assert.Equal(t, 0, len(pdu.RANfunctions))
+
+	_, err = decodeE2ap(encodeTestE2ap(e2apInitiatingMessage, e2apProcedureRICindication,
+		testIE{ID: e2apIERICindicationHeader, Value: []byte{0x05, 0x01}},
+	))
+	assert.ErrorContains(t, err, "protocol IE 25: unexpected end of APER data")
+}
+
+func TestAperReader(t *testing.T) {
+	// A 16K fragment followed by the remaining octets
+	encoded := append([]byte{0xc1}, bytes.Repeat([]byte{0x01}, 16384)...)
+	encoded = append(encoded, 0x02, 0x03, 0x04)
+	r := &aperReader{data: encoded}
+	octets, err := r.readOctetString()
+	assert.NilError(t, err)
+	assert.Equal(t, 16386, len(octets))
+	assert.Equal(t, 0, r.remaining())
+
+	r = &aperReader{data: []byte{0xb4, 0x00, 0x0a}}
+	value, err := r.readConstrained(31)
+	assert.NilError(t, err)
+	assert.Equal(t, 22, value)
+	value, err = r.readConstrained(4095)
+	assert.NilError(t, err)
+	assert.Equal(t, 10, value)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build ignore
+// +build ignore
+
+// gen_asn1names generates asn1names.go, the ASN.1 type names of the messages and enums of the pure-Go service
+// models, out of the {Type-Name} comment preceding each of them in their .proto files:
+//
+//	go generate ./cmd/onos-e2-sm
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// protoFiles are the .proto files of the compiled in service models, relative to the servicemodels directory as
+// in the path of their file descriptor
+var protoFiles = []string{
+	"e2sm_kpm_v2_go/v2/e2sm_kpm_v2_go.proto",
+	"e2sm_mho_go/v2/e2sm_mho_go.proto",
+	"e2sm_rc_pre_go/v2/e2sm_rc_pre_v2_go.proto",
+	"e2sm_rsm/v1/e2sm_rsm_v1.proto",
+	"e2sm_rsm/v1/e2sm_v2.proto",
+}
+
+var (
+	typeNameRegexp = regexp.MustCompile(`^//\s*\{([^}]+)\}`)
+	typeRegexp     = regexp.MustCompile(`^(?:message|enum)\s+(\w+)\s*\{`)
+)
+
+func main() {
+	buf := &bytes.Buffer{}
+	fmt.Fprint(buf, `// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by gen_asn1names.go. DO NOT EDIT.
+
+package main
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// asn1TypeNames are the ASN.1 type names of the protobuf messages and enums of the service models, by .proto file
+var asn1TypeNames = map[string]map[protoreflect.Name]string{
+`)
+	for _, path := range protoFiles {
+		names, err := readTypeNames(filepath.Join("..", "..", "servicemodels", filepath.FromSlash(path)))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		protoNames := make([]string, 0, len(names))
+		for protoName := range names {
+			protoNames = append(protoNames, protoName)
+		}
+		sort.Strings(protoNames)
+		fmt.Fprintf(buf, "\t%q: {\n", path)
+		for _, protoName := range protoNames {
+			fmt.Fprintf(buf, "\t\t%q: %q,\n", protoName, names[protoName])
+		}
+		fmt.Fprint(buf, "\t},\n")
+	}
+	fmt.Fprint(buf, "}\n")
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile("asn1names.go", source, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// readTypeNames reads the ASN.1 type names of the top level messages and enums of a .proto file
+func readTypeNames(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	names := make(map[string]string)
+	typeName := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if match := typeNameRegexp.FindStringSubmatch(line); match != nil {
+			typeName = match[1]
+		} else if match := typeRegexp.FindStringSubmatch(line); match != nil {
+			// {-} marks the constants, which are not types
+			if typeName != "" && typeName != "-" {
+				names[match[1]] = typeName
+			}
+			typeName = ""
+		} else if !strings.HasPrefix(line, "//") {
+			typeName = ""
+		}
+	}
+	return names, scanner.Err()
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	types "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/onosproject/onos-e2-sm/pkg/pluginloader"
+	"github.com/spf13/cobra"
+)
+
+// smokeResult is the result of the smoke test of a PDU kind
+type smokeResult struct {
+	Kind   codec.PduKind
+	Status string
+	Failed bool
+}
+
+// pluginServiceModel is a service model plugin loaded by the plugin loader
+type pluginServiceModel struct {
+	plugin *pluginloader.Plugin
+}
+
+// loadPlugin starts the plugin loader at loaderPath, or the one found by pluginloader.FindLoader if empty, on a plugin
+func loadPlugin(loaderPath string, path string) (*pluginServiceModel, error) {
+	if loaderPath == "" {
+		var err error
+		if loaderPath, err = pluginloader.FindLoader(); err != nil {
+			return nil, err
+		}
+	}
+	p, err := pluginloader.Start(loaderPath, path)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginServiceModel{plugin: p}, nil
+}
+
+func (sm *pluginServiceModel) Close() error {
+	return sm.plugin.Close()
+}
+
+func (sm *pluginServiceModel) ServiceModelData() types.ServiceModelData {
+	data := sm.plugin.Data()
+	return types.ServiceModelData{
+		Name:       types.ShortName(data.Name),
+		Version:    types.Version(data.Version),
+		ModuleName: types.ModuleName(data.ModuleName),
+		OID:        types.OID(data.OID),
+	}
+}
+
+func (sm *pluginServiceModel) IndicationHeaderASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("IndicationHeaderASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) IndicationHeaderProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("IndicationHeaderProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) IndicationMessageASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("IndicationMessageASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) IndicationMessageProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("IndicationMessageProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) RanFuncDescriptionASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("RanFuncDescriptionASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) RanFuncDescriptionProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("RanFuncDescriptionProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) EventTriggerDefinitionASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("EventTriggerDefinitionASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) EventTriggerDefinitionProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("EventTriggerDefinitionProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) ActionDefinitionASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ActionDefinitionASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) ActionDefinitionProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ActionDefinitionProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) ControlHeaderASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ControlHeaderASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) ControlHeaderProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ControlHeaderProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) ControlMessageASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ControlMessageASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) ControlMessageProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ControlMessageProtoToASN1", protoBytes)
+}
+
+func (sm *pluginServiceModel) ControlOutcomeASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ControlOutcomeASN1toProto", asn1Bytes)
+}
+
+func (sm *pluginServiceModel) ControlOutcomeProtoToASN1(protoBytes []byte) ([]byte, error) {
+	return sm.plugin.Call("ControlOutcomeProtoToASN1", protoBytes)
+}
+
+// OnSetup is not called by the inspection, the loader only serves the codec methods
+func (sm *pluginServiceModel) OnSetup(request *types.OnSetupRequest) error {
+	return fmt.Errorf("OnSetup is not supported by %s", pluginloader.Command)
+}
+
+// smokeTest decodes and re-encodes the vector of each PDU kind the service model implements
+func smokeTest(sm codec.ServiceModel) []smokeResult {
+	oid := string(sm.ServiceModelData().OID)
+	results := make([]smokeResult, 0, len(codec.PduKinds))
+	for _, kind := range codec.PduKinds {
+		results = append(results, smokeTestPdu(sm, oid, kind))
+	}
+	return results
+}
+
+func smokeTestPdu(sm codec.ServiceModel, oid string, kind codec.PduKind) (result smokeResult) {
+	result.Kind = kind
+	support := getPduSupport(sm, kind)
+	if !support.Decode && !support.Encode {
+		result.Status = "not implemented"
+		return result
+	}
+	vector, ok := getPduVector(oid, kind)
+	if !ok {
+		result.Status = "no vector for " + oid
+		return result
+	}
+	if !support.Decode || !support.Encode {
+		result.Status = support.String()
+		result.Failed = true
+		return result
+	}
+
+	protoBytes, err := kind.Decode(sm, vector)
+	if err != nil {
+		result.Status = fmt.Sprintf("FAILED: decoding: %v", err)
+		result.Failed = true
+		return result
+	}
+	asn1Bytes, err := kind.Encode(sm, protoBytes)
+	if err != nil {
+		result.Status = fmt.Sprintf("FAILED: encoding: %v", err)
+		result.Failed = true
+		return result
+	}
+	if !bytes.Equal(vector, asn1Bytes) {
+		result.Status = fmt.Sprintf("FAILED: re-encoded %x, expected %x", asn1Bytes, vector)
+		result.Failed = true
+		return result
+	}
+	result.Status = "ok"
+	return result
+}
+
+// inspect prints the data of a service model and its smoke test, and returns whether every check passed.
+// moduleName is the expected module name, if any.
+func inspect(out io.Writer, sm codec.ServiceModel, moduleName string) (bool, error) {
+	passed := true
+	data := sm.ServiceModelData()
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "Name:\t%s\n", data.Name)
+	fmt.Fprintf(writer, "Version:\t%s\n", data.Version)
+	fmt.Fprintf(writer, "OID:\t%s\n", data.OID)
+	if moduleName != "" && string(data.ModuleName) != moduleName {
+		fmt.Fprintf(writer, "Module:\t%s (FAILED: expected %s)\n", data.ModuleName, moduleName)
+		passed = false
+	} else {
+		fmt.Fprintf(writer, "Module:\t%s\n", data.ModuleName)
+	}
+	fmt.Fprintln(writer, "PDUs:")
+	for _, result := range smokeTest(sm) {
+		fmt.Fprintf(writer, "  %s:\t%s\n", result.Kind.Name, result.Status)
+		if result.Failed {
+			passed = false
+		}
+	}
+	return passed, writer.Flush()
+}
+
+func getInspectPluginCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect-plugin <file.so>",
+		Short: "Loads a service model plugin, prints its data and smoke tests the PDUs it supports",
+		Long: `Loads a service model plugin as onos-e2t does, looks up its ServiceModel symbol and checks that it
+implements the service model interface. Then each supported PDU kind is decoded and re-encoded from
+a vector embedded in the CLI. The plugin is opened by the onos-e2-sm-plugin-loader command, which only
+links the standard library: it must be built with the same Go version as the plugin, e.g.
+go install github.com/onosproject/onos-e2-sm/cmd/onos-e2-sm-plugin-loader`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			moduleName, _ := cmd.Flags().GetString("module-name")
+			loaderPath, _ := cmd.Flags().GetString("loader")
+
+			sm, err := loadPlugin(loaderPath, path)
+			if err != nil {
+				return err
+			}
+			passed, err := inspect(cmd.OutOrStdout(), sm, moduleName)
+			if closeErr := sm.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			if !passed {
+				return fmt.Errorf("%s failed the inspection", path)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String("module-name", "", "the expected module name of the service model, not checked if not given")
+	cmd.Flags().String("loader", "", "the path of onos-e2-sm-plugin-loader (defaults to the one in the PATH or next to onos-e2-sm)")
+	return cmd
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/onosproject/onos-e2-sm/pkg/pluginloader"
+	"gotest.tools/assert"
+)
+
+func TestSmokeTest(t *testing.T) {
+	for _, sm := range codec.ServiceModels {
+		for _, result := range smokeTest(sm) {
+			assert.Assert(t, !result.Failed, "%s %s: %s", codec.ModelName(sm), result.Kind.Name, result.Status)
+			assert.Assert(t, !strings.HasPrefix(result.Status, "no vector"), "%s %s: %s", codec.ModelName(sm), result.Kind.Name, result.Status)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	sm, err := codec.GetServiceModel("e2sm_rc_pre/v2_go")
+	assert.NilError(t, err)
+
+	out := &bytes.Buffer{}
+	passed, err := inspect(out, sm, "")
+	assert.NilError(t, err)
+	assert.Assert(t, passed, out.String())
+	assert.Assert(t, strings.Contains(out.String(), "Module:   e2sm_rc_pre_v2_go.so.2.0\n"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "control-outcome:           ok"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "action-definition:         not implemented"), out.String())
+
+	out.Reset()
+	passed, err = inspect(out, sm, "e2sm_rc_pre_v2_go.so.2.0")
+	assert.NilError(t, err)
+	assert.Assert(t, passed, out.String())
+
+	out.Reset()
+	passed, err = inspect(out, sm, "e2sm_rc_pre_go.so.1.0.0")
+	assert.NilError(t, err)
+	assert.Assert(t, !passed)
+	assert.Assert(t, strings.Contains(out.String(), "FAILED: expected e2sm_rc_pre_go.so.1.0.0"), out.String())
+}
+
+// goBuild builds a package with the go command running the test, skipping the test if there is none
+func goBuild(t *testing.T, dir string, args ...string) {
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("no go command: %v", err)
+	}
+	cmd := exec.Command(goCmd, append([]string{"build"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	assert.NilError(t, err, string(out))
+}
+
+func TestInspectPlugin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a plugin")
+	}
+	dir := t.TempDir()
+	loader := filepath.Join(dir, pluginloader.Command)
+	goBuild(t, "../onos-e2-sm-plugin-loader", "-o", loader, ".")
+	_, err := loadPlugin(loader, "does-not-exist.so")
+	assert.ErrorContains(t, err, "cannot open does-not-exist.so")
+
+	plugin := filepath.Join(dir, "e2sm_rsm.so.1.0.0")
+	goBuild(t, "../../servicemodels/e2sm_rsm", "-buildmode=plugin", "-o", plugin, ".")
+	out := &bytes.Buffer{}
+	cmd := getInspectPluginCmd()
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"--loader", loader, plugin})
+	assert.NilError(t, cmd.Execute(), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "Module:   e2sm_rsm_v1_go.so.2.0\n"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "control-message:           ok"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "action-definition:         not implemented"), out.String())
+
+	cmd = getInspectPluginCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--loader", loader, "--module-name", "e2sm_rsm.so.1.0.0", plugin})
+	assert.ErrorContains(t, cmd.Execute(), "failed the inspection")
+}
@@ -35,6 +35,13 @@ func getCmd() *cobra.Command {
 		Use: "onos-e2-sm",
 	}
 	cmd.AddCommand(getGenDepsCmd())
+	cmd.AddCommand(getModelsCmd())
+	cmd.AddCommand(getInspectPluginCmd())
+	cmd.AddCommand(getConvertCmd())
+	cmd.AddCommand(getDiffCmd())
+	cmd.AddCommand(getPcapCmd())
+	cmd.AddCommand(getBuildCmd())
+	cmd.AddCommand(getServeCmd())
 	return cmd
 }
 
@@ -47,21 +54,56 @@ func getGenDepsCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			model := args[0]
 			target, _ := cmd.Flags().GetString("target")
+			offline, _ := cmd.Flags().GetBool("offline")
+			modDirs, _ := cmd.Flags().GetStringSlice("mod-dir")
+			vendorDirs, _ := cmd.Flags().GetStringSlice("vendor")
+			modCache, _ := cmd.Flags().GetString("mod-cache")
+			merge, _ := cmd.Flags().GetBool("merge")
+			write, _ := cmd.Flags().GetBool("write")
+			smDir, _ := cmd.Flags().GetString("sm-dir")
+			outDir, _ := cmd.Flags().GetString("out-dir")
+			failOnConflict, _ := cmd.Flags().GetBool("fail-on-conflict")
 
 			resolver := newModuleResolver()
+			resolver.offline = offline || len(modDirs) > 0 || len(vendorDirs) > 0
+			resolver.modDirs = modDirs
+			resolver.vendorDirs = vendorDirs
+			resolver.modCache = modCache
 			mod, err := resolver.resolve(model, target)
 			if err != nil {
 				return err
 			}
+			if merge || write {
+				if smDir == "" {
+					smDir = filepath.Join("servicemodels", model)
+				}
+				if outDir == "" {
+					outDir = filepath.Join("build", "_input", model)
+				}
+				if _, err := os.Stat(outDir); write && os.IsNotExist(err) {
+					return fmt.Errorf("%s does not exist, copy servicemodels/%s there first (see build/bin/build-deps) or pass --out-dir", outDir, model)
+				}
+				return resolver.merge(cmd, mod, smDir, outDir, write, failOnConflict)
+			}
 			bytes, err := mod.Format()
 			if err != nil {
 				return err
 			}
-			os.Stdout.Write(bytes)
+			cmd.OutOrStdout().Write(bytes)
 			return nil
 		},
 	}
 	cmd.Flags().StringP("target", "t", "", "the target Go module")
+	cmd.Flags().Bool("offline", false, "resolve the target go.mod from local directories and the module cache only, without network access")
+	cmd.Flags().StringSlice("mod-dir", nil, "directories containing the target module, either at their root or under its module path (implies --offline)")
+	cmd.Flags().StringSlice("vendor", nil, "vendor trees containing or recording the version of the target module (implies --offline)")
+	cmd.Flags().String("mod-cache", "", "the module cache to resolve the target go.mod from (defaults to GOMODCACHE)")
+	cmd.Flags().Bool("merge", false, "merge the requirements of the service model into the target go.mod and report the version conflicts")
+	cmd.Flags().Bool("write", false, "write the merged go.mod and go.sum into the service model directory instead of printing the go.mod (implies --merge)")
+	cmd.Flags().String("sm-dir", "", "the directory of the service model module to read the go.mod of (defaults to servicemodels/<model>)")
+	cmd.Flags().String("out-dir", "", "the directory to write the merged go.mod and go.sum into with --write (defaults to build/_input/<model>, "+
+		"the copy of the service model made by build/bin/build-deps, so that the committed go.mod is never overwritten)")
+	cmd.Flags().Bool("fail-on-conflict", false, "fail if the service model and the target require different versions of a dependency")
 	return cmd
 }
 
@@ -71,12 +113,21 @@ func newModuleResolver() *moduleResolver {
 }
 
 // moduleResolver is a module resolver
-type moduleResolver struct{}
+type moduleResolver struct {
+	// offline resolves the target go.mod with findMod instead of fetchMod
+	offline    bool
+	modDirs    []string
+	vendorDirs []string
+	modCache   string
+}
 
 func (r *moduleResolver) exec(dir string, name string, args ...string) (string, error) {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
 	cmd.Env = append(os.Environ(), "GO111MODULE=on", "CGO_ENABLED=1")
+	if r.offline {
+		cmd.Env = append(cmd.Env, "GOPROXY=off")
+	}
 	out, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -102,6 +153,9 @@ func (r *moduleResolver) getGoEnv() (goEnv, error) {
 }
 
 func (r *moduleResolver) getGoModCacheDir() (string, error) {
+	if r.modCache != "" {
+		return r.modCache, nil
+	}
 	env, err := r.getGoEnv()
 	if err != nil {
 		return "", err
@@ -120,7 +174,16 @@ func (r *moduleResolver) getModName(model string) string {
 
 // resolve resolves the module info for the target module
 func (r *moduleResolver) resolve(model, target string) (*modfile.File, error) {
-	mod, err := r.fetchMod(target)
+	var mod *modfile.File
+	var err error
+	if r.offline {
+		mod, err = r.findLocalMod(target)
+		if err == nil && mod == nil {
+			mod, err = r.findMod(target)
+		}
+	} else {
+		mod, err = r.fetchMod(target)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -130,14 +193,25 @@ func (r *moduleResolver) resolve(model, target string) (*modfile.File, error) {
 	return mod, nil
 }
 
-func (r *moduleResolver) fetchMod(target string) (*modfile.File, error) {
+// findLocalMod returns the go.mod of a target given as a local directory, or nil if there is none
+func (r *moduleResolver) findLocalMod(target string) (*modfile.File, error) {
+	if target == "" {
+		return nil, nil
+	}
 	localModPath := filepath.Join(target, "go.mod")
-	if _, err := os.Stat(localModPath); !os.IsNotExist(err) {
-		localModBytes, err := ioutil.ReadFile(localModPath)
-		if err != nil {
-			return nil, err
-		}
-		return modfile.Parse(localModPath, localModBytes, nil)
+	if _, err := os.Stat(localModPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	localModBytes, err := ioutil.ReadFile(localModPath)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(localModPath, localModBytes, nil)
+}
+
+func (r *moduleResolver) fetchMod(target string) (*modfile.File, error) {
+	if mod, err := r.findLocalMod(target); err != nil || mod != nil {
+		return mod, err
 	}
 
 	var replace string
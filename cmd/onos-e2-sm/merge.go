@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/rogpeppe/go-internal/modfile"
+	"github.com/rogpeppe/go-internal/semver"
+	"github.com/spf13/cobra"
+)
+
+const sumFile = "go.sum"
+
+// versionConflict is a dependency required with different versions by the service model and the target.
+// The plugin uses the version of the target, which loads it.
+type versionConflict struct {
+	Path          string
+	ModelVersion  string
+	TargetVersion string
+}
+
+// Downgrade returns whether the version of the target is older than the one the service model requires,
+// in which case the service model may not build
+func (c versionConflict) Downgrade() bool {
+	return semver.Compare(c.TargetVersion, c.ModelVersion) < 0
+}
+
+// Change returns whether the service model gets an upgrade or a downgrade of the dependency
+func (c versionConflict) Change() string {
+	if c.Downgrade() {
+		return "DOWNGRADE"
+	}
+	return "upgrade"
+}
+
+// merge merges the go.mod of the service model in smDir into the resolved target go.mod and prints the conflicts.
+// The merged go.mod is printed, or written with the go.sum of both modules into outDir and verified. The go.mod
+// is always read from smDir: the one of outDir may already be truncated, e.g. by a shell redirection.
+func (r *moduleResolver) merge(cmd *cobra.Command, mod *modfile.File, smDir string, outDir string, write bool, failOnConflict bool) error {
+	modelMod, err := readModFile(filepath.Join(smDir, modFile))
+	if err != nil {
+		return err
+	}
+	if err := rebaseReplaces(modelMod, smDir, outDir); err != nil {
+		return err
+	}
+	targetVersions := requiredVersions(mod)
+	targetSumPath := r.getSumPath(mod)
+
+	conflicts := mergeMods(modelMod, mod)
+	if len(conflicts) > 0 {
+		if err := writeConflicts(cmd.ErrOrStderr(), conflicts); err != nil {
+			return err
+		}
+		if failOnConflict {
+			return errors.NewConflict("the service model and the target require %d dependencies with different versions", len(conflicts))
+		}
+	}
+
+	if !write {
+		modBytes, err := mod.Format()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(modBytes)
+		return err
+	}
+	sum, err := mergeSums(filepath.Join(smDir, sumFile), targetSumPath)
+	if err != nil {
+		return err
+	}
+	if err := writeMod(outDir, mod, sum); err != nil {
+		return err
+	}
+	return r.verifyMod(outDir, targetVersions)
+}
+
+// mergeMods merges the requirements and replacements of the service model into the go.mod of the target,
+// keeping the versions and replacements of the target for the dependencies they share. It returns the
+// dependencies the service model requires with another version than the target, ordered by path.
+// The relative paths of the replacements of the service model must already be rebased, see rebaseReplaces.
+func mergeMods(model, target *modfile.File) []versionConflict {
+	targetVersions := requiredVersions(target)
+	targetReplaces := make(map[string]bool)
+	for _, replace := range target.Replace {
+		targetReplaces[replace.Old.Path] = true
+	}
+
+	conflicts := make([]versionConflict, 0)
+	for _, require := range model.Require {
+		version, ok := targetVersions[require.Mod.Path]
+		if !ok {
+			target.AddNewRequire(require.Mod.Path, require.Mod.Version, require.Indirect)
+			continue
+		}
+		if version != require.Mod.Version {
+			conflicts = append(conflicts, versionConflict{
+				Path:          require.Mod.Path,
+				ModelVersion:  require.Mod.Version,
+				TargetVersion: version,
+			})
+		}
+	}
+	for _, replace := range model.Replace {
+		if !targetReplaces[replace.Old.Path] {
+			// AddReplace only fails on a malformed version, which the parser of the go.mod already rejected
+			_ = target.AddReplace(replace.Old.Path, replace.Old.Version, replace.New.Path, replace.New.Version)
+		}
+	}
+	target.SortBlocks()
+	target.Cleanup()
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Path < conflicts[j].Path
+	})
+	return conflicts
+}
+
+// rebaseReplaces rewrites the replacements of the service model in smDir with a local directory, e.g. the
+// ../e2sm_common of the service models requiring e2sm_common, so that they hold in outDir
+func rebaseReplaces(mod *modfile.File, smDir string, outDir string) error {
+	smDir, err := filepath.Abs(smDir)
+	if err != nil {
+		return err
+	}
+	outDir, err = filepath.Abs(outDir)
+	if err != nil {
+		return err
+	}
+	for _, replace := range mod.Replace {
+		if !modfile.IsDirectoryPath(replace.New.Path) || filepath.IsAbs(replace.New.Path) {
+			continue
+		}
+		path, err := filepath.Rel(outDir, filepath.Join(smDir, filepath.FromSlash(replace.New.Path)))
+		if err != nil {
+			return err
+		}
+		path = filepath.ToSlash(path)
+		if !strings.HasPrefix(path, "../") {
+			path = "./" + path
+		}
+		if err := mod.AddReplace(replace.Old.Path, replace.Old.Version, path, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requiredVersions returns the version each requirement of a go.mod resolves to, following the replacements
+// by another module version
+func requiredVersions(mod *modfile.File) map[string]string {
+	versions := make(map[string]string)
+	for _, require := range mod.Require {
+		versions[require.Mod.Path] = require.Mod.Version
+	}
+	for _, replace := range mod.Replace {
+		if _, ok := versions[replace.Old.Path]; ok && replace.New.Version != "" {
+			versions[replace.Old.Path] = replace.New.Version
+		}
+	}
+	return versions
+}
+
+// writeConflicts prints the version conflicts as a table
+func writeConflicts(out io.Writer, conflicts []versionConflict) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DEPENDENCY\tSERVICE MODEL\tTARGET\tCHANGE")
+	for _, c := range conflicts {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", c.Path, c.ModelVersion, c.TargetVersion, c.Change())
+	}
+	return writer.Flush()
+}
+
+// readModFile reads and parses a go.mod
+func readModFile(path string) (*modfile.File, error) {
+	modBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(path, modBytes, nil)
+}
+
+// getSumPath returns the go.sum next to a parsed go.mod: the one of its directory, or the one
+// of the extracted module for a go.mod of the download cache
+func (r *moduleResolver) getSumPath(mod *modfile.File) string {
+	path := mod.Syntax.Name
+	if filepath.Base(path) == modFile {
+		return filepath.Join(filepath.Dir(path), sumFile)
+	}
+	version := strings.TrimSuffix(filepath.Base(path), ".mod")
+	downloadDir := filepath.Dir(filepath.Dir(path))
+	modCache, err := r.getGoModCacheDir()
+	if err != nil {
+		return ""
+	}
+	encPath, err := filepath.Rel(filepath.Join(modCache, "cache", "download"), downloadDir)
+	if err != nil || strings.HasPrefix(encPath, "..") {
+		return ""
+	}
+	return filepath.Join(modCache, encPath+modVersionSep+version, sumFile)
+}
+
+// mergeSums returns the lines of the given go.sum files, sorted and without duplicates. Missing files are skipped.
+func mergeSums(paths ...string) ([]byte, error) {
+	lines := make(map[string]bool)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		sumBytes, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(sumBytes))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				lines[line] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Strings(sorted)
+	if len(sorted) == 0 {
+		return []byte{}, nil
+	}
+	return []byte(strings.Join(sorted, "\n") + "\n"), nil
+}
+
+// writeMod writes the merged go.mod and go.sum into the directory of the service model
+func writeMod(dir string, mod *modfile.File, sum []byte) error {
+	modBytes, err := mod.Format()
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, modFile), modBytes, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, sumFile), sum, 0644)
+}
+
+// listedModule is a module as listed by go list -m -json
+type listedModule struct {
+	Path    string
+	Version string
+	Replace *listedModule
+}
+
+// verifyMod checks that the build list of the service model directory selects the versions the target
+// requires, as returned by requiredVersions before the merge, i.e. that no requirement of the service model
+// raises the version of a dependency of the target. Listing the modules also completes the go.sum of the directory.
+func (r *moduleResolver) verifyMod(dir string, targetVersions map[string]string) error {
+	// -e lists the modules of which the info cannot be looked up (e.g. offline), the selected version is enough
+	out, err := r.exec(dir, "go", "list", "-e", "-mod=mod", "-m", "-json", "all")
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return errors.NewInvalid("cannot list the modules of %s: %s", dir, strings.TrimSpace(string(exitErr.Stderr)))
+	} else if err != nil {
+		return err
+	}
+	selected := make(map[string]string)
+	decoder := json.NewDecoder(strings.NewReader(out))
+	for decoder.More() {
+		m := listedModule{}
+		if err := decoder.Decode(&m); err != nil {
+			return err
+		}
+		version := m.Version
+		if m.Replace != nil && m.Replace.Version != "" {
+			version = m.Replace.Version
+		}
+		selected[m.Path] = version
+	}
+
+	mismatches := make([]string, 0)
+	for path, expected := range targetVersions {
+		if version, ok := selected[path]; ok && version != expected {
+			mismatches = append(mismatches, fmt.Sprintf("%s %s (target requires %s)", path, version, expected))
+		}
+	}
+	sort.Strings(mismatches)
+	if len(mismatches) > 0 {
+		return errors.NewInvalid("the service model selects other versions than the target:\n\t%s", strings.Join(mismatches, "\n\t"))
+	}
+	return nil
+}
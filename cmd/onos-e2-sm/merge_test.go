@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rogpeppe/go-internal/modfile"
+	"gotest.tools/assert"
+)
+
+const testModelMod = `module github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm
+
+go 1.16
+
+require (
+	github.com/gogo/protobuf v1.3.2
+	github.com/onosproject/onos-api/go v0.7.110
+	github.com/onosproject/onos-lib-go v0.8.9
+	google.golang.org/protobuf v1.27.1
+	gotest.tools v2.2.0+incompatible
+)
+
+replace github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho => ../e2sm_mho
+`
+
+const testTargetMod = `module github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm
+
+go 1.16
+
+require (
+	github.com/onosproject/onos-api/go v0.7.100
+	github.com/onosproject/onos-lib-go v0.8.9
+	github.com/spf13/cobra v1.1.3
+	google.golang.org/protobuf v1.26.0
+)
+
+replace github.com/onosproject/onos-lib-go => github.com/onosproject/onos-lib-go v0.8.10
+`
+
+func TestMergeMods(t *testing.T) {
+	model, err := modfile.Parse("model/go.mod", []byte(testModelMod), nil)
+	assert.NilError(t, err)
+	target, err := modfile.Parse("target/go.mod", []byte(testTargetMod), nil)
+	assert.NilError(t, err)
+
+	versions := requiredVersions(target)
+	assert.Equal(t, "v0.8.10", versions["github.com/onosproject/onos-lib-go"])
+	assert.Equal(t, "v1.1.3", versions["github.com/spf13/cobra"])
+
+	conflicts := mergeMods(model, target)
+	assert.DeepEqual(t, []versionConflict{
+		{Path: "github.com/onosproject/onos-api/go", ModelVersion: "v0.7.110", TargetVersion: "v0.7.100"},
+		{Path: "github.com/onosproject/onos-lib-go", ModelVersion: "v0.8.9", TargetVersion: "v0.8.10"},
+		{Path: "google.golang.org/protobuf", ModelVersion: "v1.27.1", TargetVersion: "v1.26.0"},
+	}, conflicts)
+	assert.Equal(t, "DOWNGRADE", conflicts[0].Change())
+	assert.Equal(t, "upgrade", conflicts[1].Change())
+
+	merged := requiredVersions(target)
+	assert.Equal(t, 6, len(merged))
+	assert.Equal(t, "v0.7.100", merged["github.com/onosproject/onos-api/go"])
+	assert.Equal(t, "v0.8.10", merged["github.com/onosproject/onos-lib-go"])
+	assert.Equal(t, "v1.3.2", merged["github.com/gogo/protobuf"])
+	assert.Equal(t, "v2.2.0+incompatible", merged["gotest.tools"])
+	assert.Equal(t, 2, len(target.Replace))
+
+	modBytes, err := target.Format()
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(modBytes), "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho => ../e2sm_mho"), string(modBytes))
+
+	out := &bytes.Buffer{}
+	assert.NilError(t, writeConflicts(out, conflicts))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Assert(t, strings.HasPrefix(lines[0], "DEPENDENCY"))
+	assert.Assert(t, strings.HasSuffix(lines[3], "DOWNGRADE"))
+}
+
+func TestMergeSums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onos-e2-sm-merge")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	modelSum := filepath.Join(dir, "model.sum")
+	targetSum := filepath.Join(dir, "target.sum")
+	assert.NilError(t, ioutil.WriteFile(modelSum, []byte("b v1.0.0 h1:b=\na v1.0.0 h1:a=\n"), 0644))
+	assert.NilError(t, ioutil.WriteFile(targetSum, []byte("a v1.0.0 h1:a=\n\nc v1.0.0/go.mod h1:c=\n"), 0644))
+
+	sum, err := mergeSums(modelSum, targetSum, filepath.Join(dir, "missing.sum"), "")
+	assert.NilError(t, err)
+	assert.Equal(t, "a v1.0.0 h1:a=\nb v1.0.0 h1:b=\nc v1.0.0/go.mod h1:c=\n", string(sum))
+}
+
+func TestGetSumPath(t *testing.T) {
+	resolver := newModuleResolver()
+	resolver.modCache = filepath.Join("cache", "mod")
+
+	mod, err := modfile.Parse(filepath.Join("onos-e2t", modFile), []byte("module github.com/onosproject/onos-e2t\n"), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, filepath.Join("onos-e2t", sumFile), resolver.getSumPath(mod))
+
+	cachePath := filepath.Join(resolver.modCache, "cache", "download", "github.com", "onosproject", "onos-e2t", "@v", "v0.10.0.mod")
+	mod, err = modfile.Parse(cachePath, []byte("module github.com/onosproject/onos-e2t\n"), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, filepath.Join(resolver.modCache, "github.com", "onosproject", "onos-e2t@v0.10.0", sumFile), resolver.getSumPath(mod))
+
+	mod, err = modfile.Parse(filepath.Join("elsewhere", "v0.10.0.mod"), []byte("module github.com/onosproject/onos-e2t\n"), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, "", resolver.getSumPath(mod))
+}
+
+func TestRebaseReplaces(t *testing.T) {
+	mod, err := modfile.Parse("go.mod", []byte(`module github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm
+
+go 1.16
+
+replace (
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common => ../e2sm_common
+	github.com/onosproject/onos-lib-go => github.com/onosproject/onos-lib-go v0.8.10
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/local => ./local
+)
+`), nil)
+	assert.NilError(t, err)
+	assert.NilError(t, rebaseReplaces(mod, filepath.Join("servicemodels", "e2sm_rsm"), filepath.Join("build", "_input", "e2sm_rsm")))
+	assert.Equal(t, "../../../servicemodels/e2sm_common", mod.Replace[0].New.Path)
+	assert.Equal(t, "github.com/onosproject/onos-lib-go", mod.Replace[1].New.Path)
+	assert.Equal(t, "v0.8.10", mod.Replace[1].New.Version)
+	assert.Equal(t, "../../../servicemodels/e2sm_rsm/local", mod.Replace[2].New.Path)
+
+	assert.NilError(t, rebaseReplaces(mod, filepath.Join("build", "_input", "e2sm_rsm"), filepath.Join("build", "_input", "e2sm_rsm")))
+	assert.Equal(t, "../../../servicemodels/e2sm_common", mod.Replace[0].New.Path)
+
+	modBytes, err := mod.Format()
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(modBytes), "e2sm_common => ../../../servicemodels/e2sm_common"), string(modBytes))
+}
+
+// TestBuildDeps runs build/bin/build-deps on a service model, with a go command running the onos-e2-sm
+// binary built by the test for "go run" and skipping "go mod vendor"
+func TestBuildDeps(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds onos-e2-sm")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("no go command: %v", err)
+	}
+	script, err := filepath.Abs(filepath.Join("..", "..", "build", "bin", "build-deps"))
+	assert.NilError(t, err)
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "bin")
+	assert.NilError(t, os.Mkdir(bin, 0755))
+	goBuild(t, ".", "-o", filepath.Join(bin, "onos-e2-sm"), ".")
+	fakeGo := `#!/bin/bash
+case "$1" in
+run) shift 2; exec ` + filepath.Join(bin, "onos-e2-sm") + ` "$@";;
+mod) exit 0;;
+*) exec ` + goCmd + ` "$@";;
+esac
+`
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(bin, "go"), []byte(fakeGo), 0755))
+
+	repo := filepath.Join(dir, "repo")
+	assert.NilError(t, os.MkdirAll(filepath.Join(repo, "servicemodels", "e2sm_test"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(repo, "servicemodels", "e2sm_test", modFile), []byte(`module github.com/onosproject/onos-e2-sm/servicemodels/e2sm_test
+
+go 1.16
+
+require gotest.tools v2.2.0+incompatible
+`), 0644))
+	target := filepath.Join(dir, "onos-e2t")
+	assert.NilError(t, os.Mkdir(target, 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(target, modFile), []byte(`module github.com/onosproject/onos-e2t
+
+go 1.16
+
+require github.com/onosproject/onos-lib-go v0.8.9
+`), 0644))
+
+	for _, flags := range []string{"", "--write"} {
+		cmd := exec.Command("bash", script, "e2sm_test", "github.com/onosproject/onos-e2t")
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"), "GOWORK=off",
+			"GEN_DEPS_FLAGS=--mod-dir "+target+" "+flags)
+		out, err := cmd.CombinedOutput()
+		assert.NilError(t, err, string(out))
+
+		modBytes, err := ioutil.ReadFile(filepath.Join(repo, "build", "_input", "e2sm_test", modFile))
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(string(modBytes), "github.com/onosproject/onos-lib-go v0.8.9"), "%s: %s", flags, modBytes)
+		assert.Assert(t, strings.Contains(string(modBytes), "gotest.tools v2.2.0+incompatible"), "%s: %s", flags, modBytes)
+	}
+	// The committed go.mod is left as is
+	modBytes, err := ioutil.ReadFile(filepath.Join(repo, "servicemodels", "e2sm_test", modFile))
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(modBytes), "onos-lib-go"), string(modBytes))
+}
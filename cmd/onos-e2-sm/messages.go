@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	e2smmho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	e2smrcpre "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	e2smrsm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+	"google.golang.org/protobuf/proto"
+)
+
+// pduTypes are the protobuf messages of the PDUs of the compiled in service models
+type pduTypes struct {
+	// ASN1Prefix is the prefix of the PDU type names in the ASN.1 module, e.g. E2SM-KPM
+	ASN1Prefix string
+	// Messages creates the message of each PDU kind the service model implements
+	Messages map[string]func() proto.Message
+}
+
+// pduMessages are the PDU types of the compiled in service models by OID
+var pduMessages = map[string]pduTypes{
+	"1.3.6.1.4.1.53148.1.2.2.2": {
+		ASN1Prefix: "E2SM-KPM",
+		Messages: map[string]func() proto.Message{
+			"ran-function-description": func() proto.Message { return &e2smkpmv2.E2SmKpmRanfunctionDescription{} },
+			"event-trigger-definition": func() proto.Message { return &e2smkpmv2.E2SmKpmEventTriggerDefinition{} },
+			"action-definition":        func() proto.Message { return &e2smkpmv2.E2SmKpmActionDefinition{} },
+			"indication-header":        func() proto.Message { return &e2smkpmv2.E2SmKpmIndicationHeader{} },
+			"indication-message":       func() proto.Message { return &e2smkpmv2.E2SmKpmIndicationMessage{} },
+		},
+	},
+	"1.3.6.1.4.1.53148.1.2.2.100": {
+		ASN1Prefix: "E2SM-RC-PRE",
+		Messages: map[string]func() proto.Message{
+			"ran-function-description": func() proto.Message { return &e2smrcpre.E2SmRcPreRanfunctionDescription{} },
+			"event-trigger-definition": func() proto.Message { return &e2smrcpre.E2SmRcPreEventTriggerDefinition{} },
+			"indication-header":        func() proto.Message { return &e2smrcpre.E2SmRcPreIndicationHeader{} },
+			"indication-message":       func() proto.Message { return &e2smrcpre.E2SmRcPreIndicationMessage{} },
+			"control-header":           func() proto.Message { return &e2smrcpre.E2SmRcPreControlHeader{} },
+			"control-message":          func() proto.Message { return &e2smrcpre.E2SmRcPreControlMessage{} },
+			"control-outcome":          func() proto.Message { return &e2smrcpre.E2SmRcPreControlOutcome{} },
+		},
+	},
+	"1.3.6.1.4.1.53148.1.2.2.101": {
+		ASN1Prefix: "E2SM-MHO",
+		Messages: map[string]func() proto.Message{
+			"ran-function-description": func() proto.Message { return &e2smmho.E2SmMhoRanfunctionDescription{} },
+			"event-trigger-definition": func() proto.Message { return &e2smmho.E2SmMhoEventTriggerDefinition{} },
+			"indication-header":        func() proto.Message { return &e2smmho.E2SmMhoIndicationHeader{} },
+			"indication-message":       func() proto.Message { return &e2smmho.E2SmMhoIndicationMessage{} },
+			"control-header":           func() proto.Message { return &e2smmho.E2SmMhoControlHeader{} },
+			"control-message":          func() proto.Message { return &e2smmho.E2SmMhoControlMessage{} },
+		},
+	},
+	"1.3.6.1.4.1.53148.1.1.2.102": {
+		ASN1Prefix: "E2SM-RSM",
+		Messages: map[string]func() proto.Message{
+			"ran-function-description": func() proto.Message { return &e2smrsm.E2SmRsmRanfunctionDescription{} },
+			"event-trigger-definition": func() proto.Message { return &e2smrsm.E2SmRsmEventTriggerDefinition{} },
+			"indication-header":        func() proto.Message { return &e2smrsm.E2SmRsmIndicationHeader{} },
+			"indication-message":       func() proto.Message { return &e2smrsm.E2SmRsmIndicationMessage{} },
+			"control-header":           func() proto.Message { return &e2smrsm.E2SmRsmControlHeader{} },
+			"control-message":          func() proto.Message { return &e2smrsm.E2SmRsmControlMessage{} },
+		},
+	},
+}
+
+// newPduMessage creates an empty message of a PDU kind of a service model
+func newPduMessage(sm codec.ServiceModel, kind codec.PduKind) (proto.Message, error) {
+	types, ok := pduMessages[string(sm.ServiceModelData().OID)]
+	if !ok {
+		return nil, fmt.Errorf("no PDU types for %s", codec.ModelName(sm))
+	}
+	newMessage, ok := types.Messages[kind.Name]
+	if !ok {
+		return nil, fmt.Errorf("%s does not implement the %s PDU", codec.ModelName(sm), kind.Name)
+	}
+	return newMessage(), nil
+}
+
+// getASN1TypeName returns the name of the ASN.1 type of a PDU kind of a service model, e.g. E2SM-KPM-IndicationHeader
+func getASN1TypeName(sm codec.ServiceModel, kind codec.PduKind) string {
+	prefix := pduMessages[string(sm.ServiceModelData().OID)].ASN1Prefix
+	if prefix == "" {
+		prefix = "E2SM"
+	}
+	return fmt.Sprintf("%s-%s", prefix, kind.ASN1Name)
+}
+
+// decodePdu decodes an APER encoded PDU into its protobuf message
+func decodePdu(sm codec.ServiceModel, kind codec.PduKind, asn1Bytes []byte) (proto.Message, error) {
+	msg, err := newPduMessage(sm, kind)
+	if err != nil {
+		return nil, err
+	}
+	protoBytes, err := kind.Decode(sm, asn1Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(protoBytes, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// encodePdu encodes the protobuf message of a PDU in APER
+func encodePdu(sm codec.ServiceModel, kind codec.PduKind, msg proto.Message) ([]byte, error) {
+	protoBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return kind.Encode(sm, protoBytes)
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/spf13/cobra"
+)
+
+// pduSupport is whether a service model implements the decoding and encoding of a PDU kind
+type pduSupport struct {
+	Decode bool
+	Encode bool
+}
+
+func (s pduSupport) String() string {
+	switch {
+	case s.Decode && s.Encode:
+		return "yes"
+	case s.Decode:
+		return "decode only"
+	case s.Encode:
+		return "encode only"
+	default:
+		return "not implemented"
+	}
+}
+
+// getPduSupport probes a service model with an empty PDU: anything but a "not implemented"
+// error (including a decoding error or a panic) means the PDU kind is implemented
+func getPduSupport(sm codec.ServiceModel, kind codec.PduKind) pduSupport {
+	_, decodeErr := kind.Decode(sm, []byte{})
+	_, encodeErr := kind.Encode(sm, []byte{})
+	return pduSupport{
+		Decode: isImplemented(decodeErr),
+		Encode: isImplemented(encodeErr),
+	}
+}
+
+func isImplemented(err error) bool {
+	return err == nil || !strings.Contains(err.Error(), "not implemented")
+}
+
+func getModelsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "models [name]",
+		Short:        "Lists the service models compiled into the CLI and the PDUs they support",
+		SilenceUsage: true,
+		Args:         cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			models := codec.ServiceModels
+			if len(args) == 1 {
+				sm, err := codec.GetServiceModel(args[0])
+				if err != nil {
+					return err
+				}
+				models = []codec.ServiceModel{sm}
+			}
+			brief, _ := cmd.Flags().GetBool("brief")
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			if brief {
+				fmt.Fprintln(writer, "NAME\tVERSION\tOID\tMODULE")
+				for _, sm := range models {
+					data := sm.ServiceModelData()
+					fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", data.Name, data.Version, data.OID, data.ModuleName)
+				}
+				return writer.Flush()
+			}
+			for i, sm := range models {
+				data := sm.ServiceModelData()
+				if i > 0 {
+					fmt.Fprintln(writer)
+				}
+				fmt.Fprintf(writer, "%s\n", codec.ModelName(sm))
+				fmt.Fprintf(writer, "  OID:\t%s\n", data.OID)
+				fmt.Fprintf(writer, "  Module:\t%s\n", data.ModuleName)
+				for _, kind := range codec.PduKinds {
+					fmt.Fprintf(writer, "  %s:\t%s\n", kind.Name, getPduSupport(sm, kind))
+				}
+			}
+			return writer.Flush()
+		},
+	}
+	cmd.Flags().BoolP("brief", "b", false, "only list the name, version, OID and module name of each service model")
+	return cmd
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"gotest.tools/assert"
+)
+
+func TestGetPduSupport(t *testing.T) {
+	kpm, err := codec.GetServiceModel("e2sm_kpm/v2_go")
+	assert.NilError(t, err)
+	kind, err := codec.GetPduKind("indication-message")
+	assert.NilError(t, err)
+	assert.Equal(t, "yes", getPduSupport(kpm, kind).String())
+	kind, err = codec.GetPduKind("control-header")
+	assert.NilError(t, err)
+	assert.Equal(t, "not implemented", getPduSupport(kpm, kind).String())
+}
+
+func TestModelsCmd(t *testing.T) {
+	cmd := getModelsCmd()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--brief"})
+	assert.NilError(t, cmd.Execute())
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(codec.ServiceModels)+1, len(lines))
+	assert.Assert(t, strings.HasPrefix(lines[0], "NAME"))
+
+	cmd = getModelsCmd()
+	out.Reset()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"e2sm_rc_pre/v2_go"})
+	assert.NilError(t, cmd.Execute())
+	assert.Assert(t, strings.Contains(out.String(), "OID:"))
+	assert.Assert(t, strings.Contains(out.String(), "action-definition:         not implemented"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "control-outcome:           yes"), out.String())
+}
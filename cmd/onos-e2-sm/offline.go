@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/rogpeppe/go-internal/modfile"
+	"github.com/rogpeppe/go-internal/module"
+	"github.com/rogpeppe/go-internal/semver"
+)
+
+const vendorModulesFile = "modules.txt"
+
+// findMod resolves the go.mod of the target module without network access, from the module directories,
+// then the vendor trees, then the module cache. A target version which is not a semantic version
+// (e.g. a branch name) resolves to the version recorded in a vendor tree: the module cache cannot tell
+// which version a branch stands for.
+func (r *moduleResolver) findMod(target string) (*modfile.File, error) {
+	if target == "" {
+		return nil, errors.NewInvalid("no target module configured")
+	}
+	// Same as fetchMod, a target outside of onosproject is a fork of the onosproject module
+	modPath, version := splitModPathVersion(target)
+	if !strings.HasPrefix(target, "github.com/onosproject/") {
+		elems := strings.SplitN(modPath, "/", 3)
+		if len(elems) < 3 {
+			return nil, errors.NewInvalid("invalid target module %s", target)
+		}
+	}
+
+	searched := make([]string, 0)
+	find := func(path string) (*modfile.File, bool, error) {
+		searched = append(searched, path)
+		modBytes, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+		modFile, err := modfile.Parse(path, modBytes, nil)
+		if err != nil {
+			return nil, false, err
+		}
+		if modFile.Module == nil || !r.isTargetModule(modFile.Module.Mod.Path, modPath) {
+			return nil, false, nil
+		}
+		return modFile, true, nil
+	}
+
+	for _, dir := range r.modDirs {
+		candidates := []string{
+			filepath.Join(dir, modFile),
+			filepath.Join(dir, filepath.FromSlash(modPath), modFile),
+		}
+		if version != "" {
+			candidates = append(candidates, filepath.Join(dir, filepath.FromSlash(modPath)+modVersionSep+version, modFile))
+		}
+		for _, candidate := range candidates {
+			if mod, ok, err := find(candidate); err != nil || ok {
+				return mod, err
+			}
+		}
+	}
+
+	for _, dir := range r.vendorDirs {
+		if mod, ok, err := find(filepath.Join(dir, filepath.FromSlash(modPath), modFile)); err != nil || ok {
+			return mod, err
+		}
+		modulesPath := filepath.Join(dir, vendorModulesFile)
+		searched = append(searched, modulesPath)
+		vendored, err := readVendoredVersion(modulesPath, modPath)
+		if err != nil {
+			return nil, err
+		}
+		if vendored != "" && !semver.IsValid(version) {
+			version = vendored
+		}
+	}
+
+	if !semver.IsValid(version) {
+		return nil, errors.NewNotFound("cannot resolve the go.mod of %s offline: no semantic version is given and no vendor tree records the version of %s, set the version in --target or add a --vendor tree, searched:\n\t%s",
+			target, modPath, strings.Join(searched, "\n\t"))
+	}
+	modCache, err := r.getGoModCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	encPath, err := module.EncodePath(modPath)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []string{
+		filepath.Join(modCache, "cache", "download", filepath.FromSlash(encPath), "@v", version+".mod"),
+		filepath.Join(modCache, filepath.FromSlash(encPath)+modVersionSep+version, modFile),
+	}
+	for _, candidate := range candidates {
+		if mod, ok, err := find(candidate); err != nil || ok {
+			return mod, err
+		}
+	}
+
+	return nil, errors.NewNotFound("cannot resolve the go.mod of %s offline, searched:\n\t%s", target, strings.Join(searched, "\n\t"))
+}
+
+// isTargetModule returns whether a go.mod module path is the one of the target, or of the onosproject module it forks
+func (r *moduleResolver) isTargetModule(path string, targetPath string) bool {
+	if path == targetPath {
+		return true
+	}
+	elems := strings.SplitN(targetPath, "/", 3)
+	return len(elems) == 3 && path == fmt.Sprintf("github.com/onosproject/%s", elems[2])
+}
+
+// readVendoredVersion returns the version of a module recorded in the modules.txt of a vendor tree,
+// following a replacement if any. A module replaced by a directory has no version.
+func readVendoredVersion(path string, modPath string) (string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// "# path version" or "# path [version] => replacement [version]", the replacement being what is vendored
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "#" {
+			continue
+		}
+		arrow := -1
+		for i, field := range fields {
+			if field == "=>" {
+				arrow = i
+			}
+		}
+		if arrow < 0 {
+			if fields[1] == modPath {
+				return fields[2], nil
+			}
+			continue
+		}
+		if (fields[1] == modPath || (arrow+1 < len(fields) && fields[arrow+1] == modPath)) && arrow+2 < len(fields) {
+			return fields[arrow+2], nil
+		}
+	}
+	return "", scanner.Err()
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const e2tModPath = "github.com/onosproject/onos-e2t"
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NilError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func e2tMod(version string) string {
+	return fmt.Sprintf("module %s\n\ngo 1.16\n\nrequire github.com/onosproject/onos-api/go %s\n", e2tModPath, version)
+}
+
+func apiVersion(t *testing.T, r *moduleResolver, target string) string {
+	mod, err := r.resolve("e2sm_rsm", target)
+	assert.NilError(t, err)
+	assert.Equal(t, "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm", mod.Module.Mod.Path)
+	return mod.Require[0].Mod.Version
+}
+
+func TestFindModCache(t *testing.T) {
+	modCache := t.TempDir()
+	downloadDir := filepath.Join(modCache, "cache", "download", "github.com", "onosproject", "onos-e2t", "@v")
+	writeFile(t, filepath.Join(downloadDir, "v0.9.0.mod"), e2tMod("v0.7.90"))
+	writeFile(t, filepath.Join(downloadDir, "v0.10.0.mod"), e2tMod("v0.7.100"))
+	writeFile(t, filepath.Join(modCache, "github.com", "onosproject", "onos-e2t@v0.8.0", "go.mod"), e2tMod("v0.7.80"))
+
+	r := newModuleResolver()
+	r.offline = true
+	r.modCache = modCache
+	assert.Equal(t, "v0.7.90", apiVersion(t, r, e2tModPath+"@v0.9.0"))
+	assert.Equal(t, "v0.7.80", apiVersion(t, r, e2tModPath+"@v0.8.0"))
+	assert.Equal(t, "v0.7.100", apiVersion(t, r, e2tModPath+"@v0.10.0"))
+	// The module cache does not tell which version a branch stands for
+	_, err := r.resolve("e2sm_rsm", e2tModPath+"@master")
+	assert.ErrorContains(t, err, "cannot resolve the go.mod of github.com/onosproject/onos-e2t@master offline: no semantic version is given")
+	_, err = r.resolve("e2sm_rsm", e2tModPath)
+	assert.ErrorContains(t, err, "no vendor tree records the version of github.com/onosproject/onos-e2t")
+
+	_, err = r.resolve("e2sm_rsm", e2tModPath+"@v0.11.0")
+	assert.ErrorContains(t, err, "cannot resolve the go.mod of github.com/onosproject/onos-e2t@v0.11.0 offline, searched:")
+	assert.ErrorContains(t, err, filepath.Join(downloadDir, "v0.11.0.mod"))
+	assert.ErrorContains(t, err, filepath.Join(modCache, "github.com", "onosproject", "onos-e2t@v0.11.0", "go.mod"))
+
+	_, err = r.resolve("e2sm_rsm", "github.com/onosproject/ran-simulator@v0.9.0")
+	assert.ErrorContains(t, err, filepath.Join(modCache, "cache", "download", "github.com", "onosproject", "ran-simulator", "@v", "v0.9.0.mod"))
+}
+
+func TestFindModDirs(t *testing.T) {
+	modCache := t.TempDir()
+	writeFile(t, filepath.Join(modCache, "cache", "download", "github.com", "onosproject", "onos-e2t", "@v", "v0.9.0.mod"), e2tMod("v0.7.90"))
+
+	// A checkout of the target, or a GOPATH like tree
+	checkout := t.TempDir()
+	writeFile(t, filepath.Join(checkout, "go.mod"), e2tMod("v0.7.1"))
+	gopath := t.TempDir()
+	writeFile(t, filepath.Join(gopath, "github.com", "onosproject", "onos-e2t", "go.mod"), e2tMod("v0.7.2"))
+	other := t.TempDir()
+	writeFile(t, filepath.Join(other, "go.mod"), "module github.com/onosproject/ran-simulator\n")
+
+	r := newModuleResolver()
+	r.offline = true
+	r.modCache = modCache
+	r.modDirs = []string{other, checkout}
+	assert.Equal(t, "v0.7.1", apiVersion(t, r, e2tModPath+"@master"))
+	r.modDirs = []string{gopath, checkout}
+	assert.Equal(t, "v0.7.2", apiVersion(t, r, e2tModPath+"@master"))
+
+	// A vendor tree records the version to take from the cache
+	vendor := t.TempDir()
+	writeFile(t, filepath.Join(vendor, "modules.txt"), "# github.com/onosproject/onos-api/go v0.7.110\n"+
+		"## explicit\n"+
+		"# github.com/onosproject/onos-e2t v0.8.0 => github.com/onosproject/onos-e2t v0.9.0\n")
+	r.modDirs = []string{other}
+	r.vendorDirs = []string{vendor}
+	assert.Equal(t, "v0.7.90", apiVersion(t, r, e2tModPath+"@master"))
+
+	r.vendorDirs = []string{t.TempDir()}
+	_, err := r.resolve("e2sm_rsm", e2tModPath+"@v0.12.0")
+	assert.ErrorContains(t, err, filepath.Join(other, "go.mod"))
+	assert.ErrorContains(t, err, filepath.Join(r.vendorDirs[0], "modules.txt"))
+}
+
+func TestReadVendoredVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "modules.txt")
+	writeFile(t, path, "# github.com/onosproject/onos-lib-go v0.8.9\n## explicit\ngithub.com/onosproject/onos-lib-go/pkg/errors\n")
+	version, err := readVendoredVersion(path, "github.com/onosproject/onos-lib-go")
+	assert.NilError(t, err)
+	assert.Equal(t, "v0.8.9", version)
+	version, err = readVendoredVersion(path, "github.com/onosproject/onos-api/go")
+	assert.NilError(t, err)
+	assert.Equal(t, "", version)
+	version, err = readVendoredVersion(filepath.Join(t.TempDir(), "modules.txt"), "github.com/onosproject/onos-lib-go")
+	assert.NilError(t, err)
+	assert.Equal(t, "", version)
+}
@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// pcapRecord is a line of the output of the pcap command, an E2SM PDU or an E2AP PDU which could not be decoded
+type pcapRecord struct {
+	Frame         int             `json:"frame"`
+	Time          string          `json:"time,omitempty"`
+	Src           string          `json:"src"`
+	Dst           string          `json:"dst"`
+	Procedure     string          `json:"procedure,omitempty"`
+	RANfunctionID *int            `json:"ranFunctionId,omitempty"`
+	Model         string          `json:"model,omitempty"`
+	Type          string          `json:"type"`
+	PDU           json.RawMessage `json:"pdu,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	Hex           string          `json:"hex,omitempty"`
+}
+
+// pcapStats are the counts printed at the end of the pcap command
+type pcapStats struct {
+	Packets  int
+	Messages int
+	E2AP     int
+	Decoded  int
+	Failed   int
+	Skipped  int
+}
+
+// pcapDecoder decodes the E2SM PDUs of the E2AP PDUs of a capture, keeping track of the RAN functions
+// of each SCTP association
+type pcapDecoder struct {
+	// ppid is the SCTP payload protocol identifier of E2AP, 0 for all the messages
+	ppid uint32
+	// overrides are the service models of the RAN functions given on the command line, for all associations
+	overrides map[int]codec.ServiceModel
+	// ranFunctions are the service models of the RAN functions of the E2 setups by association
+	ranFunctions map[string]map[int]codec.ServiceModel
+	out          *json.Encoder
+	stats        pcapStats
+}
+
+func newPcapDecoder(out io.Writer, ppid uint32, overrides map[int]codec.ServiceModel) *pcapDecoder {
+	return &pcapDecoder{
+		ppid:         ppid,
+		overrides:    overrides,
+		ranFunctions: make(map[string]map[int]codec.ServiceModel),
+		out:          json.NewEncoder(out),
+	}
+}
+
+// decodeCapture decodes the packets of a capture file, in order
+func (d *pcapDecoder) decodeCapture(packets []packet) error {
+	reassembler := newSctpReassembler()
+	for _, p := range packets {
+		d.stats.Packets++
+		ip, err := decodeLink(p)
+		if err == errNotSctp {
+			continue
+		} else if err != nil {
+			d.stats.Skipped++
+			continue
+		}
+		messages, err := reassembler.add(p, ip)
+		if err != nil {
+			d.stats.Skipped++
+		}
+		for _, message := range messages {
+			if err := d.decodeMessage(message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *pcapDecoder) decodeMessage(message sctpMessage) error {
+	d.stats.Messages++
+	if d.ppid != 0 && message.PPID != d.ppid {
+		d.stats.Skipped++
+		return nil
+	}
+	record := pcapRecord{Frame: message.Packet, Src: message.Src, Dst: message.Dst}
+	if !message.Time.IsZero() {
+		record.Time = message.Time.Format(time.RFC3339Nano)
+	}
+	pdu, err := decodeE2ap(message.Payload)
+	if err != nil {
+		d.stats.Failed++
+		record.Type = "e2ap"
+		record.Error = err.Error()
+		record.Hex = hex.EncodeToString(message.Payload)
+		return d.out.Encode(record)
+	}
+	d.stats.E2AP++
+	record.Procedure = pdu.Procedure()
+
+	association := message.Association()
+	for _, ranFunction := range pdu.RANfunctions {
+		ranFunctionID := ranFunction.ID
+		record := record
+		record.RANfunctionID = &ranFunctionID
+		sm, ok := d.overrides[ranFunction.ID]
+		if !ok {
+			var err error
+			if sm, err = identifyRANfunction(ranFunction); err != nil {
+				if err := d.writeError(record, "ran-function-description", err, ranFunction.Definition); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if d.ranFunctions[association] == nil {
+			d.ranFunctions[association] = make(map[int]codec.ServiceModel)
+		}
+		d.ranFunctions[association][ranFunction.ID] = sm
+		if err := d.writePdu(record, sm, "ran-function-description", ranFunction.Definition); err != nil {
+			return err
+		}
+	}
+
+	if len(pdu.E2SM) == 0 {
+		return nil
+	}
+	record.RANfunctionID = pdu.RANfunctionID
+	var sm codec.ServiceModel
+	if pdu.RANfunctionID != nil {
+		sm = d.overrides[*pdu.RANfunctionID]
+		if sm == nil {
+			sm = d.ranFunctions[association][*pdu.RANfunctionID]
+		}
+	}
+	for _, kind := range codec.PduKinds {
+		octets, ok := pdu.E2SM[kind.Name]
+		if !ok {
+			continue
+		}
+		if sm == nil {
+			err := fmt.Errorf("unknown RAN function, no E2 setup of it was captured (see --ran-function)")
+			if pdu.RANfunctionID == nil {
+				err = fmt.Errorf("no RAN function ID")
+			}
+			if err := d.writeError(record, kind.Name, err, octets); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := d.writePdu(record, sm, kind.Name, octets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePdu decodes an E2SM PDU and writes it
+func (d *pcapDecoder) writePdu(record pcapRecord, sm codec.ServiceModel, kindName string, octets []byte) error {
+	record.Model = codec.ModelName(sm)
+	kind, err := codec.GetPduKind(kindName)
+	if err != nil {
+		return err
+	}
+	msg, err := decodePdu(sm, kind, octets)
+	if err != nil {
+		return d.writeError(record, kindName, err, octets)
+	}
+	jsonBytes, err := protojson.Marshal(msg)
+	if err != nil {
+		return d.writeError(record, kindName, err, octets)
+	}
+	// protojson randomly adds spaces to its output, which must not be relied upon
+	compact := &bytes.Buffer{}
+	if err := json.Compact(compact, jsonBytes); err != nil {
+		return err
+	}
+	d.stats.Decoded++
+	record.Type = kindName
+	record.PDU = compact.Bytes()
+	return d.out.Encode(record)
+}
+
+func (d *pcapDecoder) writeError(record pcapRecord, kindName string, err error, octets []byte) error {
+	d.stats.Failed++
+	record.Type = kindName
+	record.Error = err.Error()
+	record.Hex = hex.EncodeToString(octets)
+	return d.out.Encode(record)
+}
+
+// identifyRANfunction returns the service model of a RAN function: the one of its OID from E2AP v2 on,
+// otherwise the one which decodes its definition and whose OID it names
+func identifyRANfunction(ranFunction e2apRANfunction) (codec.ServiceModel, error) {
+	if ranFunction.OID != "" {
+		sm, err := codec.GetServiceModel(ranFunction.OID)
+		if err != nil {
+			return nil, fmt.Errorf("no service model of OID %s is compiled in", ranFunction.OID)
+		}
+		return sm, nil
+	}
+	kind, err := codec.GetPduKind("ran-function-description")
+	if err != nil {
+		return nil, err
+	}
+	for _, sm := range codec.ServiceModels {
+		msg, err := decodePdu(sm, kind, ranFunction.Definition)
+		if err != nil {
+			continue
+		}
+		if findOID(msg.ProtoReflect()) == string(sm.ServiceModelData().OID) {
+			return sm, nil
+		}
+	}
+	return nil, fmt.Errorf("the RAN function definition is not one of a compiled in service model")
+}
+
+// findOID returns the ranFunction-E2SM-OID of a RAN function description
+func findOID(m protoreflect.Message) string {
+	oid := ""
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && strings.HasSuffix(string(fd.Name()), "e2_sm_oid"):
+			oid = v.String()
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap():
+			oid = findOID(v.Message())
+		}
+		return oid == ""
+	})
+	return oid
+}
+
+// parseRANfunctionOverrides parses the --ran-function flags, ID=model
+func parseRANfunctionOverrides(values []string) (map[int]codec.ServiceModel, error) {
+	overrides := make(map[int]codec.ServiceModel)
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid RAN function %q, expected ID=model", value)
+		}
+		id, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid RAN function ID %q", parts[0])
+		}
+		sm, err := codec.GetServiceModel(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		overrides[id] = sm
+	}
+	return overrides, nil
+}
+
+func getPcapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pcap <file>",
+		Short: "Decodes the E2SM PDUs of the E2AP messages of a pcap or pcapng capture into JSON lines",
+		Long: `Reads a pcap or pcapng capture offline, reassembles the SCTP DATA chunks and decodes the E2AP
+RIC indications and controls and the E2 setups and RIC service updates, as far as needed to extract
+their E2SM octet strings and RAN function IDs. The RAN functions of the E2 setups are mapped to the
+compiled in service models by their OID (E2AP v2), or by the OID named in their definition, for each
+SCTP association; --ran-function maps a RAN function when its setup is not part of the capture.
+Each E2SM PDU is printed as a line of JSON, with the frame number, the time, the addresses, the E2AP
+procedure, the RAN function ID, the service model, the PDU kind and the PDU, or an error and the
+hexadecimal bytes of the PDU when it cannot be decoded.`,
+		Example: `  onos-e2-sm pcap e2.pcapng
+  onos-e2-sm pcap --ran-function 2=e2sm_kpm/v2_go e2.pcap | jq .pdu`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ranFunctionFlags, _ := cmd.Flags().GetStringSlice("ran-function")
+			ppid, _ := cmd.Flags().GetUint32("ppid")
+			overrides, err := parseRANfunctionOverrides(ranFunctionFlags)
+			if err != nil {
+				return err
+			}
+			data, err := readInput(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			packets, err := readCapture(data)
+			if err != nil && len(packets) == 0 {
+				return err
+			} else if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%v, reading the first %d packets\n", err, len(packets))
+			}
+
+			decoder := newPcapDecoder(cmd.OutOrStdout(), ppid, overrides)
+			if err := decoder.decodeCapture(packets); err != nil {
+				return err
+			}
+			stats := decoder.stats
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d packets, %d SCTP messages, %d E2AP PDUs, %d E2SM PDUs decoded, %d failed, %d skipped\n",
+				stats.Packets, stats.Messages, stats.E2AP, stats.Decoded, stats.Failed, stats.Skipped)
+			return nil
+		},
+	}
+	cmd.Flags().StringSlice("ran-function", nil, "the service model of a RAN function, ID=model, e.g. 2=e2sm_kpm/v2_go")
+	cmd.Flags().Uint32("ppid", e2apPPID, "the SCTP payload protocol identifier of E2AP, 0 to decode all the SCTP messages")
+	return cmd
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	rsm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/servicemodel"
+	"gotest.tools/assert"
+)
+
+// testChunk is an SCTP DATA chunk of a test capture
+type testChunk struct {
+	TSN   uint32
+	Flags byte
+	Data  []byte
+}
+
+// encodeTestPacket encodes an Ethernet frame of an SCTP packet from 10.0.0.1:36421 to 10.0.0.2:36421 (or
+// the other way around if reply is set) carrying E2AP DATA chunks
+func encodeTestPacket(reply bool, chunks ...testChunk) []byte {
+	sctp := make([]byte, 12)
+	binary.BigEndian.PutUint16(sctp, 36421)
+	binary.BigEndian.PutUint16(sctp[2:], 36421)
+	for _, chunk := range chunks {
+		header := make([]byte, 16)
+		header[1] = chunk.Flags
+		binary.BigEndian.PutUint16(header[2:], uint16(16+len(chunk.Data)))
+		binary.BigEndian.PutUint32(header[4:], chunk.TSN)
+		binary.BigEndian.PutUint32(header[12:], e2apPPID)
+		sctp = append(append(sctp, header...), chunk.Data...)
+		for len(sctp)%4 != 0 {
+			sctp = append(sctp, 0)
+		}
+	}
+	ip := make([]byte, 20)
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(20+len(sctp)))
+	ip[8], ip[9] = 64, ipProtocolSctp
+	src, dst := []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}
+	if reply {
+		src, dst = dst, src
+	}
+	copy(ip[12:], src)
+	copy(ip[16:], dst)
+	ethernet := []byte{0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 1, 0x08, 0x00}
+	return append(append(ethernet, ip...), sctp...)
+}
+
+// encodeTestPcap encodes a pcap file of Ethernet frames, one second apart
+func encodeTestPcap(frames ...[]byte) []byte {
+	pcap := make([]byte, 24)
+	binary.LittleEndian.PutUint32(pcap, 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(pcap[4:], 2)
+	binary.LittleEndian.PutUint16(pcap[6:], 4)
+	binary.LittleEndian.PutUint32(pcap[16:], 65535)
+	binary.LittleEndian.PutUint32(pcap[20:], linkTypeEthernet)
+	for i, frame := range frames {
+		header := make([]byte, 16)
+		binary.LittleEndian.PutUint32(header, uint32(1600000000+i))
+		binary.LittleEndian.PutUint32(header[4:], 500)
+		binary.LittleEndian.PutUint32(header[8:], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(header[12:], uint32(len(frame)))
+		pcap = append(append(pcap, header...), frame...)
+	}
+	return pcap
+}
+
+func TestReadCapture(t *testing.T) {
+	frame := encodeTestPacket(false, testChunk{TSN: 1, Flags: 0x03, Data: []byte{0x01}})
+	packets, err := readCapture(encodeTestPcap(frame))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(packets))
+	assert.Equal(t, time.Unix(1600000000, 500000).UTC(), packets[0].Time)
+
+	// A pcapng section of an interface of nanosecond timestamps and an enhanced packet
+	block := func(blockType uint32, body []byte) []byte {
+		encoded := make([]byte, 8)
+		binary.LittleEndian.PutUint32(encoded, blockType)
+		binary.LittleEndian.PutUint32(encoded[4:], uint32(12+len(body)))
+		encoded = append(encoded, body...)
+		return append(encoded, encoded[4:8]...)
+	}
+	pcapng := block(0x0a0d0d0a, []byte{0x4d, 0x3c, 0x2b, 0x1a, 1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	pcapng = append(pcapng, block(1, []byte{linkTypeEthernet, 0, 0, 0, 0, 0, 0, 0, 9, 0, 1, 0, 9, 0, 0, 0, 0, 0, 0, 0})...)
+	enhanced := make([]byte, 20)
+	timestamp := uint64(1600000000123456789)
+	binary.LittleEndian.PutUint32(enhanced[4:], uint32(timestamp>>32))
+	binary.LittleEndian.PutUint32(enhanced[8:], uint32(timestamp))
+	binary.LittleEndian.PutUint32(enhanced[12:], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(enhanced[16:], uint32(len(frame)))
+	enhanced = append(enhanced, frame...)
+	for len(enhanced)%4 != 0 {
+		enhanced = append(enhanced, 0)
+	}
+	pcapng = append(pcapng, block(6, enhanced)...)
+	packets, err = readCapture(pcapng)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(packets))
+	assert.Equal(t, time.Unix(0, int64(timestamp)).UTC(), packets[0].Time)
+	assert.DeepEqual(t, frame, packets[0].Data)
+
+	ip, err := decodeLink(packets[0])
+	assert.NilError(t, err)
+	assert.Equal(t, "10.0.0.1", ip.Src.String())
+
+	_, err = readCapture([]byte("not a capture"))
+	assert.ErrorContains(t, err, "not a pcap or pcapng file")
+}
+
+func TestPcapCmd(t *testing.T) {
+	rsmOID, kpmOID := "1.3.6.1.4.1.53148.1.1.2.102", "1.3.6.1.4.1.53148.1.2.2.2"
+	vector := func(oid, kindName string) []byte {
+		kind, err := codec.GetPduKind(kindName)
+		assert.NilError(t, err)
+		bytes, ok := getPduVector(oid, kind)
+		assert.Assert(t, ok)
+		return bytes
+	}
+
+	// The RSM RAN function is identified by its definition (E2AP v1), the KPM one by its OID (E2AP v2)
+	setup := encodeTestE2ap(e2apInitiatingMessage, e2apProcedureE2setup,
+		testIE{ID: e2apIERANfunctionsAdded, Value: encodeTestRANfunctions(
+			e2apRANfunction{ID: 1, Definition: vector(rsmOID, "ran-function-description"), Revision: 1},
+			e2apRANfunction{ID: 2, Definition: vector(kpmOID, "ran-function-description"), Revision: 1, OID: kpmOID},
+		)})
+	indication := encodeTestE2ap(e2apInitiatingMessage, e2apProcedureRICindication,
+		testIE{ID: e2apIERANfunctionID, Value: aperUint16(1)},
+		testIE{ID: e2apIERICindicationHeader, Value: aperOctetString(vector(rsmOID, "indication-header"))},
+		testIE{ID: e2apIERICindicationMessage, Value: aperOctetString(vector(rsmOID, "indication-message"))},
+	)
+	control := encodeTestE2ap(e2apInitiatingMessage, e2apProcedureRICcontrol,
+		testIE{ID: e2apIERANfunctionID, Value: aperUint16(3)},
+		testIE{ID: e2apIERICcontrolHeader, Value: aperOctetString([]byte{0x08})},
+	)
+	capture := encodeTestPcap(
+		encodeTestPacket(false, testChunk{TSN: 1, Flags: 0x03, Data: setup}),
+		// The indication is fragmented, its last fragment being retransmitted
+		encodeTestPacket(false, testChunk{TSN: 2, Flags: 0x02, Data: indication[:10]}),
+		encodeTestPacket(false, testChunk{TSN: 3, Flags: 0x01, Data: indication[10:]}),
+		encodeTestPacket(false, testChunk{TSN: 3, Flags: 0x01, Data: indication[10:]}),
+		encodeTestPacket(true, testChunk{TSN: 1, Flags: 0x03, Data: control}),
+	)
+	dir, err := ioutil.TempDir("", "onos-e2-sm-pcap")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "e2.pcap")
+	assert.NilError(t, ioutil.WriteFile(path, capture, 0644))
+
+	cmd := getPcapCmd()
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	cmd.SetArgs([]string{path})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, "5 packets, 3 SCTP messages, 3 E2AP PDUs, 4 E2SM PDUs decoded, 1 failed, 0 skipped\n", errOut.String())
+
+	records := make([]pcapRecord, 0)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		record := pcapRecord{}
+		assert.NilError(t, json.Unmarshal([]byte(line), &record), line)
+		records = append(records, record)
+	}
+	assert.Equal(t, 5, len(records), out.String())
+	summary := func(record pcapRecord) string {
+		return strings.Join([]string{record.Procedure, record.Model, record.Type}, " ")
+	}
+	assert.Equal(t, "E2setup e2sm_rsm/v1_go ran-function-description", summary(records[0]))
+	assert.Equal(t, "E2setup e2sm_kpm/v2_go ran-function-description", summary(records[1]))
+	assert.Equal(t, 2, *records[1].RANfunctionID)
+	assert.Equal(t, "RICindication e2sm_rsm/v1_go indication-header", summary(records[2]))
+	assert.Equal(t, "RICindication e2sm_rsm/v1_go indication-message", summary(records[3]))
+	assert.Equal(t, 3, records[3].Frame)
+	assert.Equal(t, "10.0.0.1:36421", records[3].Src)
+	assert.Equal(t, "2020-09-13T12:26:42.0005Z", records[3].Time)
+	assert.Assert(t, len(records[3].PDU) > 0)
+	assert.Equal(t, "RICcontrol  control-header", summary(records[4]))
+	assert.Assert(t, strings.Contains(records[4].Error, "unknown RAN function"), records[4].Error)
+	assert.Equal(t, "08", records[4].Hex)
+
+	// The RAN function of the control is given on the command line
+	cmd = getPcapCmd()
+	out.Reset()
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	cmd.SetArgs([]string{"--ran-function", "3=e2sm_rsm/v1_go", path})
+	assert.NilError(t, cmd.Execute())
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Assert(t, strings.Contains(lines[4], `"model":"e2sm_rsm/v1_go","type":"control-header","pdu":{`), lines[4])
+}
+
+// panickingServiceModel is the RSM service model whose decoders panic, as on some malformed PDUs
+type panickingServiceModel struct {
+	rsm.RsmServiceModel
+}
+
+func (sm panickingServiceModel) IndicationHeaderASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	panic("index out of range")
+}
+
+func (sm panickingServiceModel) IndicationHeaderProtoToASN1(protoBytes []byte) ([]byte, error) {
+	panic("index out of range")
+}
+
+func (sm panickingServiceModel) RanFuncDescriptionASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	panic("index out of range")
+}
+
+func TestPcapDecoder_Panic(t *testing.T) {
+	sm := panickingServiceModel{RsmServiceModel: rsm.RsmServiceModel("")}
+	out := &bytes.Buffer{}
+	decoder := newPcapDecoder(out, e2apPPID, nil)
+	assert.NilError(t, decoder.writePdu(pcapRecord{}, sm, "indication-header", []byte{0x08}))
+	record := pcapRecord{}
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &record), out.String())
+	assert.Equal(t, "panic: index out of range", record.Error)
+	assert.Equal(t, "08", record.Hex)
+	assert.Equal(t, 1, decoder.stats.Failed)
+
+	models := codec.ServiceModels
+	defer func() { codec.ServiceModels = models }()
+	codec.ServiceModels = []codec.ServiceModel{sm}
+	_, err := identifyRANfunction(e2apRANfunction{ID: 1, Definition: []byte{0x08}})
+	assert.ErrorContains(t, err, "not one of a compiled in service model")
+}
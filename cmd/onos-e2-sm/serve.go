@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	codecapi "github.com/onosproject/onos-e2-sm/api/codec/v1"
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultGrpcAddress = "localhost:5160"
+	defaultHTTPAddress = "localhost:8160"
+)
+
+// codecServer implements the CodecService with the compiled in service models
+type codecServer struct {
+	codecapi.UnimplementedCodecServiceServer
+}
+
+// Register registers the CodecService with a gRPC server
+func (s *codecServer) Register(server *grpc.Server) {
+	codecapi.RegisterCodecServiceServer(server, s)
+}
+
+func (s *codecServer) ListModels(ctx context.Context, request *codecapi.ListModelsRequest) (*codecapi.ListModelsResponse, error) {
+	response := &codecapi.ListModelsResponse{}
+	for _, sm := range codec.ServiceModels {
+		data := sm.ServiceModelData()
+		model := &codecapi.Model{
+			Name:   codec.ModelName(sm),
+			Oid:    string(data.OID),
+			Module: string(data.ModuleName),
+		}
+		for _, kind := range codec.PduKinds {
+			if _, err := newPduMessage(sm, kind); err == nil {
+				model.PduTypes = append(model.PduTypes, kind.Name)
+			}
+		}
+		response.Models = append(response.Models, model)
+	}
+	return response, nil
+}
+
+func (s *codecServer) Decode(ctx context.Context, request *codecapi.DecodeRequest) (*codecapi.DecodeResponse, error) {
+	sm, kind, msg, err := getCodecPdu(request.Model, request.PduType)
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	protoBytes, err := kind.Decode(sm, request.Aper)
+	if err != nil {
+		return nil, errors.Status(errors.NewInvalid("cannot decode the %s: %v", getASN1TypeName(sm, kind), err)).Err()
+	}
+	if err := proto.Unmarshal(protoBytes, msg); err != nil {
+		return nil, errors.Status(errors.NewInternal(err.Error())).Err()
+	}
+	jsonBytes, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, errors.Status(errors.NewInternal(err.Error())).Err()
+	}
+	jsonPdu := &structpb.Struct{}
+	if err := protojson.Unmarshal(jsonBytes, jsonPdu); err != nil {
+		return nil, errors.Status(errors.NewInternal(err.Error())).Err()
+	}
+	return &codecapi.DecodeResponse{Proto: protoBytes, Json: jsonPdu}, nil
+}
+
+func (s *codecServer) Encode(ctx context.Context, request *codecapi.EncodeRequest) (*codecapi.EncodeResponse, error) {
+	sm, kind, msg, err := getCodecPdu(request.Model, request.PduType)
+	if err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	protoBytes := request.GetProto()
+	if jsonPdu := request.GetJson(); jsonPdu != nil {
+		jsonBytes, err := protojson.Marshal(jsonPdu)
+		if err != nil {
+			return nil, errors.Status(errors.NewInvalid(err.Error())).Err()
+		}
+		if err := protojson.Unmarshal(jsonBytes, msg); err != nil {
+			return nil, errors.Status(errors.NewInvalid("invalid %s: %v", getASN1TypeName(sm, kind), err)).Err()
+		}
+		if protoBytes, err = proto.Marshal(msg); err != nil {
+			return nil, errors.Status(errors.NewInternal(err.Error())).Err()
+		}
+	} else if request.Pdu == nil {
+		return nil, errors.Status(errors.NewInvalid("no PDU to encode, either proto or json is expected")).Err()
+	}
+	asn1Bytes, err := kind.Encode(sm, protoBytes)
+	if err != nil {
+		return nil, errors.Status(errors.NewInvalid("cannot encode the %s: %v", getASN1TypeName(sm, kind), err)).Err()
+	}
+	return &codecapi.EncodeResponse{Aper: asn1Bytes}, nil
+}
+
+// recoverInterceptor turns the panics of the other gRPC handlers into internal errors
+func recoverInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp, err = nil, errors.Status(errors.NewInternal("%s: panic: %v", info.FullMethod, r)).Err()
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// getCodecPdu returns the service model, the PDU kind and an empty message of a codec request
+func getCodecPdu(model string, pduType string) (codec.ServiceModel, codec.PduKind, proto.Message, error) {
+	sm, err := codec.GetServiceModel(model)
+	if err != nil {
+		return nil, codec.PduKind{}, nil, errors.NewNotFound(err.Error())
+	}
+	kind, err := codec.GetPduKind(pduType)
+	if err != nil {
+		return nil, codec.PduKind{}, nil, errors.NewInvalid(err.Error())
+	}
+	msg, err := newPduMessage(sm, kind)
+	if err != nil {
+		return nil, codec.PduKind{}, nil, errors.NewNotSupported(err.Error())
+	}
+	return sm, kind, msg, nil
+}
+
+// newCodecHandler returns the HTTP handler of the CodecService: the requests and responses are
+// the JSON mapping of its messages, the errors are {"code": <gRPC code>, "message": <message>}
+func newCodecHandler(s *codecServer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeHTTPError(w, errors.Status(errors.NewNotSupported("method %s not allowed", r.Method)).Err())
+			return
+		}
+		response, err := s.ListModels(r.Context(), &codecapi.ListModelsRequest{})
+		writeHTTPResponse(w, response, err)
+	})
+	mux.HandleFunc("/v1/decode", func(w http.ResponseWriter, r *http.Request) {
+		request := &codecapi.DecodeRequest{}
+		if err := readHTTPRequest(r, request); err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+		response, err := s.Decode(r.Context(), request)
+		writeHTTPResponse(w, response, err)
+	})
+	mux.HandleFunc("/v1/encode", func(w http.ResponseWriter, r *http.Request) {
+		request := &codecapi.EncodeRequest{}
+		if err := readHTTPRequest(r, request); err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+		response, err := s.Encode(r.Context(), request)
+		writeHTTPResponse(w, response, err)
+	})
+	return mux
+}
+
+func readHTTPRequest(r *http.Request, request proto.Message) error {
+	if r.Method != http.MethodPost {
+		return errors.Status(errors.NewNotSupported("method %s not allowed", r.Method)).Err()
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.Status(errors.NewInvalid(err.Error())).Err()
+	}
+	if err := protojson.Unmarshal(body, request); err != nil {
+		return errors.Status(errors.NewInvalid("invalid request: %v", err)).Err()
+	}
+	return nil
+}
+
+func writeHTTPResponse(w http.ResponseWriter, response proto.Message, err error) {
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	jsonBytes, err := protojson.Marshal(response)
+	if err != nil {
+		writeHTTPError(w, errors.Status(errors.NewInternal(err.Error())).Err())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(jsonBytes)
+}
+
+func writeHTTPError(w http.ResponseWriter, err error) {
+	st := errors.Status(errors.FromGRPC(err))
+	jsonBytes, _ := protojson.Marshal(st.Proto())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	_, _ = w.Write(jsonBytes)
+}
+
+// httpStatus returns the HTTP status of a gRPC status code
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// serveCodec serves the CodecService on the gRPC and HTTP listeners which are not nil, until the context is done
+func serveCodec(ctx context.Context, grpcListener, httpListener net.Listener) error {
+	s := &codecServer{}
+	errCh := make(chan error, 2)
+
+	var grpcServer *grpc.Server
+	if grpcListener != nil {
+		grpcServer = grpc.NewServer(grpc.UnaryInterceptor(recoverInterceptor))
+		s.Register(grpcServer)
+		go func() {
+			errCh <- grpcServer.Serve(grpcListener)
+		}()
+	}
+	var httpServer *http.Server
+	if httpListener != nil {
+		httpServer = &http.Server{Handler: newCodecHandler(s)}
+		go func() {
+			if err := httpServer.Serve(httpListener); err != http.ErrServerClosed {
+				errCh <- err
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errCh:
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if httpServer != nil {
+		_ = httpServer.Shutdown(context.Background())
+	}
+	return err
+}
+
+func getServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serves the encoding and decoding of the PDUs of the compiled in service models over gRPC and HTTP",
+		Long: `Serves the encoding and decoding of the PDUs of the compiled in service models, as a local sidecar
+for the tools without an APER codec, e.g. the Python bindings of the service models:
+  gRPC  onos.e2sm.codec.v1.CodecService, see api/codec/v1/codec.proto
+  HTTP  GET /v1/models, POST /v1/decode and POST /v1/encode, with the JSON mapping of the same messages
+The PDUs are decoded into both their protobuf encoding and their JSON mapping, and are encoded from either.
+An empty address disables its server. The servers are not secured and listen on localhost by default.`,
+		Example: `  onos-e2-sm serve
+  curl -d '{"model": "e2sm_rc_pre/v2_go", "pduType": "control-header", "aper": "NBL0EKvUvAAB"}' localhost:8160/v1/decode`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grpcAddress, _ := cmd.Flags().GetString("grpc-address")
+			httpAddress, _ := cmd.Flags().GetString("http-address")
+			if grpcAddress == "" && httpAddress == "" {
+				return errors.NewInvalid("either --grpc-address or --http-address is required")
+			}
+
+			var grpcListener, httpListener net.Listener
+			var err error
+			if grpcAddress != "" {
+				if grpcListener, err = net.Listen("tcp", grpcAddress); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Serving gRPC on %s\n", grpcListener.Addr())
+			}
+			if httpAddress != "" {
+				if httpListener, err = net.Listen("tcp", httpAddress); err != nil {
+					if grpcListener != nil {
+						grpcListener.Close()
+					}
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Serving HTTP on %s\n", httpListener.Addr())
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return serveCodec(ctx, grpcListener, httpListener)
+		},
+	}
+	cmd.Flags().String("grpc-address", defaultGrpcAddress, "the address of the gRPC server, empty to disable it")
+	cmd.Flags().String("http-address", defaultHTTPAddress, "the address of the HTTP server, empty to disable it")
+	return cmd
+}
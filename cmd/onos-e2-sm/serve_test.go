@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	codecapi "github.com/onosproject/onos-e2-sm/api/codec/v1"
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	rsm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/servicemodel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gotest.tools/assert"
+)
+
+func TestCodecService(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- serveCodec(ctx, lis, nil)
+	}()
+	defer func() {
+		cancel()
+		assert.NilError(t, <-done)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NilError(t, err)
+	defer conn.Close()
+	client := codecapi.NewCodecServiceClient(conn)
+
+	models, err := client.ListModels(ctx, &codecapi.ListModelsRequest{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(codec.ServiceModels), len(models.Models))
+	for _, model := range models.Models {
+		if model.Name == "e2sm_rc_pre/v2_go" {
+			assert.Equal(t, "1.3.6.1.4.1.53148.1.2.2.100", model.Oid)
+			assert.Assert(t, len(model.PduTypes) > 0)
+		}
+	}
+
+	sm, err := codec.GetServiceModel("e2sm_rc_pre/v2_go")
+	assert.NilError(t, err)
+	kind, err := codec.GetPduKind("indication-header")
+	assert.NilError(t, err)
+	vector, ok := getPduVector(string(sm.ServiceModelData().OID), kind)
+	assert.Assert(t, ok)
+
+	decoded, err := client.Decode(ctx, &codecapi.DecodeRequest{Model: "e2sm_rc_pre", PduType: "IndicationHeader", Aper: vector})
+	assert.NilError(t, err)
+	assert.Assert(t, len(decoded.Proto) > 0)
+	assert.Assert(t, decoded.Json.Fields["indicationHeader_Format1"] != nil, decoded.Json.String())
+
+	encoded, err := client.Encode(ctx, &codecapi.EncodeRequest{Model: "e2sm_rc_pre", PduType: "indication-header",
+		Pdu: &codecapi.EncodeRequest_Proto{Proto: decoded.Proto}})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, vector, encoded.Aper)
+	encoded, err = client.Encode(ctx, &codecapi.EncodeRequest{Model: "e2sm_rc_pre", PduType: "indication-header",
+		Pdu: &codecapi.EncodeRequest_Json{Json: decoded.Json}})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, vector, encoded.Aper)
+
+	_, err = client.Decode(ctx, &codecapi.DecodeRequest{Model: "e2sm_unknown", PduType: "indication-header", Aper: vector})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.Decode(ctx, &codecapi.DecodeRequest{Model: "e2sm_rc_pre", PduType: "indication-header", Aper: []byte{0xff}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Encode(ctx, &codecapi.EncodeRequest{Model: "e2sm_rc_pre", PduType: "indication-header"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCodecService_Panic(t *testing.T) {
+	models := codec.ServiceModels
+	defer func() { codec.ServiceModels = models }()
+	codec.ServiceModels = []codec.ServiceModel{panickingServiceModel{RsmServiceModel: rsm.RsmServiceModel("")}}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- serveCodec(ctx, lis, nil)
+	}()
+	defer func() {
+		cancel()
+		assert.NilError(t, <-done)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.NilError(t, err)
+	defer conn.Close()
+	client := codecapi.NewCodecServiceClient(conn)
+
+	_, err = client.Decode(ctx, &codecapi.DecodeRequest{Model: "e2sm_rsm", PduType: "indication-header", Aper: []byte{0x08}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "panic: index out of range")
+	_, err = client.Encode(ctx, &codecapi.EncodeRequest{Model: "e2sm_rsm", PduType: "indication-header",
+		Pdu: &codecapi.EncodeRequest_Proto{Proto: []byte{}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The server still serves
+	_, err = client.ListModels(ctx, &codecapi.ListModelsRequest{})
+	assert.NilError(t, err)
+}
+
+func TestRecoverInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/onos.e2sm.codec.v1.CodecService/Decode"}
+	_, err := recoverInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("nil map")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.ErrorContains(t, err, "/onos.e2sm.codec.v1.CodecService/Decode: panic: nil map")
+}
+
+func TestCodecHandler(t *testing.T) {
+	server := httptest.NewServer(newCodecHandler(&codecServer{}))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/v1/models")
+	assert.NilError(t, err)
+	listBody, err := ioutil.ReadAll(response.Body)
+	assert.NilError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	models := &codecapi.ListModelsResponse{}
+	assert.NilError(t, protojson.Unmarshal(listBody, models))
+	assert.Equal(t, len(codec.ServiceModels), len(models.Models))
+
+	post := func(path string, request string) (int, string) {
+		response, err := http.Post(server.URL+path, "application/json", strings.NewReader(request))
+		assert.NilError(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		assert.NilError(t, err)
+		return response.StatusCode, string(body)
+	}
+
+	// The control header of the build test, in base64
+	code, body := post("/v1/decode", `{"model": "e2sm_rc_pre/v2_go", "pduType": "control-header", "aper": "NBL0EKvUvAAB"}`)
+	assert.Equal(t, http.StatusOK, code, body)
+	decoded := &codecapi.DecodeResponse{}
+	assert.NilError(t, protojson.Unmarshal([]byte(body), decoded))
+	assert.Assert(t, strings.Contains(body, `"eUTRA_CGI"`), body)
+
+	json, err := protojson.Marshal(decoded.Json)
+	assert.NilError(t, err)
+	code, body = post("/v1/encode", `{"model": "e2sm_rc_pre/v2_go", "pduType": "control-header", "json": `+string(json)+`}`)
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.Assert(t, strings.Contains(body, `"aper":"NBL0EKvUvAAB"`), body)
+
+	code, body = post("/v1/decode", `{"model": "e2sm_rc_pre/v2_go", "pduType": "unknown"}`)
+	assert.Equal(t, http.StatusBadRequest, code, body)
+	assert.Assert(t, strings.Contains(body, `"code":3`), body)
+	code, _ = post("/v1/decode", `not json`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = post("/v1/models", `{}`)
+	assert.Equal(t, http.StatusNotImplemented, code)
+}
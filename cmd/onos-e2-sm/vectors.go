@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/hex"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+)
+
+// pduVectors are APER encoded PDUs by service model OID and PDU kind, used to smoke test the service models.
+// They are taken from the unit tests of the service models (e2sm_mho_compat for MHO v1), re-encoded where needed
+// so that each of them decodes and re-encodes to the same bytes.
+var pduVectors = map[string]map[string]string{
+	// E2SM-KPM v2, shared by e2sm_kpm_v2 and e2sm_kpm_v2_go
+	"1.3.6.1.4.1.53148.1.2.2.2": {
+		"ran-function-description": "74046f6e660000056f69643132330700736f6d654465736372697074696f6e00150000430021222300d4bc08803039201a8500000000034f4e46002122230000002000000b01006f6e66000f000b01006f6e66000f000041a04f70656e4e6574776f726b696e67000017002f0018",
+		"event-trigger-definition": "000b",
+		"action-definition":        "000c4000036f6e6600000040747269616c000048210200c90014403038",
+		"indication-header":        "1f21222324187478740000034f4e4640736f6d6554797065066f6e660c37343700d4bc08803039201a85",
+		"indication-message":       "0e8030380000036f6e66001400004020747269616c013fffe02122234040010203000a7c0f000f0001724000fa00000400007a0001c7000314000000400208303940",
+	},
+	// E2SM-RC-PRE v2, shared by e2sm_rc_pre and e2sm_rc_pre_go
+	"1.3.6.1.4.1.53148.1.2.2.100": {
+		"ran-function-description": "20204f4e460000024f696406804f70656e4e6574776f726b696e67000360000d03804f4e466576656e74002a000c04004f4e467265706f727400150038",
+		"event-trigger-definition": "140b",
+		"indication-header":        "2812f410abd4bc00",
+		"indication-message":       "40fd60000b00012012f410acd4bc00fd60000b",
+		"control-header":           "3412f410abd4bc0001",
+		"control-message":          "0000010100504349000014",
+		"control-outcome":          "200000000014",
+	},
+	// E2SM-MHO v2 (e2sm_mho_go)
+	"1.3.6.1.4.1.53148.1.2.2.101": {
+		"ran-function-description": "20204f4e460000024f696406804f70656e4e6574776f726b696e670103600681c04f4e466576656e74002a0030104f4e467265706f727400150038",
+		"event-trigger-definition": "14010c",
+		"indication-header":        "1012f410abd4bc00",
+		"indication-message":       "0004313233340080aafdd400000040040104d20015",
+		"control-header":           "2001",
+		"control-message":          "1012f410abd4bc0004313233344012f410abd4bc00",
+	},
+	// E2SM-RSM v1 (e2sm_rsm)
+	"1.3.6.1.4.1.53148.1.1.2.102": {
+		"ran-function-description": "00704532534d2d52534d00001a312e332e362e312e342e312e35333134382e312e312e322e3130320c0052414e20536c6963696e672053657276696365204d6f64656c010047001b40010a40804320",
+		"event-trigger-definition": "00",
+		"indication-header":        "0000010f0000000010",
+		"indication-message":       "060001001b000e0003640064c9e1400164e5e0101820025b001f6d6a802552c0",
+		"control-header":           "08",
+		"control-message":          "690007082c7f2d3e04053e6c800b00070013",
+	},
+	// E2SM-MHO v1 (e2sm_mho), encoded with the CGo implementation
+	"1.3.6.1.4.1.53148.1.1.2.101": {
+		"ran-function-description": "00604f4e462d4d484f00001a312e332e362e312e342e312e35333134382e312e312e322e31303101004d484f60008380506572696f646963207265706f727400010004224d6561737572656d656e74207265706f727400010001",
+		"event-trigger-definition": "100203e8",
+		"indication-header":        "0012f410efabd4bc00",
+		"indication-message":       "000431323334004012f410abd4bc02ff64",
+		"control-header":           "200a",
+		"control-message":          "0012f410efabd4bc0004313233344012f410abd4bc00",
+	},
+}
+
+// getPduVector returns the vector of a PDU kind for a service model OID
+func getPduVector(oid string, kind codec.PduKind) ([]byte, bool) {
+	vector, ok := pduVectors[oid][kind.Name]
+	if !ok {
+		return nil, false
+	}
+	bytes, err := hex.DecodeString(vector)
+	if err != nil {
+		return nil, false
+	}
+	return bytes, true
+}
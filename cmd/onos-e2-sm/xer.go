@@ -0,0 +1,536 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The Go service models only implement APER, XER (X.693 basic XER, as produced by the asn1c XER encoders of the
+// CGo service models) is derived from the protobuf messages, which mirror the ASN.1 types:
+//  - the json_name of a field is the ASN.1 identifier of the component
+//  - a message with a single oneof is a CHOICE, a message with a single value field is a defined type
+//  - a message with a single repeated field is a SEQUENCE OF
+//  - a BitString message (value and len) is a BIT STRING
+// The json_name of some fields has '_' in place of the '-' of the ASN.1 identifier, e.g. ranFunction_Instance.
+// The ASN.1 type names are not recorded in the protobuf descriptors: the items of a SEQUENCE OF are named after
+// the {Type-Name} comment of their message in the .proto files (see asn1TypeNames) when encoding, and any item
+// name is accepted when decoding. The identifiers of an ENUMERATED are derived from the protobuf enum values,
+// and are matched ignoring case, '-' and '_'.
+
+//go:generate go run gen_asn1names.go
+
+const xerIndent = "    "
+
+// xerEncode encodes a message in basic XER as the content of the root element name
+func xerEncode(msg proto.Message, name string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := xerEncodeMessage(buf, name, msg.ProtoReflect(), 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func xerEncodeMessage(buf *bytes.Buffer, name string, m protoreflect.Message, depth int) error {
+	indent := strings.Repeat(xerIndent, depth)
+	desc := m.Descriptor()
+	switch {
+	case isBitString(desc):
+		fmt.Fprintf(buf, "%s<%s>%s</%s>\n", indent, name, formatBitString(m), name)
+		return nil
+	case isChoice(desc):
+		fd := m.WhichOneof(desc.Oneofs().Get(0))
+		if fd == nil {
+			return fmt.Errorf("no alternative of the CHOICE %s is set", desc.FullName())
+		}
+		fmt.Fprintf(buf, "%s<%s>\n", indent, name)
+		if err := xerEncodeField(buf, m, fd, depth+1); err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "%s</%s>\n", indent, name)
+		return nil
+	case isDefinedType(desc):
+		fd := desc.Fields().Get(0)
+		if fd.Kind() == protoreflect.MessageKind {
+			return xerEncodeMessage(buf, name, m.Get(fd).Message(), depth)
+		}
+		return xerEncodeValue(buf, name, fd, m.Get(fd), depth)
+	case isSequenceOf(desc):
+		return xerEncodeList(buf, name, desc.Fields().Get(0), m.Get(desc.Fields().Get(0)).List(), depth)
+	}
+
+	fields := make([]protoreflect.FieldDescriptor, 0, desc.Fields().Len())
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		// Scalars without presence are mandatory components
+		if (fd.IsList() && m.Get(fd).List().Len() == 0) || (fd.HasPresence() && !m.Has(fd)) {
+			continue
+		}
+		fields = append(fields, fd)
+	}
+	if len(fields) == 0 {
+		fmt.Fprintf(buf, "%s<%s/>\n", indent, name)
+		return nil
+	}
+	fmt.Fprintf(buf, "%s<%s>\n", indent, name)
+	for _, fd := range fields {
+		if err := xerEncodeField(buf, m, fd, depth+1); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(buf, "%s</%s>\n", indent, name)
+	return nil
+}
+
+func xerEncodeField(buf *bytes.Buffer, m protoreflect.Message, fd protoreflect.FieldDescriptor, depth int) error {
+	name := asn1Identifier(fd)
+	if !fd.IsList() {
+		if fd.Kind() == protoreflect.MessageKind {
+			return xerEncodeMessage(buf, name, m.Get(fd).Message(), depth)
+		}
+		return xerEncodeValue(buf, name, fd, m.Get(fd), depth)
+	}
+	return xerEncodeList(buf, name, fd, m.Get(fd).List(), depth)
+}
+
+func xerEncodeList(buf *bytes.Buffer, name string, fd protoreflect.FieldDescriptor, list protoreflect.List, depth int) error {
+	indent := strings.Repeat(xerIndent, depth)
+	itemName := xerItemName(fd)
+	fmt.Fprintf(buf, "%s<%s>\n", indent, name)
+	for i := 0; i < list.Len(); i++ {
+		var err error
+		if fd.Kind() == protoreflect.MessageKind && isChoice(fd.Message()) {
+			// The items of a SEQUENCE OF CHOICE are the alternatives, without an element for the item
+			item := list.Get(i).Message()
+			alternative := item.WhichOneof(fd.Message().Oneofs().Get(0))
+			if alternative == nil {
+				return fmt.Errorf("no alternative of the CHOICE %s is set", fd.Message().FullName())
+			}
+			err = xerEncodeField(buf, item, alternative, depth+1)
+		} else if fd.Kind() == protoreflect.MessageKind {
+			err = xerEncodeMessage(buf, itemName, list.Get(i).Message(), depth+1)
+		} else {
+			err = xerEncodeValue(buf, itemName, fd, list.Get(i), depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(buf, "%s</%s>\n", indent, name)
+	return nil
+}
+
+func xerEncodeValue(buf *bytes.Buffer, name string, fd protoreflect.FieldDescriptor, v protoreflect.Value, depth int) error {
+	indent := strings.Repeat(xerIndent, depth)
+	var content string
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		content = fmt.Sprintf("<%t/>", v.Bool())
+	case protoreflect.EnumKind:
+		ev := fd.Enum().Values().ByNumber(v.Enum())
+		if ev == nil {
+			return fmt.Errorf("unknown value %d of the ENUMERATED %s", v.Enum(), fd.Enum().FullName())
+		}
+		content = fmt.Sprintf("<%s/>", enumIdentifier(fd.Enum(), ev))
+	case protoreflect.BytesKind:
+		content = formatOctetString(v.Bytes())
+	case protoreflect.StringKind:
+		escaped := &bytes.Buffer{}
+		if err := xml.EscapeText(escaped, []byte(v.String())); err != nil {
+			return err
+		}
+		content = escaped.String()
+	default:
+		content = v.String()
+	}
+	fmt.Fprintf(buf, "%s<%s>%s</%s>\n", indent, name, content, name)
+	return nil
+}
+
+// xerNode is an element of a XER document
+type xerNode struct {
+	Name     string
+	Text     string
+	Children []*xerNode
+}
+
+// TrimmedText returns the text of the element without whitespaces
+func (n *xerNode) TrimmedText() string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, n.Text)
+}
+
+// parseXer parses a XER document into its root element
+func parseXer(data []byte) (*xerNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	stack := make([]*xerNode, 0)
+	var root *xerNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xerNode{Name: t.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else if root != nil {
+				return nil, fmt.Errorf("unexpected element %s after the root element %s", node.Name, root.Name)
+			} else {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no XER element found")
+	}
+	return root, nil
+}
+
+// xerDecode decodes a XER document into a message. The name of the root element is not checked.
+func xerDecode(data []byte, msg proto.Message) error {
+	root, err := parseXer(data)
+	if err != nil {
+		return err
+	}
+	return xerDecodeMessage(root, msg.ProtoReflect())
+}
+
+func xerDecodeMessage(node *xerNode, m protoreflect.Message) error {
+	desc := m.Descriptor()
+	switch {
+	case isBitString(desc):
+		return parseBitString(node, m)
+	case isChoice(desc):
+		if len(node.Children) != 1 {
+			return fmt.Errorf("%s: expected a single alternative of the CHOICE %s, got %d", node.Name, desc.FullName(), len(node.Children))
+		}
+		child := node.Children[0]
+		fd := findField(desc.Oneofs().Get(0).Fields(), child.Name)
+		if fd == nil {
+			return fmt.Errorf("%s: unknown alternative %s of the CHOICE %s", node.Name, child.Name, desc.FullName())
+		}
+		return xerDecodeField(child, m, fd)
+	case isDefinedType(desc), isSequenceOf(desc):
+		return xerDecodeField(node, m, desc.Fields().Get(0))
+	}
+
+	for _, child := range node.Children {
+		fd := findField(desc.Fields(), child.Name)
+		if fd == nil {
+			return fmt.Errorf("%s: unknown component %s of %s", node.Name, child.Name, desc.FullName())
+		}
+		if err := xerDecodeField(child, m, fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func xerDecodeField(node *xerNode, m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if !fd.IsList() {
+		if fd.Kind() == protoreflect.MessageKind {
+			value := m.NewField(fd)
+			if err := xerDecodeMessage(node, value.Message()); err != nil {
+				return err
+			}
+			m.Set(fd, value)
+			return nil
+		}
+		value, err := xerDecodeValue(node, fd)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, value)
+		return nil
+	}
+
+	list := m.Mutable(fd).List()
+	for _, child := range node.Children {
+		if fd.Kind() == protoreflect.MessageKind {
+			item := list.NewElement()
+			itemNode := child
+			if isChoice(fd.Message()) {
+				itemNode = &xerNode{Name: xerItemName(fd), Children: []*xerNode{child}}
+			}
+			if err := xerDecodeMessage(itemNode, item.Message()); err != nil {
+				return err
+			}
+			list.Append(item)
+			continue
+		}
+		item, err := xerDecodeValue(child, fd)
+		if err != nil {
+			return err
+		}
+		list.Append(item)
+	}
+	return nil
+}
+
+func xerDecodeValue(node *xerNode, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	text := node.TrimmedText()
+	// BOOLEAN and ENUMERATED values are empty elements, e.g. <true/>
+	if len(node.Children) == 1 && text == "" {
+		text = node.Children[0].Name
+	}
+	// NULL is an empty element, the service models represent it as an integer
+	if text == "" && len(node.Children) == 0 && fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind {
+		return fd.Default(), nil
+	}
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, fmt.Errorf("%s: invalid %s value %q: %v", node.Name, fd.Kind(), text, err)
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(value), nil
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			ev := values.Get(i)
+			if normalizeName(text) == normalizeName(enumIdentifier(fd.Enum(), ev)) || normalizeName(text) == normalizeName(string(ev.Name())) {
+				return protoreflect.ValueOfEnum(ev.Number()), nil
+			}
+		}
+		return invalid(fmt.Errorf("not an identifier of %s", fd.Enum().FullName()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		value, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(value)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(value), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		value, err := strconv.ParseUint(text, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint32(uint32(value)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		value, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfUint64(value), nil
+	case protoreflect.FloatKind:
+		value, err := strconv.ParseFloat(text, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat32(float32(value)), nil
+	case protoreflect.DoubleKind:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat64(value), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(node.Text), nil
+	case protoreflect.BytesKind:
+		value, err := hex.DecodeString(text)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBytes(value), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("%s: unsupported field kind %s", node.Name, fd.Kind())
+}
+
+// isBitString returns whether a message is a BIT STRING, i.e. a BitString message with the value and len fields
+func isBitString(desc protoreflect.MessageDescriptor) bool {
+	if desc.Name() != "BitString" || desc.Fields().Len() != 2 {
+		return false
+	}
+	value, length := desc.Fields().ByName("value"), desc.Fields().ByName("len")
+	return value != nil && value.Kind() == protoreflect.BytesKind && length != nil && length.Kind() == protoreflect.Uint32Kind
+}
+
+// isChoice returns whether a message is a CHOICE, i.e. all of its fields are in a single oneof
+func isChoice(desc protoreflect.MessageDescriptor) bool {
+	return desc.Oneofs().Len() == 1 && desc.Oneofs().Get(0).Fields().Len() == desc.Fields().Len()
+}
+
+// isDefinedType returns whether a message wraps a single value, e.g. PlmnIdentity
+func isDefinedType(desc protoreflect.MessageDescriptor) bool {
+	return desc.Fields().Len() == 1 && desc.Fields().Get(0).Name() == "value" && !desc.Fields().Get(0).IsList()
+}
+
+// isSequenceOf returns whether a message wraps a single list, e.g. MeasurementInfoList
+func isSequenceOf(desc protoreflect.MessageDescriptor) bool {
+	return desc.Fields().Len() == 1 && desc.Fields().Get(0).IsList() && !desc.Fields().Get(0).IsMap()
+}
+
+// formatBitString formats a BIT STRING as its binary digits
+func formatBitString(m protoreflect.Message) string {
+	desc := m.Descriptor()
+	value := m.Get(desc.Fields().ByName("value")).Bytes()
+	length := int(m.Get(desc.Fields().ByName("len")).Uint())
+	bits := make([]byte, 0, length)
+	for i := 0; i < length && i/8 < len(value); i++ {
+		if value[i/8]&(0x80>>uint(i%8)) != 0 {
+			bits = append(bits, '1')
+		} else {
+			bits = append(bits, '0')
+		}
+	}
+	return string(bits)
+}
+
+// parseBitString sets a BitString message from the binary digits of a BIT STRING
+func parseBitString(node *xerNode, m protoreflect.Message) error {
+	if err := setBitString(m, node.TrimmedText()); err != nil {
+		return fmt.Errorf("%s: %v", node.Name, err)
+	}
+	return nil
+}
+
+// setBitString sets a BIT STRING message to binary digits
+func setBitString(m protoreflect.Message, bits string) error {
+	value := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		switch bit {
+		case '1':
+			value[i/8] |= 0x80 >> uint(i%8)
+		case '0':
+		default:
+			return fmt.Errorf("invalid BIT STRING %q", bits)
+		}
+	}
+	desc := m.Descriptor()
+	m.Set(desc.Fields().ByName("value"), protoreflect.ValueOfBytes(value))
+	m.Set(desc.Fields().ByName("len"), protoreflect.ValueOfUint32(uint32(len(bits))))
+	return nil
+}
+
+// formatOctetString formats an OCTET STRING as space separated hexadecimal octets, as asn1c does
+func formatOctetString(value []byte) string {
+	octets := make([]string, len(value))
+	for i, b := range value {
+		octets[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(octets, " ")
+}
+
+// xerItemName returns the element name of the items of a SEQUENCE OF, the ASN.1 type name of the items
+func xerItemName(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return asn1TypeName(fd.Message())
+	case protoreflect.EnumKind:
+		return asn1TypeName(fd.Enum())
+	case protoreflect.BoolKind:
+		return "BOOLEAN"
+	case protoreflect.StringKind:
+		return "PrintableString"
+	case protoreflect.BytesKind:
+		return "OCTET_STRING"
+	default:
+		return "INTEGER"
+	}
+}
+
+// findField returns the field of which the ASN.1 identifier (json_name) or protobuf name matches an element name
+func findField(fields protoreflect.FieldDescriptors, name string) protoreflect.FieldDescriptor {
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if asn1Identifier(fd) == name {
+			return fd
+		}
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if normalizeName(asn1Identifier(fd)) == normalizeName(name) || normalizeName(string(fd.Name())) == normalizeName(name) {
+			return fd
+		}
+	}
+	return nil
+}
+
+// asn1TypeName returns the ASN.1 type name of a message or enum, its protobuf name if it is not known
+func asn1TypeName(desc protoreflect.Descriptor) string {
+	if name, ok := asn1TypeNames[desc.ParentFile().Path()][desc.Name()]; ok {
+		return name
+	}
+	return string(desc.Name())
+}
+
+// asn1Identifier returns the ASN.1 identifier of a field, i.e. its json_name without the suffixes some service
+// models append to it and with '-' in place of '_', e.g. ranFunction_Instance:OPTIONAL is ranFunction-Instance
+func asn1Identifier(fd protoreflect.FieldDescriptor) string {
+	name := fd.JSONName()
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.ReplaceAll(name, "_", "-")
+}
+
+// enumIdentifier returns the ASN.1 identifier of an enum value: its name without the prefix of the enum,
+// in lower camel case, e.g. UE_ID_TYPE_CU_UE_F1_AP_ID is cuUeF1ApId
+func enumIdentifier(ed protoreflect.EnumDescriptor, ev protoreflect.EnumValueDescriptor) string {
+	name := string(ev.Name())
+	name = strings.TrimPrefix(name, upperSnakeCase(string(ed.Name()))+"_")
+	words := strings.Split(strings.ToLower(name), "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// upperSnakeCase converts a CamelCase name to UPPER_SNAKE_CASE as protoc-gen-go does for the enum value
+// prefixes, e.g. UeIdType is UE_ID_TYPE and SUM is SUM
+func upperSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// normalizeName returns a name in lower case without '-' and '_', to match ASN.1 identifiers and protobuf names
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/pkg/codec"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+// kpmRanFunctionDescriptionCGoXer is the XER of a RAN function description encoded by XerEncodeE2SmKpmRanfunctionDescription
+// of the CGo KPM v2 service model (servicemodels/e2sm_kpm_v2/kpmctypes), whose ASN.1 type names end with -KPMv2
+const kpmRanFunctionDescriptionCGoXer = `<E2SM-KPMv2-RANfunction-Description>
+    <ranFunction-Name>
+        <ranFunction-ShortName>onf</ranFunction-ShortName>
+        <ranFunction-E2SM-OID>oid123</ranFunction-E2SM-OID>
+        <ranFunction-Description>someDescription</ranFunction-Description>
+        <ranFunction-Instance>21</ranFunction-Instance>
+    </ranFunction-Name>
+    <ric-KPM-Node-List>
+        <RIC-KPMNode-Item-KPMv2>
+            <ric-KPMNode-Type>
+                <gNB>
+                    <global-gNB-ID>
+                        <plmn-id>21 22 23</plmn-id>
+                        <gnb-id>
+                            <gnb-ID>
+                                1101010010111100000010
+                            </gnb-ID>
+                        </gnb-id>
+                    </global-gNB-ID>
+                    <gNB-CU-UP-ID>12345</gNB-CU-UP-ID>
+                    <gNB-DU-ID>6789</gNB-DU-ID>
+                </gNB>
+            </ric-KPMNode-Type>
+            <cell-Measurement-Object-List>
+                <Cell-Measurement-Object-Item-KPMv2>
+                    <cell-object-ID>ONF</cell-object-ID>
+                    <cell-global-ID>
+                        <nr-CGI>
+                            <pLMN-Identity>21 22 23</pLMN-Identity>
+                            <nRCellIdentity>
+                                110101011011110000001001000000000000
+                            </nRCellIdentity>
+                        </nr-CGI>
+                    </cell-global-ID>
+                </Cell-Measurement-Object-Item-KPMv2>
+            </cell-Measurement-Object-List>
+        </RIC-KPMNode-Item-KPMv2>
+    </ric-KPM-Node-List>
+    <ric-EventTriggerStyle-List>
+        <RIC-EventTriggerStyle-Item-KPMv2>
+            <ric-EventTriggerStyle-Type>11</ric-EventTriggerStyle-Type>
+            <ric-EventTriggerStyle-Name>onf</ric-EventTriggerStyle-Name>
+            <ric-EventTriggerFormat-Type>15</ric-EventTriggerFormat-Type>
+        </RIC-EventTriggerStyle-Item-KPMv2>
+    </ric-EventTriggerStyle-List>
+    <ric-ReportStyle-List>
+        <RIC-ReportStyle-Item-KPMv2>
+            <ric-ReportStyle-Type>11</ric-ReportStyle-Type>
+            <ric-ReportStyle-Name>onf</ric-ReportStyle-Name>
+            <ric-ActionFormat-Type>15</ric-ActionFormat-Type>
+            <measInfo-Action-List>
+                <MeasurementInfo-Action-Item-KPMv2>
+                    <measName>OpenNetworking</measName>
+                    <measID>24</measID>
+                </MeasurementInfo-Action-Item-KPMv2>
+            </measInfo-Action-List>
+            <ric-IndicationHeaderFormat-Type>2</ric-IndicationHeaderFormat-Type>
+            <ric-IndicationMessageFormat-Type>1</ric-IndicationMessageFormat-Type>
+        </RIC-ReportStyle-Item-KPMv2>
+    </ric-ReportStyle-List>
+</E2SM-KPMv2-RANfunction-Description>`
+
+// xerTokens returns the elements and the text of a XER document, ignoring the indentation
+func xerTokens(t *testing.T, data []byte) []string {
+	tokens := make([]string, 0)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return tokens
+		}
+		assert.NilError(t, err)
+		switch token := token.(type) {
+		case xml.StartElement:
+			tokens = append(tokens, "<"+token.Name.Local+">")
+		case xml.EndElement:
+			tokens = append(tokens, "</"+token.Name.Local+">")
+		case xml.CharData:
+			if text := strings.TrimSpace(string(token)); text != "" {
+				tokens = append(tokens, text)
+			}
+		}
+	}
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	assert.Equal(t, "UE_ID_TYPE", upperSnakeCase("UeIdType"))
+	assert.Equal(t, "SUM", upperSnakeCase("SUM"))
+	assert.Equal(t, "RC_PRE_COMMAND", upperSnakeCase("RcPreCommand"))
+	assert.Equal(t, "E2_SM_RSM_COMMAND", upperSnakeCase("E2SmRsmCommand"))
+}
+
+func TestXerEncodeCGo(t *testing.T) {
+	// The APER encoding of kpmRanFunctionDescriptionCGoXer, from the tests of kpmctypes
+	asn1Bytes, err := hex.DecodeString("74046f6e660000056f69643132330700736f6d654465736372697074696f6e00150000430021222300d4bc0880" +
+		"3039201a8500000000034f4e4600212223d5bc090000000b01006f6e66000f000b01006f6e66000f000041a04f70656e4e6574776f726b696e6700001700020001")
+	assert.NilError(t, err)
+	sm, err := codec.GetServiceModel("e2sm_kpm/v2_go")
+	assert.NilError(t, err)
+	kind, err := codec.GetPduKind("ran-function-description")
+	assert.NilError(t, err)
+	protoBytes, err := kind.Decode(sm, asn1Bytes)
+	assert.NilError(t, err)
+	description := &e2smkpmv2.E2SmKpmRanfunctionDescription{}
+	assert.NilError(t, proto.Unmarshal(protoBytes, description))
+
+	data, err := xerEncode(description, getASN1TypeName(sm, kind))
+	assert.NilError(t, err)
+	expected := strings.ReplaceAll(kpmRanFunctionDescriptionCGoXer, "E2SM-KPMv2-", "E2SM-KPM-")
+	expected = strings.ReplaceAll(expected, "-KPMv2>", ">")
+	assert.DeepEqual(t, xerTokens(t, []byte(expected)), xerTokens(t, data))
+
+	// The XER of the CGo service model is read as is
+	decoded := &e2smkpmv2.E2SmKpmRanfunctionDescription{}
+	assert.NilError(t, xerDecode([]byte(kpmRanFunctionDescriptionCGoXer), decoded))
+	assert.Assert(t, proto.Equal(description, decoded))
+}
+
+func TestXerDecode(t *testing.T) {
+	header := &e2smkpmv2.E2SmKpmIndicationHeader{}
+	assert.NilError(t, xerDecode([]byte(kpmIndicationHeaderXer), header))
+	format1 := header.GetIndicationHeaderFormats().GetIndicationHeaderFormat1()
+	assert.Equal(t, "ONF", format1.GetSenderName())
+	gnb := format1.GetKpmNodeId().GetGNb()
+	assert.DeepEqual(t, []byte{0x37, 0x34, 0x37}, gnb.GetGlobalGNbId().GetPlmnId().GetValue())
+	assert.Equal(t, uint32(22), gnb.GetGlobalGNbId().GetGnbId().GetGnbId().GetLen())
+	assert.DeepEqual(t, []byte{0xd4, 0xbc, 0x08}, gnb.GetGlobalGNbId().GetGnbId().GetGnbId().GetValue())
+	assert.Equal(t, int64(12345), gnb.GetGNbCuUpId().GetValue())
+
+	// Enumerated values and the items of a SEQUENCE OF CHOICE
+	definition := &e2smkpmv2.E2SmKpmActionDefinition{}
+	assert.NilError(t, xerDecode([]byte(`<E2SM-KPM-ActionDefinition>
+    <ric-Style-Type>12</ric-Style-Type>
+    <actionDefinition-formats>
+        <actionDefinition-Format3>
+            <measCondList>
+                <MeasurementCondItem>
+                    <measType><measID>21</measID></measType>
+                    <matchingCond>
+                        <testCondInfo>
+                            <testType><rSRP><true/></rSRP></testType>
+                            <testExpr><lessthan/></testExpr>
+                            <testValue><valueEnum>201</valueEnum></testValue>
+                        </testCondInfo>
+                    </matchingCond>
+                </MeasurementCondItem>
+            </measCondList>
+            <granulPeriod>21</granulPeriod>
+        </actionDefinition-Format3>
+    </actionDefinition-formats>
+</E2SM-KPM-ActionDefinition>`), definition))
+	format3 := definition.GetActionDefinitionFormats().GetActionDefinitionFormat3()
+	assert.Equal(t, 1, len(format3.GetMeasCondList().GetValue()))
+	condition := format3.GetMeasCondList().GetValue()[0].GetMatchingCond().GetValue()
+	assert.Equal(t, 1, len(condition))
+	assert.Equal(t, e2smkpmv2.TestCondExpression_TEST_COND_EXPRESSION_LESSTHAN, condition[0].GetTestCondInfo().GetTestExpr())
+
+	data, err := xerEncode(definition, "E2SM-KPM-ActionDefinition")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(data), "<testExpr><lessthan/></testExpr>"), string(data))
+	assert.Assert(t, strings.Contains(string(data), "<matchingCond>\n                        <testCondInfo>\n"), string(data))
+
+	err = xerDecode([]byte("<E2SM-KPM-ActionDefinition><ric-Style-Type>x</ric-Style-Type></E2SM-KPM-ActionDefinition>"), definition)
+	assert.ErrorContains(t, err, `ric-Style-Type: invalid int32 value "x"`)
+	err = xerDecode([]byte("<E2SM-KPM-ActionDefinition><style>1</style></E2SM-KPM-ActionDefinition>"), definition)
+	assert.ErrorContains(t, err, "unknown component style")
+}
+
+func TestAsn1Identifier(t *testing.T) {
+	fields := (&e2smkpmv2.E2SmKpmActionDefinitionFormat3{}).ProtoReflect().Descriptor().Fields()
+	assert.Equal(t, "measCondList", asn1Identifier(fields.ByName("meas_cond_list")))
+	formats := (&e2smkpmv2.E2SmKpmIndicationMessage{}).ProtoReflect().Descriptor().Fields().ByName("indication_message_formats")
+	assert.Equal(t, "indicationMessage-Format1", asn1Identifier(formats.Message().Fields().Get(0)))
+
+	fields = (&e2smkpmv2.E2SmKpmRanfunctionDescription{}).ProtoReflect().Descriptor().Fields()
+	assert.Equal(t, "RIC-KPMNode-Item", xerItemName(fields.ByName("ric_kpm_node_list")))
+}
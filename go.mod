@@ -3,8 +3,23 @@ module github.com/onosproject/onos-e2-sm
 go 1.16
 
 require (
-	github.com/onosproject/onos-lib-go v0.7.22
+	github.com/onosproject/onos-api/go v0.7.110
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go v0.0.0-00010101000000-000000000000
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go v0.0.0-00010101000000-000000000000
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go v0.0.0-00010101000000-000000000000
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm v0.0.0-00010101000000-000000000000
+	github.com/onosproject/onos-lib-go v0.8.9
 	github.com/rogpeppe/go-internal v1.8.0
-	github.com/spf13/cobra v1.1.3
-	google.golang.org/genproto v0.0.0-20201113130914-ce600e9a6f9e // indirect
+	github.com/spf13/cobra v1.2.1
+	google.golang.org/grpc v1.40.0
+	google.golang.org/protobuf v1.27.1
+	gotest.tools v2.2.0+incompatible
+)
+
+replace (
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common => ./servicemodels/e2sm_common
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go => ./servicemodels/e2sm_kpm_v2_go
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go => ./servicemodels/e2sm_mho_go
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go => ./servicemodels/e2sm_rc_pre_go
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm => ./servicemodels/e2sm_rsm
 )
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codec gives access to the PDU codecs of the pure-Go service models, by service model and PDU type name,
+// for the onos-e2-sm command and the libe2sm C library
+package codec
+
+import (
+	"fmt"
+	"strings"
+
+	types "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	kpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
+	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/servicemodel"
+	rcpre "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/servicemodel"
+	rsm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/servicemodel"
+)
+
+// ServiceModel is the interface of the ServiceModel symbol of a plugin, as loaded by onos-e2t
+type ServiceModel interface {
+	ServiceModelData() types.ServiceModelData
+	IndicationHeaderASN1toProto(asn1Bytes []byte) ([]byte, error)
+	IndicationHeaderProtoToASN1(protoBytes []byte) ([]byte, error)
+	IndicationMessageASN1toProto(asn1Bytes []byte) ([]byte, error)
+	IndicationMessageProtoToASN1(protoBytes []byte) ([]byte, error)
+	RanFuncDescriptionASN1toProto(asn1Bytes []byte) ([]byte, error)
+	RanFuncDescriptionProtoToASN1(protoBytes []byte) ([]byte, error)
+	EventTriggerDefinitionASN1toProto(asn1Bytes []byte) ([]byte, error)
+	EventTriggerDefinitionProtoToASN1(protoBytes []byte) ([]byte, error)
+	ActionDefinitionASN1toProto(asn1Bytes []byte) ([]byte, error)
+	ActionDefinitionProtoToASN1(protoBytes []byte) ([]byte, error)
+	ControlHeaderASN1toProto(asn1Bytes []byte) ([]byte, error)
+	ControlHeaderProtoToASN1(protoBytes []byte) ([]byte, error)
+	ControlMessageASN1toProto(asn1Bytes []byte) ([]byte, error)
+	ControlMessageProtoToASN1(protoBytes []byte) ([]byte, error)
+	ControlOutcomeASN1toProto(asn1Bytes []byte) ([]byte, error)
+	ControlOutcomeProtoToASN1(protoBytes []byte) ([]byte, error)
+	OnSetup(request *types.OnSetupRequest) error
+}
+
+// ServiceModels are the compiled in service models. The CGo service models only exist as plugins
+// (their ServiceModel is declared in package main).
+var ServiceModels = []ServiceModel{
+	kpmv2.Kpm2ServiceModel(""),
+	mho.MhoServiceModel(""),
+	rcpre.RcPreServiceModel(""),
+	rsm.RsmServiceModel(""),
+}
+
+// PduKind is a kind of PDU handled by a service model
+type PduKind struct {
+	// Name identifies the PDU kind on the command line and in the codec APIs
+	Name string
+	// ASN1Name is the name of the PDU type in the ASN.1 modules, after the E2SM-<model>- prefix
+	ASN1Name    string
+	asn1ToProto func(sm ServiceModel) func([]byte) ([]byte, error)
+	protoToASN1 func(sm ServiceModel) func([]byte) ([]byte, error)
+}
+
+// Decode decodes an APER encoded PDU into the protobuf encoding of its message
+func (k PduKind) Decode(sm ServiceModel, asn1Bytes []byte) ([]byte, error) {
+	return call(k.asn1ToProto(sm), asn1Bytes)
+}
+
+// Encode encodes the protobuf encoding of a PDU message in APER
+func (k PduKind) Encode(sm ServiceModel, protoBytes []byte) ([]byte, error) {
+	return call(k.protoToASN1(sm), protoBytes)
+}
+
+// PduKinds are the kinds of PDU of the service model interface
+var PduKinds = []PduKind{
+	{
+		Name:        "ran-function-description",
+		ASN1Name:    "RANfunction-Description",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.RanFuncDescriptionASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.RanFuncDescriptionProtoToASN1 },
+	},
+	{
+		Name:        "event-trigger-definition",
+		ASN1Name:    "EventTriggerDefinition",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.EventTriggerDefinitionASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.EventTriggerDefinitionProtoToASN1 },
+	},
+	{
+		Name:        "action-definition",
+		ASN1Name:    "ActionDefinition",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ActionDefinitionASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ActionDefinitionProtoToASN1 },
+	},
+	{
+		Name:        "indication-header",
+		ASN1Name:    "IndicationHeader",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.IndicationHeaderASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.IndicationHeaderProtoToASN1 },
+	},
+	{
+		Name:        "indication-message",
+		ASN1Name:    "IndicationMessage",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.IndicationMessageASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.IndicationMessageProtoToASN1 },
+	},
+	{
+		Name:        "control-header",
+		ASN1Name:    "ControlHeader",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ControlHeaderASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ControlHeaderProtoToASN1 },
+	},
+	{
+		Name:        "control-message",
+		ASN1Name:    "ControlMessage",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ControlMessageASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ControlMessageProtoToASN1 },
+	},
+	{
+		Name:        "control-outcome",
+		ASN1Name:    "ControlOutcome",
+		asn1ToProto: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ControlOutcomeASN1toProto },
+		protoToASN1: func(sm ServiceModel) func([]byte) ([]byte, error) { return sm.ControlOutcomeProtoToASN1 },
+	},
+}
+
+// GetPduKind returns the PDU kind with the given name or ASN.1 name, ignoring the case and the dashes,
+// e.g. indication-message, IndicationMessage or RANfunction-Description
+func GetPduKind(name string) (PduKind, error) {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.ReplaceAll(name, "-", ""))
+	}
+	names := make([]string, 0, len(PduKinds))
+	for _, kind := range PduKinds {
+		if normalize(name) == normalize(kind.Name) || normalize(name) == normalize(kind.ASN1Name) {
+			return kind, nil
+		}
+		names = append(names, kind.Name)
+	}
+	return PduKind{}, fmt.Errorf("unknown PDU type %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// ModelName returns the name of a service model as given on the command line, i.e. <name>/<version>
+func ModelName(sm ServiceModel) string {
+	data := sm.ServiceModelData()
+	return fmt.Sprintf("%s/%s", data.Name, data.Version)
+}
+
+// GetServiceModel returns the compiled in service model with the given name, either <name>/<version>,
+// <name> alone, the module name or the OID
+func GetServiceModel(name string) (ServiceModel, error) {
+	names := make([]string, 0, len(ServiceModels))
+	for _, sm := range ServiceModels {
+		data := sm.ServiceModelData()
+		if name == ModelName(sm) || name == string(data.Name) || name == string(data.ModuleName) || name == string(data.OID) {
+			return sm, nil
+		}
+		names = append(names, ModelName(sm))
+	}
+	return nil, fmt.Errorf("unknown service model %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// call calls a codec, turning its panics into errors: a malformed PDU must not take down the host process
+func call(f func([]byte) ([]byte, error), in []byte) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return f(in)
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestGetServiceModel(t *testing.T) {
+	sm, err := GetServiceModel("e2sm_rc_pre/v2_go")
+	assert.NilError(t, err)
+	assert.Equal(t, "e2sm_rc_pre_v2_go.so.2.0", string(sm.ServiceModelData().ModuleName))
+
+	sm, err = GetServiceModel("e2sm_kpm_v2_go.so.2.0")
+	assert.NilError(t, err)
+	assert.Equal(t, "e2sm_kpm/v2_go", ModelName(sm))
+
+	sm, err = GetServiceModel("1.3.6.1.4.1.53148.1.2.2.101")
+	assert.NilError(t, err)
+	assert.Equal(t, "e2sm_mho_go/v2", ModelName(sm))
+
+	sm, err = GetServiceModel("e2sm_rsm")
+	assert.NilError(t, err)
+	assert.Equal(t, "e2sm_rsm/v1_go", ModelName(sm))
+
+	_, err = GetServiceModel("e2sm_ni")
+	assert.ErrorContains(t, err, `unknown service model "e2sm_ni", expected one of e2sm_kpm/v2_go`)
+}
+
+func TestGetPduKind(t *testing.T) {
+	for _, name := range []string{"control-message", "ControlMessage", "controlmessage", "Control-Message"} {
+		kind, err := GetPduKind(name)
+		assert.NilError(t, err, name)
+		assert.Equal(t, "control-message", kind.Name)
+	}
+	for _, name := range []string{"ran-function-description", "RANfunction-Description", "RanFunctionDescription"} {
+		kind, err := GetPduKind(name)
+		assert.NilError(t, err, name)
+		assert.Equal(t, "ran-function-description", kind.Name)
+	}
+
+	_, err := GetPduKind("control")
+	assert.ErrorContains(t, err, `unknown PDU type "control", expected one of ran-function-description`)
+}
+
+func TestPduKind_Decode(t *testing.T) {
+	// A control header of E2SM-RC-PRE, see the build test of onos-e2-sm
+	asn1Bytes, err := hex.DecodeString("3412f410abd4bc0001")
+	assert.NilError(t, err)
+	sm, err := GetServiceModel("e2sm_rc_pre/v2_go")
+	assert.NilError(t, err)
+	kind, err := GetPduKind("control-header")
+	assert.NilError(t, err)
+
+	protoBytes, err := kind.Decode(sm, asn1Bytes)
+	assert.NilError(t, err)
+	assert.Assert(t, len(protoBytes) > 0)
+	encoded, err := kind.Encode(sm, protoBytes)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, asn1Bytes, encoded)
+
+	_, err = kind.Decode(sm, []byte{0xff})
+	assert.Assert(t, err != nil)
+}
+
+func TestCall(t *testing.T) {
+	_, err := call(func([]byte) ([]byte, error) { panic("index out of range") }, nil)
+	assert.Error(t, err, "panic: index out of range")
+}
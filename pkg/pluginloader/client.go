@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Command is the name of the loader command
+const Command = "onos-e2-sm-plugin-loader"
+
+// Plugin is a service model plugin loaded by a loader process
+type Plugin struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stderr  *strings.Builder
+	encoder *json.Encoder
+	decoder *json.Decoder
+	data    Data
+	mu      sync.Mutex
+	// stopped is set once the loader has been waited for
+	stopped bool
+	// exitErr is the error of a loader which stopped answering, returned by the later calls
+	exitErr error
+}
+
+// FindLoader returns the path of the loader command, looked up in the PATH then next to the running executable
+func FindLoader() (string, error) {
+	if path, err := exec.LookPath(Command); err == nil {
+		return path, nil
+	}
+	if executable, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(executable), Command)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find %s, install it with the Go version the plugin is built with: "+
+		"go install github.com/onosproject/onos-e2-sm/cmd/%s", Command, Command)
+}
+
+// Start starts the loader at loaderPath on the plugin at path
+func Start(loaderPath string, path string) (*Plugin, error) {
+	p := &Plugin{
+		cmd:    exec.Command(loaderPath, path),
+		stderr: &strings.Builder{},
+	}
+	p.cmd.Stderr = p.stderr
+	stdin, err := p.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := p.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("cannot start %s: %v", loaderPath, err)
+	}
+	p.stdin = stdin
+	p.encoder = json.NewEncoder(stdin)
+	p.decoder = json.NewDecoder(stdout)
+
+	var h hello
+	if err := p.decoder.Decode(&h); err != nil {
+		return nil, p.exitError(err)
+	}
+	if h.Error != "" {
+		_ = p.Close()
+		return nil, fmt.Errorf("%s", h.Error)
+	}
+	p.data = h.Data
+	return p, nil
+}
+
+// Data returns the data of the service model
+func (p *Plugin) Data() Data {
+	return p.data
+}
+
+// Call calls a codec method of the service model. It panics if the method panicked, as it would have in process.
+func (p *Plugin) Call(method string, input []byte) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.exitErr != nil {
+		return nil, p.exitErr
+	}
+	if p.stopped {
+		return nil, fmt.Errorf("%s is closed", Command)
+	}
+	if err := p.encoder.Encode(request{Method: method, Input: input}); err != nil {
+		return nil, p.exitError(err)
+	}
+	var resp response
+	if err := p.decoder.Decode(&resp); err != nil {
+		return nil, p.exitError(err)
+	}
+	if resp.Panic != "" {
+		panic(resp.Panic)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	return resp.Output, nil
+}
+
+// Close stops the loader process. Closing a plugin again, or one of which the loader failed, is a no-op.
+func (p *Plugin) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return nil
+	}
+	return p.stop()
+}
+
+// stop closes the input of the loader and waits for it to exit
+func (p *Plugin) stop() error {
+	p.stopped = true
+	_ = p.stdin.Close()
+	return p.cmd.Wait()
+}
+
+// exitError returns the error of a loader which stopped answering, with its output on stderr
+func (p *Plugin) exitError(err error) error {
+	if waitErr := p.stop(); waitErr != nil {
+		err = waitErr
+	}
+	p.exitErr = fmt.Errorf("%s failed: %v: %s", Command, err, strings.TrimSpace(p.stderr.String()))
+	return p.exitErr
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginloader
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// crashingLoader greets then exits on the first request, as a loader of which the plugin crashed
+const crashingLoader = `#!/bin/sh
+echo '{"data":{"Name":"e2sm_rsm","Version":"v1_go"}}'
+read request
+echo "fatal error: unexpected signal" >&2
+exit 2
+`
+
+func TestPluginExit(t *testing.T) {
+	loaderPath := filepath.Join(t.TempDir(), Command)
+	assert.NilError(t, ioutil.WriteFile(loaderPath, []byte(crashingLoader), 0755))
+
+	p, err := Start(loaderPath, "e2sm_rsm.so")
+	assert.NilError(t, err)
+	assert.Equal(t, "e2sm_rsm", p.Data().Name)
+
+	_, err = p.Call("IndicationHeaderASN1toProto", []byte{0x00})
+	assert.ErrorContains(t, err, "exit status 2: fatal error: unexpected signal")
+	// The later calls get the same error instead of waiting for the loader again
+	_, errAgain := p.Call("IndicationHeaderASN1toProto", []byte{0x00})
+	assert.Equal(t, err.Error(), errAgain.Error())
+	assert.NilError(t, p.Close())
+	assert.NilError(t, p.Close())
+}
+
+func TestPluginClose(t *testing.T) {
+	loaderPath := filepath.Join(t.TempDir(), Command)
+	assert.NilError(t, ioutil.WriteFile(loaderPath, []byte(crashingLoader), 0755))
+
+	p, err := Start(loaderPath, "e2sm_rsm.so")
+	assert.NilError(t, err)
+	// The loader exits with an error on the end of its input
+	assert.ErrorContains(t, p.Close(), "exit status 2")
+	assert.NilError(t, p.Close())
+	_, err = p.Call("IndicationHeaderASN1toProto", []byte{0x00})
+	assert.ErrorContains(t, err, "onos-e2-sm-plugin-loader is closed")
+}
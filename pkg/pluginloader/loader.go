@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pluginloader loads service model plugins out of process. A plugin can only be opened by a program
+// built with the same versions of the packages they share, and the onos-e2-sm command links the service models
+// themselves, so it starts the onos-e2-sm-plugin-loader command instead, which only links the standard library
+// and calls the ServiceModel symbol by reflection. This package must not import anything but the standard library.
+//
+// The loader writes the service model data (or the error loading the plugin) as a JSON line, then serves the
+// requests read as JSON lines on its stdin until it is closed, writing a JSON line for each response.
+package pluginloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"plugin"
+	"reflect"
+	"strings"
+)
+
+const serviceModelSymbol = "ServiceModel"
+
+// CodecMethods are the methods of the service model interface which take and return bytes
+var CodecMethods = []string{
+	"IndicationHeaderASN1toProto",
+	"IndicationHeaderProtoToASN1",
+	"IndicationMessageASN1toProto",
+	"IndicationMessageProtoToASN1",
+	"RanFuncDescriptionASN1toProto",
+	"RanFuncDescriptionProtoToASN1",
+	"EventTriggerDefinitionASN1toProto",
+	"EventTriggerDefinitionProtoToASN1",
+	"ActionDefinitionASN1toProto",
+	"ActionDefinitionProtoToASN1",
+	"ControlHeaderASN1toProto",
+	"ControlHeaderProtoToASN1",
+	"ControlMessageASN1toProto",
+	"ControlMessageProtoToASN1",
+	"ControlOutcomeASN1toProto",
+	"ControlOutcomeProtoToASN1",
+}
+
+var (
+	bytesType = reflect.TypeOf([]byte(nil))
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Data is the ServiceModelData of a service model
+type Data struct {
+	Name       string
+	Version    string
+	ModuleName string
+	OID        string
+}
+
+// hello is the first line written by the loader
+type hello struct {
+	Data  Data   `json:"data"`
+	Error string `json:"error,omitempty"`
+}
+
+// request is the call of a codec method
+type request struct {
+	Method string `json:"method"`
+	Input  []byte `json:"input"`
+}
+
+// response is the result of a codec method, Panic is set if the method panicked
+type response struct {
+	Output []byte `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Panic  string `json:"panic,omitempty"`
+}
+
+// Run loads the plugin at path and serves the requests read from in, until in is closed
+func Run(path string, in io.Reader, out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	sm, err := open(path)
+	if err != nil {
+		if e := encoder.Encode(hello{Error: err.Error()}); e != nil {
+			return e
+		}
+		return err
+	}
+	return serve(sm, in, out)
+}
+
+// open opens a service model plugin and returns its ServiceModel symbol
+func open(path string) (reflect.Value, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot open %s: %v", path, err)
+	}
+	symbol, err := p.Lookup(serviceModelSymbol)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot find the %s symbol in %s: %v", serviceModelSymbol, path, err)
+	}
+	if missing := MissingMethods(symbol); len(missing) > 0 {
+		return reflect.Value{}, fmt.Errorf("%s symbol of %s (%T) does not implement the service model interface, missing: %s",
+			serviceModelSymbol, path, symbol, strings.Join(missing, ", "))
+	}
+	return reflect.ValueOf(symbol), nil
+}
+
+// MissingMethods returns the methods of the service model interface which a symbol does not implement.
+// The types of onos-api cannot be linked, so they are only checked by name.
+func MissingMethods(symbol interface{}) []string {
+	t := reflect.TypeOf(symbol)
+	missing := make([]string, 0)
+	check := func(name string, ok func(in []reflect.Type, out []reflect.Type) bool) {
+		m, found := t.MethodByName(name)
+		if !found {
+			missing = append(missing, name)
+			return
+		}
+		// The method of a concrete type has the receiver as first argument
+		in := make([]reflect.Type, 0, m.Type.NumIn())
+		for i := 1; i < m.Type.NumIn(); i++ {
+			in = append(in, m.Type.In(i))
+		}
+		out := make([]reflect.Type, 0, m.Type.NumOut())
+		for i := 0; i < m.Type.NumOut(); i++ {
+			out = append(out, m.Type.Out(i))
+		}
+		if !ok(in, out) {
+			missing = append(missing, fmt.Sprintf("%s (got %s)", name, m.Type))
+		}
+	}
+	check("ServiceModelData", func(in []reflect.Type, out []reflect.Type) bool {
+		return len(in) == 0 && len(out) == 1 && out[0].Name() == "ServiceModelData"
+	})
+	for _, name := range CodecMethods {
+		check(name, func(in []reflect.Type, out []reflect.Type) bool {
+			return len(in) == 1 && in[0] == bytesType && len(out) == 2 && out[0] == bytesType && out[1] == errorType
+		})
+	}
+	check("OnSetup", func(in []reflect.Type, out []reflect.Type) bool {
+		return len(in) == 1 && in[0].Kind() == reflect.Ptr && in[0].Elem().Name() == "OnSetupRequest" &&
+			len(out) == 1 && out[0] == errorType
+	})
+	return missing
+}
+
+// serve writes the data of a service model, then calls its codec methods for each request read from in
+func serve(sm reflect.Value, in io.Reader, out io.Writer) error {
+	decoder := json.NewDecoder(in)
+	encoder := json.NewEncoder(out)
+	data := sm.MethodByName("ServiceModelData").Call(nil)[0]
+	if err := encoder.Encode(hello{Data: Data{
+		Name:       data.FieldByName("Name").String(),
+		Version:    data.FieldByName("Version").String(),
+		ModuleName: data.FieldByName("ModuleName").String(),
+		OID:        data.FieldByName("OID").String(),
+	}}); err != nil {
+		return err
+	}
+	for {
+		var req request
+		if err := decoder.Decode(&req); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid request: %v", err)
+		}
+		if err := encoder.Encode(call(sm, req)); err != nil {
+			return err
+		}
+	}
+}
+
+// call calls a codec method, turning its panics into a response: the next requests must still be served
+func call(sm reflect.Value, req request) (resp response) {
+	known := false
+	for _, name := range CodecMethods {
+		known = known || name == req.Method
+	}
+	if !known {
+		return response{Error: fmt.Sprintf("unknown method %q", req.Method)}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			resp = response{Panic: fmt.Sprintf("%v", r)}
+		}
+	}()
+	results := sm.MethodByName(req.Method).Call([]reflect.Value{reflect.ValueOf(req.Input)})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return response{Error: err.Error()}
+	}
+	return response{Output: results[0].Interface().([]byte)}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	types "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	rsm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/servicemodel"
+	"gotest.tools/assert"
+)
+
+type incompleteServiceModel struct{}
+
+func (sm incompleteServiceModel) ServiceModelData() types.ServiceModelData {
+	return types.ServiceModelData{}
+}
+
+func (sm incompleteServiceModel) OnSetup(request []byte) error {
+	return nil
+}
+
+type panickingServiceModel struct {
+	rsm.RsmServiceModel
+}
+
+func (sm panickingServiceModel) IndicationHeaderASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	panic("index out of range")
+}
+
+func TestMissingMethods(t *testing.T) {
+	missing := MissingMethods(&incompleteServiceModel{})
+	assert.Equal(t, 17, len(missing))
+	assert.Assert(t, strings.Contains(strings.Join(missing, ", "), "OnSetup (got func("), missing)
+
+	sm := rsm.RsmServiceModel("")
+	assert.Equal(t, 0, len(MissingMethods(&sm)))
+}
+
+func TestServe(t *testing.T) {
+	in := &bytes.Buffer{}
+	encoder := json.NewEncoder(in)
+	for _, req := range []request{
+		{Method: "IndicationHeaderASN1toProto", Input: []byte{0x00}},
+		{Method: "ActionDefinitionASN1toProto", Input: []byte{}},
+		{Method: "OnSetup"},
+		{Method: "ControlHeaderASN1toProto", Input: []byte{}},
+	} {
+		assert.NilError(t, encoder.Encode(req))
+	}
+	out := &bytes.Buffer{}
+	sm := panickingServiceModel{RsmServiceModel: rsm.RsmServiceModel("")}
+	assert.NilError(t, serve(reflect.ValueOf(&sm), in, out))
+
+	decoder := json.NewDecoder(out)
+	var h hello
+	assert.NilError(t, decoder.Decode(&h))
+	assert.Equal(t, "e2sm_rsm", h.Data.Name)
+	assert.Equal(t, "e2sm_rsm_v1_go.so.2.0", h.Data.ModuleName)
+	assert.Equal(t, "", h.Error)
+	responses := make([]response, 4)
+	for i := range responses {
+		assert.NilError(t, decoder.Decode(&responses[i]))
+	}
+	assert.Equal(t, "index out of range", responses[0].Panic)
+	assert.Assert(t, strings.Contains(responses[1].Error, "not implemented"), responses[1].Error)
+	assert.Equal(t, `unknown method "OnSetup"`, responses[2].Error)
+	assert.Assert(t, responses[3].Error != "" && responses[3].Panic == "", responses[3])
+}
@@ -0,0 +1,119 @@
+# SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+#
+# SPDX-License-Identifier: Apache-2.0
+
+"""In-process APER codecs of the pure-Go service models, through the libe2sm C library
+
+The library is built from the onos-e2-sm repository with
+``go build -buildmode=c-shared -o libe2sm.so ./cmd/libe2sm`` (``make libe2sm``), and is
+looked up in $ONOS_E2_SM_LIB, next to this module, then on the library path.
+
+The protobuf encodings are those of the messages of the Go service models
+(e.g. servicemodels/e2sm_kpm_v2_go), to be parsed with ``Message().parse(...)``
+and given as ``bytes(message)``.
+"""
+
+import ctypes
+import ctypes.util
+import os
+import threading
+from typing import Optional, Union
+
+import betterproto
+
+__all__ = ["Codec", "CodecError", "decode", "encode"]
+
+
+class CodecError(Exception):
+    """A PDU could not be decoded or encoded"""
+
+
+class Codec:
+    """The codecs of the libe2sm library at path, or found as described in the module"""
+
+    def __init__(self, path: Optional[str] = None):
+        self._lib = ctypes.CDLL(path or _find_library())
+        for function in (self._lib.e2sm_decode, self._lib.e2sm_encode):
+            function.argtypes = [
+                ctypes.c_char_p,
+                ctypes.c_char_p,
+                ctypes.c_char_p,
+                ctypes.c_size_t,
+                ctypes.POINTER(ctypes.c_void_p),
+                ctypes.POINTER(ctypes.c_size_t),
+                ctypes.POINTER(ctypes.c_void_p),
+            ]
+            function.restype = ctypes.c_int
+        self._lib.e2sm_free.argtypes = [ctypes.c_void_p]
+        self._lib.e2sm_free.restype = None
+
+    def decode(self, model: str, pdu_type: str, data: bytes) -> bytes:
+        """Decodes an APER encoded PDU into the protobuf encoding of its message
+
+        model is <name>/<version>, <name>, the module name or the OID of the service model,
+        and pdu_type e.g. indication-message or IndicationMessage.
+        """
+        return self._call(self._lib.e2sm_decode, model, pdu_type, data)
+
+    def encode(
+        self, model: str, pdu_type: str, data: Union[bytes, betterproto.Message]
+    ) -> bytes:
+        """Encodes a PDU message, or its protobuf encoding, in APER"""
+        return self._call(self._lib.e2sm_encode, model, pdu_type, bytes(data))
+
+    def _call(self, function, model: str, pdu_type: str, data: bytes) -> bytes:
+        out, out_len, err = ctypes.c_void_p(), ctypes.c_size_t(), ctypes.c_void_p()
+        if (
+            function(
+                model.encode(),
+                pdu_type.encode(),
+                data,
+                len(data),
+                ctypes.byref(out),
+                ctypes.byref(out_len),
+                ctypes.byref(err),
+            )
+            != 0
+        ):
+            try:
+                raise CodecError(ctypes.string_at(err).decode())
+            finally:
+                self._lib.e2sm_free(err)
+        try:
+            return ctypes.string_at(out, out_len.value)
+        finally:
+            self._lib.e2sm_free(out)
+
+
+def _find_library() -> str:
+    path = os.environ.get("ONOS_E2_SM_LIB")
+    if path:
+        return path
+    path = os.path.join(os.path.dirname(os.path.abspath(__file__)), "libe2sm.so")
+    if os.path.exists(path):
+        return path
+    return ctypes.util.find_library("e2sm") or "libe2sm.so"
+
+
+_codec: Optional[Codec] = None
+_codec_lock = threading.Lock()
+
+
+def _default_codec() -> Codec:
+    global _codec
+    with _codec_lock:
+        if _codec is None:
+            _codec = Codec()
+        return _codec
+
+
+def decode(model: str, pdu_type: str, data: bytes) -> bytes:
+    """Decodes an APER encoded PDU with the default library, see Codec.decode"""
+    return _default_codec().decode(model, pdu_type, data)
+
+
+def encode(
+    model: str, pdu_type: str, data: Union[bytes, betterproto.Message]
+) -> bytes:
+    """Encodes a PDU in APER with the default library, see Codec.encode"""
+    return _default_codec().encode(model, pdu_type, data)
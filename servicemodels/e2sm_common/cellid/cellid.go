@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cellid converts NR and E-UTRA cell identities between their ASN.1 BIT STRING form and integers,
+// and formats/parses NR-CGI and ECGI strings.
+package cellid
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+)
+
+const (
+	// NciBits is the length of the NR Cell Identity
+	NciBits = 36
+	// EciBits is the length of the E-UTRA Cell Identity
+	EciBits = 28
+	// MinGnbIDBits is the shortest gNB ID allowed by 3GPP TS 38.413
+	MinGnbIDBits = 22
+	// MaxGnbIDBits is the longest gNB ID allowed by 3GPP TS 38.413
+	MaxGnbIDBits = 32
+)
+
+// BitStringToUint64 returns the value held by a left-aligned BIT STRING of at most 64 bits
+func BitStringToUint64(bs *asn1.BitString) (uint64, error) {
+	if bs.GetLen() > 64 {
+		return 0, fmt.Errorf("BitString of %d bits does not fit into uint64", bs.GetLen())
+	}
+	expectedBytes := int(bs.GetLen()+7) / 8
+	if len(bs.GetValue()) != expectedBytes {
+		return 0, fmt.Errorf("BitString of %d bits should be carried in %d bytes, got %d", bs.GetLen(), expectedBytes, len(bs.GetValue()))
+	}
+	var result uint64
+	for _, b := range bs.GetValue() {
+		result = result<<8 | uint64(b)
+	}
+	return result >> (uint32(expectedBytes)*8 - bs.GetLen()), nil
+}
+
+// Uint64ToBitString returns a left-aligned BIT STRING of the given length holding value
+func Uint64ToBitString(value uint64, bitCount uint32) (*asn1.BitString, error) {
+	if bitCount == 0 || bitCount > 64 {
+		return nil, fmt.Errorf("BitString length should be within 1..64, got %d", bitCount)
+	}
+	if bitCount < 64 && value>>bitCount != 0 {
+		return nil, fmt.Errorf("value %#x does not fit into %d bits", value, bitCount)
+	}
+	byteCount := (bitCount + 7) / 8
+	value <<= byteCount*8 - bitCount
+	bytes := make([]byte, byteCount)
+	for i := int(byteCount) - 1; i >= 0; i-- {
+		bytes[i] = byte(value)
+		value >>= 8
+	}
+	return &asn1.BitString{
+		Value: bytes,
+		Len:   bitCount,
+	}, nil
+}
+
+// NciFromBitString returns the NR Cell Identity carried in a 36 bit BIT STRING
+func NciFromBitString(bs *asn1.BitString) (uint64, error) {
+	if bs.GetLen() != NciBits {
+		return 0, fmt.Errorf("NRCellIdentity should be of length %d, got %d", NciBits, bs.GetLen())
+	}
+	return BitStringToUint64(bs)
+}
+
+// NciToBitString returns the 36 bit BIT STRING carrying an NR Cell Identity
+func NciToBitString(nci uint64) (*asn1.BitString, error) {
+	return Uint64ToBitString(nci, NciBits)
+}
+
+// EciFromBitString returns the E-UTRA Cell Identity carried in a 28 bit BIT STRING
+func EciFromBitString(bs *asn1.BitString) (uint32, error) {
+	if bs.GetLen() != EciBits {
+		return 0, fmt.Errorf("EUTRACellIdentity should be of length %d, got %d", EciBits, bs.GetLen())
+	}
+	eci, err := BitStringToUint64(bs)
+	return uint32(eci), err
+}
+
+// EciToBitString returns the 28 bit BIT STRING carrying an E-UTRA Cell Identity
+func EciToBitString(eci uint32) (*asn1.BitString, error) {
+	return Uint64ToBitString(uint64(eci), EciBits)
+}
+
+// SplitNci splits an NR Cell Identity into the gNB ID (leftmost gnbIDBits bits) and the local cell ID
+func SplitNci(nci uint64, gnbIDBits int) (uint64, uint64, error) {
+	if err := validateGnbIDBits(gnbIDBits); err != nil {
+		return 0, 0, err
+	}
+	if nci>>NciBits != 0 {
+		return 0, 0, fmt.Errorf("NCI %#x does not fit into %d bits", nci, NciBits)
+	}
+	cellIDBits := NciBits - gnbIDBits
+	return nci >> cellIDBits, nci & (1<<cellIDBits - 1), nil
+}
+
+// JoinNci builds an NR Cell Identity out of a gNB ID of gnbIDBits bits and a local cell ID
+func JoinNci(gnbID uint64, cellID uint64, gnbIDBits int) (uint64, error) {
+	if err := validateGnbIDBits(gnbIDBits); err != nil {
+		return 0, err
+	}
+	cellIDBits := NciBits - gnbIDBits
+	if gnbID>>gnbIDBits != 0 {
+		return 0, fmt.Errorf("gNB ID %#x does not fit into %d bits", gnbID, gnbIDBits)
+	}
+	if cellID>>cellIDBits != 0 {
+		return 0, fmt.Errorf("cell ID %#x does not fit into %d bits", cellID, cellIDBits)
+	}
+	return gnbID<<cellIDBits | cellID, nil
+}
+
+// FormatNrcgi returns the canonical NR-CGI string: the PLMN Identity octets followed by the NCI,
+// as 15 hexadecimal digits (the same value as the 60 bit NCGI)
+func FormatNrcgi(plmnID plmnid.PlmnID, nci uint64) (string, error) {
+	if err := plmnID.Validate(); err != nil {
+		return "", err
+	}
+	if nci>>NciBits != 0 {
+		return "", fmt.Errorf("NCI %#x does not fit into %d bits", nci, NciBits)
+	}
+	return fmt.Sprintf("%06x%09x", plmnIDToUint32(plmnID), nci), nil
+}
+
+// ParseNrcgi parses a string produced by FormatNrcgi
+func ParseNrcgi(nrcgi string) (plmnid.PlmnID, uint64, error) {
+	plmnID, cellID, err := parseCgi(nrcgi, NciBits)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid NR-CGI %q: %v", nrcgi, err)
+	}
+	return plmnID, cellID, nil
+}
+
+// FormatEcgi returns the canonical ECGI string: the PLMN Identity octets followed by the ECI,
+// as 13 hexadecimal digits (the same value as the 52 bit ECGI)
+func FormatEcgi(plmnID plmnid.PlmnID, eci uint32) (string, error) {
+	if err := plmnID.Validate(); err != nil {
+		return "", err
+	}
+	if eci>>EciBits != 0 {
+		return "", fmt.Errorf("ECI %#x does not fit into %d bits", eci, EciBits)
+	}
+	return fmt.Sprintf("%06x%07x", plmnIDToUint32(plmnID), eci), nil
+}
+
+// FormatNrcgiBitString returns the canonical NR-CGI string of a PLMN Identity and an NRCellIdentity BIT STRING,
+// i.e. of the NR-CGI of a service model
+func FormatNrcgiBitString(plmnID plmnid.PlmnID, nrCellID *asn1.BitString) (string, error) {
+	nci, err := NciFromBitString(nrCellID)
+	if err != nil {
+		return "", err
+	}
+	return FormatNrcgi(plmnID, nci)
+}
+
+// FormatEcgiBitString returns the canonical ECGI string of a PLMN Identity and an EUTRACellIdentity BIT STRING,
+// i.e. of the EUTRA-CGI of a service model
+func FormatEcgiBitString(plmnID plmnid.PlmnID, eutraCellID *asn1.BitString) (string, error) {
+	eci, err := EciFromBitString(eutraCellID)
+	if err != nil {
+		return "", err
+	}
+	return FormatEcgi(plmnID, eci)
+}
+
+// ParseEcgi parses a string produced by FormatEcgi
+func ParseEcgi(ecgi string) (plmnid.PlmnID, uint32, error) {
+	plmnID, cellID, err := parseCgi(ecgi, EciBits)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid ECGI %q: %v", ecgi, err)
+	}
+	return plmnID, uint32(cellID), nil
+}
+
+func parseCgi(cgi string, cellIDBits int) (plmnid.PlmnID, uint64, error) {
+	expectedLen := plmnid.Length*2 + (cellIDBits+3)/4
+	if len(cgi) != expectedLen {
+		return nil, 0, fmt.Errorf("expected %d hexadecimal digits, got %d", expectedLen, len(cgi))
+	}
+	plmnIDValue, err := strconv.ParseUint(cgi[:plmnid.Length*2], 16, 32)
+	if err != nil {
+		return nil, 0, err
+	}
+	cellID, err := strconv.ParseUint(cgi[plmnid.Length*2:], 16, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+	if cellID>>cellIDBits != 0 {
+		return nil, 0, fmt.Errorf("cell identity %#x does not fit into %d bits", cellID, cellIDBits)
+	}
+	plmnID, err := plmnid.FromBytes([]byte{byte(plmnIDValue >> 16), byte(plmnIDValue >> 8), byte(plmnIDValue)})
+	if err != nil {
+		return nil, 0, err
+	}
+	return plmnID, cellID, nil
+}
+
+func plmnIDToUint32(plmnID plmnid.PlmnID) uint32 {
+	return uint32(plmnID[0])<<16 | uint32(plmnID[1])<<8 | uint32(plmnID[2])
+}
+
+func validateGnbIDBits(gnbIDBits int) error {
+	if gnbIDBits < MinGnbIDBits || gnbIDBits > MaxGnbIDBits {
+		return fmt.Errorf("gNB ID length should be within %d..%d bits, got %d", MinGnbIDBits, MaxGnbIDBits, gnbIDBits)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cellid
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"gotest.tools/assert"
+)
+
+func TestNci(t *testing.T) {
+	bs := &asn1.BitString{
+		Value: []byte{0x12, 0xF0, 0xDE, 0xBC, 0x50},
+		Len:   36,
+	}
+	nci, err := NciFromBitString(bs)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(0x12F0DEBC5), nci)
+
+	bsBack, err := NciToBitString(nci)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, bs.GetValue(), bsBack.GetValue())
+	assert.Equal(t, uint32(36), bsBack.GetLen())
+
+	_, err = NciToBitString(1 << 36)
+	assert.ErrorContains(t, err, "does not fit into 36 bits")
+	_, err = NciFromBitString(&asn1.BitString{Value: []byte{0x12, 0xF0, 0xDE, 0xB0}, Len: 28})
+	assert.ErrorContains(t, err, "should be of length 36")
+}
+
+func TestEci(t *testing.T) {
+	bs := &asn1.BitString{
+		Value: []byte{0xd4, 0xbc, 0x09, 0x00},
+		Len:   28,
+	}
+	eci, err := EciFromBitString(bs)
+	assert.NilError(t, err)
+	assert.Equal(t, uint32(0xd4bc090), eci)
+
+	bsBack, err := EciToBitString(eci)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, bs.GetValue(), bsBack.GetValue())
+	assert.Equal(t, uint32(28), bsBack.GetLen())
+
+	_, err = EciToBitString(1 << 28)
+	assert.ErrorContains(t, err, "does not fit into 28 bits")
+	_, err = EciFromBitString(&asn1.BitString{Value: []byte{0xd4, 0xbc, 0x09, 0x00, 0x00}, Len: 36})
+	assert.ErrorContains(t, err, "should be of length 28")
+}
+
+func TestSplitJoinNci(t *testing.T) {
+	for gnbIDBits := MinGnbIDBits; gnbIDBits <= MaxGnbIDBits; gnbIDBits++ {
+		nci := uint64(0xABCDEF123)
+		gnbID, cellID, err := SplitNci(nci, gnbIDBits)
+		assert.NilError(t, err)
+		assert.Equal(t, nci>>(NciBits-gnbIDBits), gnbID)
+		joined, err := JoinNci(gnbID, cellID, gnbIDBits)
+		assert.NilError(t, err)
+		assert.Equal(t, nci, joined)
+	}
+
+	gnbID, cellID, err := SplitNci(0x12F0DEBC5, 32)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(0x12F0DEBC), gnbID)
+	assert.Equal(t, uint64(0x5), cellID)
+
+	_, _, err = SplitNci(0x12F0DEBC5, 21)
+	assert.ErrorContains(t, err, "within 22..32 bits")
+	_, err = JoinNci(0x1, 0x4000, 22)
+	assert.ErrorContains(t, err, "cell ID 0x4000 does not fit into 14 bits")
+}
+
+func TestNrcgiString(t *testing.T) {
+	plmnID := plmnid.PlmnID{0x13, 0xF0, 0x14}
+	nrcgi, err := FormatNrcgi(plmnID, 0x12F0DEBC5)
+	assert.NilError(t, err)
+	assert.Equal(t, "13f01412f0debc5", nrcgi)
+
+	plmnIDBack, nci, err := ParseNrcgi(nrcgi)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, plmnID, plmnIDBack)
+	assert.Equal(t, uint64(0x12F0DEBC5), nci)
+
+	_, _, err = ParseNrcgi("13f01412f0debc")
+	assert.ErrorContains(t, err, "expected 15 hexadecimal digits")
+	_, _, err = ParseNrcgi("13f01412f0debcx")
+	assert.ErrorContains(t, err, "invalid NR-CGI")
+	_, _, err = ParseNrcgi("a3f01412f0debc5")
+	assert.ErrorContains(t, err, "not BCD encoded")
+	_, err = FormatNrcgi(plmnid.PlmnID{0x13, 0xF0, 0xA4}, 0x12F0DEBC5)
+	assert.ErrorContains(t, err, "not BCD encoded")
+}
+
+func TestEcgiString(t *testing.T) {
+	plmnID := plmnid.PlmnID{0x13, 0xF0, 0x14}
+	ecgi, err := FormatEcgi(plmnID, 0xd4bc090)
+	assert.NilError(t, err)
+	assert.Equal(t, "13f014d4bc090", ecgi)
+
+	plmnIDBack, eci, err := ParseEcgi(ecgi)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, plmnID, plmnIDBack)
+	assert.Equal(t, uint32(0xd4bc090), eci)
+
+	_, err = FormatEcgi([]byte{0x13, 0xF0}, 0xd4bc090)
+	assert.ErrorContains(t, err, "PlmnID should be 3 bytes")
+}
+
+func TestCgiBitString(t *testing.T) {
+	plmnID := plmnid.PlmnID{0x13, 0xF0, 0x14}
+	nrcgi, err := FormatNrcgiBitString(plmnID, &asn1.BitString{Value: []byte{0x12, 0xF0, 0xDE, 0xBC, 0x50}, Len: 36})
+	assert.NilError(t, err)
+	assert.Equal(t, "13f01412f0debc5", nrcgi)
+	ecgi, err := FormatEcgiBitString(plmnID, &asn1.BitString{Value: []byte{0xd4, 0xbc, 0x09, 0x00}, Len: 28})
+	assert.NilError(t, err)
+	assert.Equal(t, "13f014d4bc090", ecgi)
+
+	_, err = FormatNrcgiBitString(plmnID, &asn1.BitString{Value: []byte{0xd4, 0xbc, 0x09, 0x00}, Len: 28})
+	assert.ErrorContains(t, err, "should be of length 36")
+	_, err = FormatEcgiBitString(plmnid.PlmnID{0x13, 0xF0, 0xA4}, &asn1.BitString{Value: []byte{0xd4, 0xbc, 0x09, 0x00}, Len: 28})
+	assert.ErrorContains(t, err, "not BCD encoded")
+}
@@ -0,0 +1,8 @@
+module github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common
+
+go 1.16
+
+require (
+	github.com/onosproject/onos-lib-go v0.8.9
+	gotest.tools v2.2.0+incompatible
+)
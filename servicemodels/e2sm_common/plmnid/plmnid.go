@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package plmnid encodes and decodes the 3 octet PLMN Identity used by every E2SM.
+package plmnid
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Length is the length of an encoded PLMN Identity
+	Length = 3
+	filler = 0xF
+)
+
+// PlmnID is a PLMN Identity encoded as in 3GPP TS 38.413: MCC and MNC BCD digits,
+// with the filler nibble 0xF in place of the third MNC digit when the MNC has only 2 digits.
+type PlmnID []byte
+
+// FromMCCMNC encodes a 3 digit MCC and a 2 or 3 digit MNC, e.g. FromMCCMNC("310", "410")
+func FromMCCMNC(mcc string, mnc string) (PlmnID, error) {
+	if len(mcc) != 3 {
+		return nil, fmt.Errorf("MCC should have 3 digits, got %q", mcc)
+	}
+	if len(mnc) != 2 && len(mnc) != 3 {
+		return nil, fmt.Errorf("MNC should have 2 or 3 digits, got %q", mnc)
+	}
+	mccDigits, err := digits(mcc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MCC %q: %v", mcc, err)
+	}
+	mncDigits, err := digits(mnc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MNC %q: %v", mnc, err)
+	}
+	mnc3 := byte(filler)
+	if len(mncDigits) == 3 {
+		mnc3 = mncDigits[2]
+	}
+	return PlmnID{
+		mccDigits[1]<<4 | mccDigits[0],
+		mnc3<<4 | mccDigits[2],
+		mncDigits[1]<<4 | mncDigits[0],
+	}, nil
+}
+
+// FromBytes returns the PlmnID held in 3 octets, after checking that they carry valid BCD digits
+func FromBytes(plmnID []byte) (PlmnID, error) {
+	p := PlmnID(plmnID)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Parse parses the "MCC-MNC" form produced by String, e.g. "310-410" or "001-01"
+func Parse(plmnID string) (PlmnID, error) {
+	parts := strings.Split(plmnID, "-")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("PLMN ID %q should be of the form MCC-MNC", plmnID)
+	}
+	return FromMCCMNC(parts[0], parts[1])
+}
+
+// Validate checks the length of the PlmnID and that every nibble is a decimal digit, except the MNC filler
+func (p PlmnID) Validate() error {
+	if len(p) != Length {
+		return fmt.Errorf("PlmnID should be %d bytes, got %d", Length, len(p))
+	}
+	nibbles := []byte{p[0] & 0x0F, p[0] >> 4, p[1] & 0x0F, p[2] & 0x0F, p[2] >> 4}
+	for _, n := range nibbles {
+		if n > 9 {
+			return fmt.Errorf("PlmnID %x is not BCD encoded", []byte(p))
+		}
+	}
+	if mnc3 := p[1] >> 4; mnc3 > 9 && mnc3 != filler {
+		return fmt.Errorf("PlmnID %x is not BCD encoded", []byte(p))
+	}
+	return nil
+}
+
+// MCC returns the 3 digit Mobile Country Code
+func (p PlmnID) MCC() string {
+	if len(p) != Length {
+		return ""
+	}
+	return string([]byte{'0' + p[0]&0x0F, '0' + p[0]>>4, '0' + p[1]&0x0F})
+}
+
+// MNC returns the 2 or 3 digit Mobile Network Code
+func (p PlmnID) MNC() string {
+	if len(p) != Length {
+		return ""
+	}
+	mnc := []byte{'0' + p[2]&0x0F, '0' + p[2]>>4}
+	if !p.IsTwoDigitMNC() {
+		mnc = append(mnc, '0'+p[1]>>4)
+	}
+	return string(mnc)
+}
+
+// IsTwoDigitMNC returns true if the third MNC digit is the filler nibble
+func (p PlmnID) IsTwoDigitMNC() bool {
+	return len(p) == Length && p[1]>>4 == filler
+}
+
+// Bytes returns the 3 encoded octets
+func (p PlmnID) Bytes() []byte {
+	return []byte(p)
+}
+
+// String returns the PlmnID as "MCC-MNC"
+func (p PlmnID) String() string {
+	if err := p.Validate(); err != nil {
+		return fmt.Sprintf("%x", []byte(p))
+	}
+	return fmt.Sprintf("%s-%s", p.MCC(), p.MNC())
+}
+
+func digits(s string) ([]byte, error) {
+	result := make([]byte, len(s))
+	for i, c := range s {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("%q is not a decimal digit", c)
+		}
+		result[i] = byte(c - '0')
+	}
+	return result, nil
+}
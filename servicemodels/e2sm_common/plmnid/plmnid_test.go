@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plmnid
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestFromMCCMNC(t *testing.T) {
+	plmnID, err := FromMCCMNC("310", "410")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x13, 0x00, 0x14}, plmnID.Bytes())
+	assert.Equal(t, "310", plmnID.MCC())
+	assert.Equal(t, "410", plmnID.MNC())
+	assert.Assert(t, !plmnID.IsTwoDigitMNC())
+	assert.Equal(t, "310-410", plmnID.String())
+
+	plmnID, err = FromMCCMNC("001", "01")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x00, 0xF1, 0x10}, plmnID.Bytes())
+	assert.Equal(t, "001", plmnID.MCC())
+	assert.Equal(t, "01", plmnID.MNC())
+	assert.Assert(t, plmnID.IsTwoDigitMNC())
+
+	_, err = FromMCCMNC("31", "410")
+	assert.ErrorContains(t, err, "MCC should have 3 digits")
+	_, err = FromMCCMNC("310", "4100")
+	assert.ErrorContains(t, err, "MNC should have 2 or 3 digits")
+	_, err = FromMCCMNC("3a0", "410")
+	assert.ErrorContains(t, err, "invalid MCC")
+}
+
+func TestFromBytes(t *testing.T) {
+	plmnID, err := FromBytes([]byte{0x21, 0x22, 0x23})
+	assert.NilError(t, err)
+	assert.Equal(t, "122", plmnID.MCC())
+	assert.Equal(t, "322", plmnID.MNC())
+
+	plmnID, err = FromBytes([]byte{0x13, 0xF0, 0x14})
+	assert.NilError(t, err)
+	assert.Equal(t, "310-41", plmnID.String())
+
+	_, err = FromBytes([]byte{0x13, 0xF0})
+	assert.ErrorContains(t, err, "should be 3 bytes")
+	_, err = FromBytes([]byte{0x00, 0x01, 0x0F})
+	assert.ErrorContains(t, err, "not BCD encoded")
+}
+
+func TestParse(t *testing.T) {
+	plmnID, err := Parse("208-93")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x02, 0xF8, 0x39}, plmnID.Bytes())
+
+	parsed, err := Parse(plmnID.String())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, plmnID, parsed)
+
+	_, err = Parse("20893")
+	assert.ErrorContains(t, err, "should be of the form MCC-MNC")
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package uekey provides a canonical key for the UE identities carried by the E2 service models,
+// so that the same UE can be correlated across service models.
+// The converters from the identities of each SM live in that SM's module, e.g. e2sm_rsm/rsmuekey.
+package uekey
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Type is the kind of UE identity a Key holds, named after the 3GPP application protocol ID
+type Type string
+
+const (
+	TypeAmfUeNgapID     Type = "AMF-UE-NGAP-ID"
+	TypeRanUeNgapID     Type = "RAN-UE-NGAP-ID"
+	TypeGnbCuUeF1apID   Type = "GNB-CU-UE-F1AP-ID"
+	TypeGnbDuUeF1apID   Type = "GNB-DU-UE-F1AP-ID"
+	TypeGnbCuCpUeE1apID Type = "GNB-CU-CP-UE-E1AP-ID"
+	TypeNgEnbCuUeW1apID Type = "NG-ENB-CU-UE-W1AP-ID"
+	TypeMmeUeS1apID     Type = "MME-UE-S1AP-ID"
+	TypeEnbUeS1apID     Type = "ENB-UE-S1AP-ID"
+	TypeMenbUeX2apID    Type = "MENB-UE-X2AP-ID"
+	TypeOpaque          Type = "UE-ID"
+)
+
+const (
+	separator        = "/"
+	unscopedKeyParts = 2
+	scopedKeyParts   = 3
+)
+
+var types = map[Type]bool{
+	TypeAmfUeNgapID:     true,
+	TypeRanUeNgapID:     true,
+	TypeGnbCuUeF1apID:   true,
+	TypeGnbDuUeF1apID:   true,
+	TypeGnbCuCpUeE1apID: true,
+	TypeNgEnbCuUeW1apID: true,
+	TypeMmeUeS1apID:     true,
+	TypeEnbUeS1apID:     true,
+	TypeMenbUeX2apID:    true,
+	TypeOpaque:          true,
+}
+
+// Key is the canonical key of a UE identity. Keys are comparable, so they can be used as map keys.
+// Value is the decimal ID, or the hexadecimal octets of an opaque identity.
+// Scope names the node which allocated the ID, such as the Global eNB ID of a MeNB UE X2AP ID.
+// The converters scope a type of identity only if every SM carrying it carries its scope too, so that
+// keys of the same UE are equal whichever SM they are built from: AMF UE NGAP IDs are never scoped
+// by their GUAMI since E2SM-RSM reports them without it.
+//
+// An AMF UE NGAP ID is only unique within its AMF, so the keys of two UEs of a node connected to several
+// AMFs (or AMF sets) collide when both AMFs allocate the same ID. The RAN UE NGAP ID, unique within the
+// node, does not have this problem and should be preferred as the key of a UE, or the keys of the AMF UE
+// NGAP IDs kept per AMF by the caller when the GUAMI is known.
+type Key struct {
+	Type  Type
+	Scope string
+	Value string
+}
+
+// New returns the key of a numeric UE identity
+func New(t Type, value int64) Key {
+	return Key{Type: t, Value: strconv.FormatInt(value, 10)}
+}
+
+// NewScoped returns the key of a numeric UE identity allocated by the node named by scope
+func NewScoped(t Type, scope string, value int64) Key {
+	return Key{Type: t, Scope: scope, Value: strconv.FormatInt(value, 10)}
+}
+
+// Opaque returns the key of a UE identity known only as octets
+func Opaque(value []byte) Key {
+	return Key{Type: TypeOpaque, Value: hex.EncodeToString(value)}
+}
+
+// Parse parses the encoding produced by String
+func Parse(key string) (Key, error) {
+	parts := strings.Split(key, separator)
+	var k Key
+	switch len(parts) {
+	case unscopedKeyParts:
+		k = Key{Type: Type(parts[0]), Value: parts[1]}
+	case scopedKeyParts:
+		k = Key{Type: Type(parts[0]), Scope: parts[1], Value: parts[2]}
+	default:
+		return Key{}, fmt.Errorf("UE key %q should be of the form TYPE/VALUE or TYPE/SCOPE/VALUE", key)
+	}
+	if err := k.Validate(); err != nil {
+		return Key{}, err
+	}
+	return k, nil
+}
+
+// Validate checks the type of the key and the form of its value
+func (k Key) Validate() error {
+	if !types[k.Type] {
+		return fmt.Errorf("unknown UE key type %q", k.Type)
+	}
+	if k.Value == "" {
+		return fmt.Errorf("UE key %s has no value", k.Type)
+	}
+	if strings.Contains(k.Scope, separator) {
+		return fmt.Errorf("UE key scope %q should not contain %q", k.Scope, separator)
+	}
+	if k.Type == TypeOpaque {
+		if _, err := hex.DecodeString(k.Value); err != nil {
+			return fmt.Errorf("invalid %s value %q: %v", k.Type, k.Value, err)
+		}
+		return nil
+	}
+	value, err := strconv.ParseUint(k.Value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s value %q: %v", k.Type, k.Value, err)
+	}
+	if strconv.FormatUint(value, 10) != k.Value {
+		return fmt.Errorf("%s value %q is not in canonical decimal form", k.Type, k.Value)
+	}
+	return nil
+}
+
+// IsZero returns true for the zero Key
+func (k Key) IsZero() bool {
+	return k == Key{}
+}
+
+// Equal returns true if both keys hold the same identity with the same scope
+func (k Key) Equal(other Key) bool {
+	return k == other
+}
+
+// String returns the key as "TYPE/VALUE", or "TYPE/SCOPE/VALUE" for scoped keys, e.g. "MENB-UE-X2AP-ID/310-410:d4bc0:20/3"
+func (k Key) String() string {
+	if k.Scope == "" {
+		return string(k.Type) + separator + k.Value
+	}
+	return string(k.Type) + separator + k.Scope + separator + k.Value
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uekey
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestKey_String(t *testing.T) {
+	key := New(TypeRanUeNgapID, 42)
+	assert.Equal(t, "RAN-UE-NGAP-ID/42", key.String())
+	parsed, err := Parse(key.String())
+	assert.NilError(t, err)
+	assert.Assert(t, key.Equal(parsed))
+	assert.Assert(t, key == parsed)
+
+	key = NewScoped(TypeMenbUeX2apID, "310-410:d4bc0:20", 4095)
+	assert.Equal(t, "MENB-UE-X2AP-ID/310-410:d4bc0:20/4095", key.String())
+	parsed, err = Parse(key.String())
+	assert.NilError(t, err)
+	assert.Assert(t, key.Equal(parsed))
+	assert.Assert(t, !key.Equal(New(TypeMenbUeX2apID, 4095)))
+
+	key = Opaque([]byte{0x00, 0x01, 0xab})
+	assert.Equal(t, "UE-ID/0001ab", key.String())
+	parsed, err = Parse(key.String())
+	assert.NilError(t, err)
+	assert.Assert(t, key.Equal(parsed))
+
+	_, err = Parse("RAN-UE-NGAP-ID")
+	assert.ErrorContains(t, err, "should be of the form TYPE/VALUE")
+	_, err = Parse("RAN-UE-ID/42")
+	assert.ErrorContains(t, err, `unknown UE key type "RAN-UE-ID"`)
+	_, err = Parse("RAN-UE-NGAP-ID/042")
+	assert.ErrorContains(t, err, "not in canonical decimal form")
+	_, err = Parse("RAN-UE-NGAP-ID/-1")
+	assert.ErrorContains(t, err, "invalid RAN-UE-NGAP-ID value")
+	_, err = Parse("UE-ID/0g")
+	assert.ErrorContains(t, err, "invalid UE-ID value")
+	assert.Assert(t, Key{}.IsZero())
+}
@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package aggregator rolls up the values of consecutive E2SM-KPM format 1 indications per cell, measurement and label
+// into fixed length windows, keeping track of noValue records, incomplete data items and unreported granularity periods.
+package aggregator
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// Function is the function used to roll up the values of a measurement within a window
+type Function int
+
+const (
+	Sum Function = iota
+	Avg
+	Min
+	Max
+	Last
+)
+
+func (f Function) String() string {
+	switch f {
+	case Sum:
+		return "sum"
+	case Avg:
+		return "avg"
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Last:
+		return "last"
+	}
+	return fmt.Sprintf("Function(%d)", int(f))
+}
+
+// Key identifies one aggregated series
+type Key struct {
+	CellObjectID string
+	// Measurement is the measurement name, or "#<id>" for measurements identified by MeasurementTypeID
+	Measurement string
+	// Label is an opaque, stable encoding of the MeasurementLabel, empty for unlabelled measurements
+	Label string
+}
+
+// Record is the result of aggregating one series over one window
+type Record struct {
+	Key
+	MeasLabel *e2sm_kpm_v2_go.MeasurementLabel
+	Start     time.Time
+	Window    time.Duration
+	Function  Function
+	Value     float64
+	// Samples is the number of values aggregated into Value
+	Samples int
+	// NoValue is the number of granularity periods reported with noValue
+	NoValue int
+	// Incomplete is set when any aggregated measurement data item carried the IncompleteFlag
+	Incomplete bool
+	// Gap is set when granularity periods within the window were never reported
+	Gap bool
+}
+
+type series struct {
+	record *Record
+	// next is the collection time expected for the next value of the series
+	next time.Time
+}
+
+type subscription struct {
+	cellObjID    string
+	granularity  int64
+	measInfoList *e2sm_kpm_v2_go.MeasurementInfoList
+}
+
+// Aggregator rolls up consecutive KPM format 1 indications into windows of a fixed length
+type Aggregator struct {
+	window          time.Duration
+	defaultFunction Function
+	functions       map[string]Function
+	subscriptions   map[int64]*subscription
+	series          map[Key]*series
+}
+
+// NewAggregator creates an aggregator emitting records for windows of the given length,
+// using defaultFunction for measurements without a function set by SetFunction
+func NewAggregator(window time.Duration, defaultFunction Function) (*Aggregator, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window should be positive, got %v", window)
+	}
+	return &Aggregator{
+		window:          window,
+		defaultFunction: defaultFunction,
+		functions:       make(map[string]Function),
+		subscriptions:   make(map[int64]*subscription),
+		series:          make(map[Key]*series),
+	}, nil
+}
+
+// SetFunction sets the roll up function of a measurement, given by name or as "#<id>"
+func (a *Aggregator) SetFunction(measurement string, function Function) *Aggregator {
+	a.functions[measurement] = function
+	return a
+}
+
+// AddActionDefinition registers the subscription an E2 node reports against, so that indications which
+// omit the optional cell object ID, granularity period or measurement info list can still be aggregated
+func (a *Aggregator) AddActionDefinition(actionDefinition *e2sm_kpm_v2_go.E2SmKpmActionDefinitionFormat1) {
+	a.subscriptions[actionDefinition.GetSubscriptId().GetValue()] = &subscription{
+		cellObjID:    actionDefinition.GetCellObjId().GetValue(),
+		granularity:  actionDefinition.GetGranulPeriod().GetValue(),
+		measInfoList: actionDefinition.GetMeasInfoList(),
+	}
+}
+
+// CollectStartTime returns the collection start time of a format 1 indication header,
+// whose TimeStamp carries the 32 most significant bits (seconds) of an NTP timestamp
+func CollectStartTime(header *e2sm_kpm_v2_go.E2SmKpmIndicationHeader) (time.Time, error) {
+	timeStamp := header.GetIndicationHeaderFormats().GetIndicationHeaderFormat1().GetColletStartTime().GetValue()
+	if len(timeStamp) != 4 {
+		return time.Time{}, fmt.Errorf("TimeStamp should be 4 bytes, got %d", len(timeStamp))
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(timeStamp))-ntpEpochOffset, 0).UTC(), nil
+}
+
+// Add aggregates a decoded indication header and format 1 indication message, and returns the records of
+// the windows which the indication completed. The indication is checked as a whole before any value is
+// aggregated, so that an indication which cannot be aggregated leaves the aggregator unchanged.
+func (a *Aggregator) Add(header *e2sm_kpm_v2_go.E2SmKpmIndicationHeader, message *e2sm_kpm_v2_go.E2SmKpmIndicationMessage) ([]*Record, error) {
+	startTime, err := CollectStartTime(header)
+	if err != nil {
+		return nil, err
+	}
+	format1 := message.GetIndicationMessageFormats().GetIndicationMessageFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("only E2SmKpmIndicationMessageFormat1 can be aggregated, got %v", message.GetIndicationMessageFormats())
+	}
+
+	sub := a.subscriptions[format1.GetSubscriptId().GetValue()]
+	cellObjID := format1.GetCellObjId().GetValue()
+	granularity := format1.GetGranulPeriod().GetValue()
+	measInfoList := format1.GetMeasInfoList()
+	if sub != nil {
+		if format1.GetCellObjId() == nil {
+			cellObjID = sub.cellObjID
+		}
+		if format1.GetGranulPeriod() == nil {
+			granularity = sub.granularity
+		}
+		if measInfoList == nil {
+			measInfoList = sub.measInfoList
+		}
+	}
+	if granularity <= 0 {
+		return nil, fmt.Errorf("no GranularityPeriod known for subscription %d", format1.GetSubscriptId().GetValue())
+	}
+	if measInfoList == nil {
+		return nil, fmt.Errorf("no MeasurementInfoList known for subscription %d", format1.GetSubscriptId().GetValue())
+	}
+
+	keys, labels, err := a.keys(cellObjID, measInfoList)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.validate(keys, startTime, format1.GetMeasData()); err != nil {
+		return nil, err
+	}
+
+	records := make([]*Record, 0)
+	period := time.Duration(granularity) * time.Millisecond
+	for i, dataItem := range format1.GetMeasData().GetValue() {
+		collectTime := startTime.Add(time.Duration(i) * period)
+		for j, recordItem := range dataItem.GetMeasRecord().GetValue() {
+			if closed := a.addValue(keys[j], labels[j], collectTime, period, recordItem, dataItem.IncompleteFlag != nil); closed != nil {
+				records = append(records, closed)
+			}
+		}
+	}
+	return records, nil
+}
+
+// validate checks that the measurement data of an indication can be aggregated into the series of keys
+func (a *Aggregator) validate(keys []Key, startTime time.Time, measData *e2sm_kpm_v2_go.MeasurementData) error {
+	seen := make(map[Key]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			return fmt.Errorf("%v: measured more than once in the MeasurementInfoList", key)
+		}
+		seen[key] = true
+		if s, ok := a.series[key]; ok && startTime.Before(s.next) {
+			return fmt.Errorf("%v: value collected at %v is older than the last aggregated value", key, startTime)
+		}
+	}
+	for i, dataItem := range measData.GetValue() {
+		measRecord := dataItem.GetMeasRecord().GetValue()
+		if len(measRecord) != len(keys) {
+			return fmt.Errorf("MeasurementDataItem %d has %d records, expected %d", i, len(measRecord), len(keys))
+		}
+		for _, recordItem := range measRecord {
+			switch recordItem.GetMeasurementRecordItem().(type) {
+			case *e2sm_kpm_v2_go.MeasurementRecordItem_Integer,
+				*e2sm_kpm_v2_go.MeasurementRecordItem_Real,
+				*e2sm_kpm_v2_go.MeasurementRecordItem_NoValue:
+			default:
+				return fmt.Errorf("unexpected MeasurementRecordItem %v in MeasurementDataItem %d", recordItem, i)
+			}
+		}
+	}
+	return nil
+}
+
+// Flush returns the records of all open windows, including incomplete ones, and resets the aggregator state
+func (a *Aggregator) Flush() []*Record {
+	records := make([]*Record, 0, len(a.series))
+	for key, s := range a.series {
+		if s.next.Before(s.record.Start.Add(a.window)) {
+			s.record.Gap = true
+		}
+		records = append(records, finish(s.record))
+		delete(a.series, key)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Start.Equal(records[j].Start) {
+			return records[i].Start.Before(records[j].Start)
+		}
+		if records[i].CellObjectID != records[j].CellObjectID {
+			return records[i].CellObjectID < records[j].CellObjectID
+		}
+		if records[i].Measurement != records[j].Measurement {
+			return records[i].Measurement < records[j].Measurement
+		}
+		return records[i].Label < records[j].Label
+	})
+	return records
+}
+
+func (a *Aggregator) keys(cellObjID string, measInfoList *e2sm_kpm_v2_go.MeasurementInfoList) ([]Key, []*e2sm_kpm_v2_go.MeasurementLabel, error) {
+	keys := make([]Key, 0)
+	labels := make([]*e2sm_kpm_v2_go.MeasurementLabel, 0)
+	for _, measInfoItem := range measInfoList.GetValue() {
+		var measurement string
+		switch measType := measInfoItem.GetMeasType().GetMeasurementType().(type) {
+		case *e2sm_kpm_v2_go.MeasurementType_MeasName:
+			measurement = measType.MeasName.GetValue()
+		case *e2sm_kpm_v2_go.MeasurementType_MeasId:
+			measurement = "#" + strconv.FormatInt(int64(measType.MeasId.GetValue()), 10)
+		default:
+			return nil, nil, fmt.Errorf("unexpected MeasurementType %v", measInfoItem.GetMeasType())
+		}
+		if len(measInfoItem.GetLabelInfoList().GetValue()) == 0 {
+			keys = append(keys, Key{CellObjectID: cellObjID, Measurement: measurement})
+			labels = append(labels, nil)
+			continue
+		}
+		for _, labelInfoItem := range measInfoItem.GetLabelInfoList().GetValue() {
+			labelBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(labelInfoItem.GetMeasLabel())
+			if err != nil {
+				return nil, nil, err
+			}
+			keys = append(keys, Key{CellObjectID: cellObjID, Measurement: measurement, Label: hex.EncodeToString(labelBytes)})
+			labels = append(labels, labelInfoItem.GetMeasLabel())
+		}
+	}
+	return keys, labels, nil
+}
+
+// addValue aggregates a value checked by validate, and returns the record of the window it closed, if any
+func (a *Aggregator) addValue(key Key, label *e2sm_kpm_v2_go.MeasurementLabel, collectTime time.Time, period time.Duration,
+	recordItem *e2sm_kpm_v2_go.MeasurementRecordItem, incomplete bool) *Record {
+	windowStart := collectTime.Truncate(a.window)
+
+	var closed *Record
+	s, ok := a.series[key]
+	if ok {
+		if !windowStart.Equal(s.record.Start) {
+			if s.next.Before(s.record.Start.Add(a.window)) {
+				s.record.Gap = true
+			}
+			closed = finish(s.record)
+			ok = false
+		} else if collectTime.After(s.next) {
+			s.record.Gap = true
+		}
+	}
+	if !ok {
+		function, found := a.functions[key.Measurement]
+		if !found {
+			function = a.defaultFunction
+		}
+		s = &series{
+			record: &Record{
+				Key:       key,
+				MeasLabel: label,
+				Start:     windowStart,
+				Window:    a.window,
+				Function:  function,
+				Gap:       collectTime.After(windowStart),
+			},
+		}
+		a.series[key] = s
+	}
+	s.next = collectTime.Add(period)
+	if incomplete {
+		s.record.Incomplete = true
+	}
+
+	var value float64
+	switch item := recordItem.GetMeasurementRecordItem().(type) {
+	case *e2sm_kpm_v2_go.MeasurementRecordItem_Integer:
+		value = float64(item.Integer)
+	case *e2sm_kpm_v2_go.MeasurementRecordItem_Real:
+		value = item.Real
+	default:
+		s.record.NoValue++
+		return closed
+	}
+
+	r := s.record
+	switch {
+	case r.Samples == 0:
+		r.Value = value
+	case r.Function == Sum || r.Function == Avg:
+		r.Value += value
+	case r.Function == Min:
+		r.Value = math.Min(r.Value, value)
+	case r.Function == Max:
+		r.Value = math.Max(r.Value, value)
+	case r.Function == Last:
+		r.Value = value
+	}
+	r.Samples++
+	return closed
+}
+
+func finish(record *Record) *Record {
+	if record.Function == Avg && record.Samples > 0 {
+		record.Value /= float64(record.Samples)
+	}
+	return record
+}
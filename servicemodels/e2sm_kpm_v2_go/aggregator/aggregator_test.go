@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/pdubuilder"
+	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"gotest.tools/assert"
+)
+
+// t0 is aligned to a minute boundary
+var t0 = time.Unix(1600000020, 0).UTC()
+
+func createHeader(t *testing.T, collectStartTime time.Time) *e2sm_kpm_v2_go.E2SmKpmIndicationHeader {
+	timeStamp := make([]byte, 4)
+	binary.BigEndian.PutUint32(timeStamp, uint32(collectStartTime.Unix()+ntpEpochOffset))
+	header, err := pdubuilder.CreateE2SmKpmIndicationHeader(timeStamp)
+	assert.NilError(t, err)
+	return header
+}
+
+func createMeasInfoList(t *testing.T, measNames ...string) *e2sm_kpm_v2_go.MeasurementInfoList {
+	measInfoList := &e2sm_kpm_v2_go.MeasurementInfoList{
+		Value: make([]*e2sm_kpm_v2_go.MeasurementInfoItem, 0),
+	}
+	for _, measName := range measNames {
+		measType, err := pdubuilder.CreateMeasurementTypeMeasName(measName)
+		assert.NilError(t, err)
+		measInfoList.Value = append(measInfoList.Value, pdubuilder.CreateMeasurementInfoItem(measType))
+	}
+	return measInfoList
+}
+
+func createMessage(t *testing.T, subscriptionID int64, records ...[]*e2sm_kpm_v2_go.MeasurementRecordItem) *e2sm_kpm_v2_go.E2SmKpmIndicationMessage {
+	measData := &e2sm_kpm_v2_go.MeasurementData{
+		Value: make([]*e2sm_kpm_v2_go.MeasurementDataItem, 0),
+	}
+	for _, record := range records {
+		dataItem, err := pdubuilder.CreateMeasurementDataItem(&e2sm_kpm_v2_go.MeasurementRecord{
+			Value: record,
+		})
+		assert.NilError(t, err)
+		measData.Value = append(measData.Value, dataItem)
+	}
+	return pdubuilder.CreateE2SmKpmIndicationMessageFormat1(subscriptionID, measData)
+}
+
+func ints(values ...int64) []*e2sm_kpm_v2_go.MeasurementRecordItem {
+	items := make([]*e2sm_kpm_v2_go.MeasurementRecordItem, 0, len(values))
+	for _, v := range values {
+		items = append(items, pdubuilder.CreateMeasurementRecordItemInteger(v))
+	}
+	return items
+}
+
+func TestAggregator_Windows(t *testing.T) {
+	agg, err := NewAggregator(time.Minute, Sum)
+	assert.NilError(t, err)
+	agg.SetFunction("RRC.Conn.Avg", Avg).
+		SetFunction("RRC.Conn.Max", Max)
+	measInfoList := createMeasInfoList(t, "RRC.Conn.Avg", "RRC.Conn.Max", "DRB.UEThpDl")
+
+	msg := createMessage(t, 1, ints(1, 5, 100), ints(2, 9, 200), ints(3, 7, 300)).
+		SetMeasInfoList(measInfoList).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	records, err := agg.Add(createHeader(t, t0), msg)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	msg = createMessage(t, 1, ints(4, 2, 400), ints(5, 3, 500), ints(6, 4, 600)).
+		SetMeasInfoList(measInfoList).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	records, err = agg.Add(createHeader(t, t0.Add(30*time.Second)), msg)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	// First value of the next window closes the first one
+	msg = createMessage(t, 1, ints(10, 10, 1000)).
+		SetMeasInfoList(measInfoList).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	records, err = agg.Add(createHeader(t, t0.Add(time.Minute)), msg)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(records))
+	for _, r := range records {
+		assert.Equal(t, "cell1", r.CellObjectID)
+		assert.Equal(t, t0, r.Start)
+		assert.Equal(t, 6, r.Samples)
+		assert.Assert(t, !r.Gap)
+		assert.Assert(t, !r.Incomplete)
+		switch r.Measurement {
+		case "RRC.Conn.Avg":
+			assert.Equal(t, Avg, r.Function)
+			assert.Equal(t, 3.5, r.Value)
+		case "RRC.Conn.Max":
+			assert.Equal(t, Max, r.Function)
+			assert.Equal(t, 9.0, r.Value)
+		case "DRB.UEThpDl":
+			assert.Equal(t, Sum, r.Function)
+			assert.Equal(t, 2100.0, r.Value)
+		default:
+			t.Fatalf("unexpected measurement %s", r.Measurement)
+		}
+	}
+
+	// Skipping t0+70s..t0+80s leaves a gap in the second window
+	msg = createMessage(t, 1, ints(20, 20, 2000)).
+		SetMeasInfoList(measInfoList).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	records, err = agg.Add(createHeader(t, t0.Add(90*time.Second)), msg)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	// Values older than the last aggregated ones are rejected
+	_, err = agg.Add(createHeader(t, t0.Add(80*time.Second)), msg)
+	assert.ErrorContains(t, err, "older than the last aggregated value")
+
+	records = agg.Flush()
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, "DRB.UEThpDl", records[0].Measurement)
+	assert.Equal(t, 3000.0, records[0].Value)
+	assert.Equal(t, "RRC.Conn.Avg", records[1].Measurement)
+	assert.Equal(t, 15.0, records[1].Value)
+	for _, r := range records {
+		assert.Equal(t, t0.Add(time.Minute), r.Start)
+		assert.Equal(t, 2, r.Samples)
+		assert.Assert(t, r.Gap)
+	}
+	assert.Equal(t, 0, len(agg.Flush()))
+}
+
+func TestAggregator_NoValueAndIncomplete(t *testing.T) {
+	agg, err := NewAggregator(time.Minute, Min)
+	assert.NilError(t, err)
+	measInfoList := createMeasInfoList(t, "RRC.Conn.Avg")
+	actionDefinition, err := pdubuilder.CreateActionDefinitionFormat1("cell2", measInfoList, 30000, 7)
+	assert.NilError(t, err)
+	agg.AddActionDefinition(actionDefinition)
+
+	// Cell object ID, granularity period and measurement info list are taken from the action definition
+	msg := createMessage(t, 7,
+		[]*e2sm_kpm_v2_go.MeasurementRecordItem{pdubuilder.CreateMeasurementRecordItemReal(2.5)},
+		[]*e2sm_kpm_v2_go.MeasurementRecordItem{pdubuilder.CreateMeasurementRecordItemNoValue()})
+	msg.GetIndicationMessageFormats().GetIndicationMessageFormat1().GetMeasData().GetValue()[1].SetIncompleteFlag()
+	records, err := agg.Add(createHeader(t, t0), msg)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	records = agg.Flush()
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, "cell2", records[0].CellObjectID)
+	assert.Equal(t, 2.5, records[0].Value)
+	assert.Equal(t, 1, records[0].Samples)
+	assert.Equal(t, 1, records[0].NoValue)
+	assert.Assert(t, records[0].Incomplete)
+	assert.Assert(t, !records[0].Gap)
+
+	// Without an action definition, an indication omitting the measurement info list cannot be aggregated
+	_, err = agg.Add(createHeader(t, t0), createMessage(t, 8, ints(1)).SetGranularityPeriod(1000))
+	assert.ErrorContains(t, err, "no MeasurementInfoList known for subscription 8")
+	_, err = agg.Add(createHeader(t, t0), createMessage(t, 8, ints(1)))
+	assert.ErrorContains(t, err, "no GranularityPeriod known for subscription 8")
+	_, err = agg.Add(createHeader(t, t0), createMessage(t, 7, ints(1, 2)))
+	assert.ErrorContains(t, err, "has 2 records, expected 1")
+}
+
+func TestAggregator_InvalidIndication(t *testing.T) {
+	_, err := NewAggregator(0, Sum)
+	assert.ErrorContains(t, err, "window should be positive")
+
+	agg, err := NewAggregator(time.Minute, Sum)
+	assert.NilError(t, err)
+	measInfoList := createMeasInfoList(t, "RRC.Conn.Avg", "DRB.UEThpDl")
+	msg := createMessage(t, 1, ints(1, 100)).
+		SetMeasInfoList(measInfoList).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	_, err = agg.Add(createHeader(t, t0), msg)
+	assert.NilError(t, err)
+
+	// The last data item is invalid: none of the values before it is aggregated
+	msg = createMessage(t, 1, ints(2, 200), ints(3, 300), ints(4)).
+		SetMeasInfoList(measInfoList).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	_, err = agg.Add(createHeader(t, t0.Add(10*time.Second)), msg)
+	assert.ErrorContains(t, err, "MeasurementDataItem 2 has 1 records, expected 2")
+
+	// A measurement listed twice is rejected before either is aggregated
+	msg = createMessage(t, 1, ints(2, 200)).
+		SetMeasInfoList(createMeasInfoList(t, "DRB.UEThpDl", "DRB.UEThpDl")).SetGranularityPeriod(10000).SetCellObjectID("cell1")
+	_, err = agg.Add(createHeader(t, t0.Add(10*time.Second)), msg)
+	assert.ErrorContains(t, err, "measured more than once")
+
+	records := agg.Flush()
+	assert.Equal(t, 2, len(records))
+	for _, r := range records {
+		assert.Equal(t, 1, r.Samples)
+	}
+	assert.Equal(t, 100.0, records[0].Value)
+	assert.Equal(t, 1.0, records[1].Value)
+}
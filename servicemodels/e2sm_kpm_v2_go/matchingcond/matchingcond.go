@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package matchingcond
+
+import (
+	"bytes"
+	"fmt"
+
+	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+)
+
+// TestCond identifies the UE attribute a TestCondInfo refers to
+type TestCond int
+
+const (
+	TestCondGBR TestCond = iota
+	TestCondAMBR
+	TestCondIsStat
+	TestCondIsCatM
+	TestCondRSRP
+	TestCondRSRQ
+)
+
+func (tc TestCond) String() string {
+	switch tc {
+	case TestCondGBR:
+		return "GBR"
+	case TestCondAMBR:
+		return "AMBR"
+	case TestCondIsStat:
+		return "IsStat"
+	case TestCondIsCatM:
+		return "IsCatM"
+	case TestCondRSRP:
+		return "RSRP"
+	case TestCondRSRQ:
+		return "RSRQ"
+	}
+	return fmt.Sprintf("TestCond(%d)", int(tc))
+}
+
+// TestCondOf returns the TestCond selected by the TestCondType CHOICE
+func TestCondOf(tct *e2sm_kpm_v2_go.TestCondType) (TestCond, error) {
+	switch tct.GetTestCondType().(type) {
+	case *e2sm_kpm_v2_go.TestCondType_GBr:
+		return TestCondGBR, nil
+	case *e2sm_kpm_v2_go.TestCondType_AMbr:
+		return TestCondAMBR, nil
+	case *e2sm_kpm_v2_go.TestCondType_IsStat:
+		return TestCondIsStat, nil
+	case *e2sm_kpm_v2_go.TestCondType_IsCatM:
+		return TestCondIsCatM, nil
+	case *e2sm_kpm_v2_go.TestCondType_RSrp:
+		return TestCondRSRP, nil
+	case *e2sm_kpm_v2_go.TestCondType_RSrq:
+		return TestCondRSRQ, nil
+	}
+	return 0, fmt.Errorf("unexpected TestCondType %v", tct)
+}
+
+// UeAttributes describes a UE (or one of its bearers) against which matching conditions are evaluated.
+// Unset fields are treated as unknown: a label constraining them does not match.
+type UeAttributes struct {
+	PlmnID []byte
+	Sst    []byte
+	Sd     []byte
+	FiveQI *int32
+	Qfi    *int32
+	Qci    *int32
+	Arp    *int32
+	// Tests holds the values reported for each test condition, e.g. pdubuilder.CreateTestCondValueInt(-95) for RSRP
+	Tests map[TestCond]*e2sm_kpm_v2_go.TestCondValue
+}
+
+// SetTest sets the value reported for a test condition
+func (ue *UeAttributes) SetTest(tc TestCond, value *e2sm_kpm_v2_go.TestCondValue) *UeAttributes {
+	if ue.Tests == nil {
+		ue.Tests = make(map[TestCond]*e2sm_kpm_v2_go.TestCondValue)
+	}
+	ue.Tests[tc] = value
+	return ue
+}
+
+// MatchConditionList returns true if the UE satisfies every item of the MatchingCondList
+func MatchConditionList(mcl *e2sm_kpm_v2_go.MatchingCondList, ue *UeAttributes) (bool, error) {
+	if len(mcl.GetValue()) == 0 {
+		return false, fmt.Errorf("MatchingCondList should contain at least one item")
+	}
+	for i, item := range mcl.GetValue() {
+		ok, err := MatchConditionItem(item, ue)
+		if err != nil {
+			return false, fmt.Errorf("MatchingCondItem %d: %v", i, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MatchConditionItem evaluates a single MatchingCondItem (either a MeasurementLabel or a TestCondInfo)
+func MatchConditionItem(mci *e2sm_kpm_v2_go.MatchingCondItem, ue *UeAttributes) (bool, error) {
+	switch item := mci.GetMatchingCondItem().(type) {
+	case *e2sm_kpm_v2_go.MatchingCondItem_MeasLabel:
+		return MatchLabel(item.MeasLabel, ue), nil
+	case *e2sm_kpm_v2_go.MatchingCondItem_TestCondInfo:
+		return MatchTestCondInfo(item.TestCondInfo, ue)
+	}
+	return false, fmt.Errorf("unexpected MatchingCondItem %v", mci)
+}
+
+// MatchLabel returns true if the UE falls within every present field of the MeasurementLabel.
+// Fields which only describe how a measurement is reported (bitrate range, distribution bins,
+// SUM, pre-label override and start/end indication) do not constrain UEs and are ignored.
+func MatchLabel(ml *e2sm_kpm_v2_go.MeasurementLabel, ue *UeAttributes) bool {
+	if ml.GetPlmnId() != nil && !bytes.Equal(ml.GetPlmnId().GetValue(), ue.PlmnID) {
+		return false
+	}
+	if ml.GetSliceId() != nil {
+		if !bytes.Equal(ml.GetSliceId().GetSSt(), ue.Sst) {
+			return false
+		}
+		if ml.GetSliceId().SD != nil && !bytes.Equal(ml.GetSliceId().GetSD(), ue.Sd) {
+			return false
+		}
+	}
+	if ml.GetFiveQi() != nil && !equalInt32(ue.FiveQI, ml.GetFiveQi().GetValue()) {
+		return false
+	}
+	if ml.GetQFi() != nil && !equalInt32(ue.Qfi, ml.GetQFi().GetValue()) {
+		return false
+	}
+	if ml.GetQCi() != nil && !equalInt32(ue.Qci, ml.GetQCi().GetValue()) {
+		return false
+	}
+	if ml.GetQCimin() != nil && (ue.Qci == nil || *ue.Qci < ml.GetQCimin().GetValue()) {
+		return false
+	}
+	if ml.GetQCimax() != nil && (ue.Qci == nil || *ue.Qci > ml.GetQCimax().GetValue()) {
+		return false
+	}
+	if ml.GetARpmin() != nil && (ue.Arp == nil || *ue.Arp < ml.GetARpmin().GetValue()) {
+		return false
+	}
+	if ml.GetARpmax() != nil && (ue.Arp == nil || *ue.Arp > ml.GetARpmax().GetValue()) {
+		return false
+	}
+	return true
+}
+
+// MatchTestCondInfo applies the TestCondExpression of a TestCondInfo to the UE's value for its TestCondType
+func MatchTestCondInfo(tci *e2sm_kpm_v2_go.TestCondInfo, ue *UeAttributes) (bool, error) {
+	tc, err := TestCondOf(tci.GetTestType())
+	if err != nil {
+		return false, err
+	}
+	actual, ok := ue.Tests[tc]
+	if tci.GetTestExpr() == e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_PRESENT {
+		return ok && actual != nil, nil
+	}
+	if tci.GetTestValue() == nil {
+		return false, fmt.Errorf("%v %v requires a TestCondValue", tc, tci.GetTestExpr())
+	}
+	if !ok || actual == nil {
+		return false, nil
+	}
+
+	switch tci.GetTestExpr() {
+	case e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_EQUAL:
+		return equalValue(actual, tci.GetTestValue())
+	case e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_GREATERTHAN:
+		cmp, err := compareValue(actual, tci.GetTestValue())
+		return cmp > 0, err
+	case e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_LESSTHAN:
+		cmp, err := compareValue(actual, tci.GetTestValue())
+		return cmp < 0, err
+	case e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_CONTAINS:
+		return containsValue(actual, tci.GetTestValue())
+	}
+	return false, fmt.Errorf("unexpected TestCondExpression %v", tci.GetTestExpr())
+}
+
+// MatchingMeasurements returns the measurement types of a format 3 MeasurementCondList whose conditions the UE satisfies
+func MatchingMeasurements(mcl *e2sm_kpm_v2_go.MeasurementCondList, ue *UeAttributes) ([]*e2sm_kpm_v2_go.MeasurementType, error) {
+	measTypes := make([]*e2sm_kpm_v2_go.MeasurementType, 0)
+	for i, item := range mcl.GetValue() {
+		ok, err := MatchConditionList(item.GetMatchingCond(), ue)
+		if err != nil {
+			return nil, fmt.Errorf("MeasurementCondItem %d: %v", i, err)
+		}
+		if ok {
+			measTypes = append(measTypes, item.GetMeasType())
+		}
+	}
+	return measTypes, nil
+}
+
+func equalInt32(actual *int32, expected int32) bool {
+	return actual != nil && *actual == expected
+}
+
+func numericValue(tcv *e2sm_kpm_v2_go.TestCondValue) (int64, bool) {
+	switch v := tcv.GetTestCondValue().(type) {
+	case *e2sm_kpm_v2_go.TestCondValue_ValueInt:
+		return v.ValueInt, true
+	case *e2sm_kpm_v2_go.TestCondValue_ValueEnum:
+		return v.ValueEnum, true
+	}
+	return 0, false
+}
+
+func byteValue(tcv *e2sm_kpm_v2_go.TestCondValue) ([]byte, bool) {
+	switch v := tcv.GetTestCondValue().(type) {
+	case *e2sm_kpm_v2_go.TestCondValue_ValueOctS:
+		return v.ValueOctS, true
+	case *e2sm_kpm_v2_go.TestCondValue_ValuePrtS:
+		return []byte(v.ValuePrtS), true
+	}
+	return nil, false
+}
+
+func equalValue(actual *e2sm_kpm_v2_go.TestCondValue, expected *e2sm_kpm_v2_go.TestCondValue) (bool, error) {
+	if a, ok := numericValue(actual); ok {
+		if e, ok := numericValue(expected); ok {
+			return a == e, nil
+		}
+	}
+	switch e := expected.GetTestCondValue().(type) {
+	case *e2sm_kpm_v2_go.TestCondValue_ValueBool:
+		a, ok := actual.GetTestCondValue().(*e2sm_kpm_v2_go.TestCondValue_ValueBool)
+		if !ok {
+			break
+		}
+		return a.ValueBool == e.ValueBool, nil
+	case *e2sm_kpm_v2_go.TestCondValue_ValueBitS:
+		a, ok := actual.GetTestCondValue().(*e2sm_kpm_v2_go.TestCondValue_ValueBitS)
+		if !ok {
+			break
+		}
+		return a.ValueBitS.GetLen() == e.ValueBitS.GetLen() && bytes.Equal(a.ValueBitS.GetValue(), e.ValueBitS.GetValue()), nil
+	case *e2sm_kpm_v2_go.TestCondValue_ValueOctS, *e2sm_kpm_v2_go.TestCondValue_ValuePrtS:
+		a, ok := byteValue(actual)
+		if !ok {
+			break
+		}
+		b, _ := byteValue(expected)
+		return bytes.Equal(a, b), nil
+	}
+	return false, fmt.Errorf("cannot compare %v with %v", actual, expected)
+}
+
+func compareValue(actual *e2sm_kpm_v2_go.TestCondValue, expected *e2sm_kpm_v2_go.TestCondValue) (int, error) {
+	a, okA := numericValue(actual)
+	e, okE := numericValue(expected)
+	if !okA || !okE {
+		return 0, fmt.Errorf("ordering is only defined for integer and enumerated values, got %v and %v", actual, expected)
+	}
+	switch {
+	case a < e:
+		return -1, nil
+	case a > e:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func containsValue(actual *e2sm_kpm_v2_go.TestCondValue, expected *e2sm_kpm_v2_go.TestCondValue) (bool, error) {
+	a, okA := byteValue(actual)
+	e, okE := byteValue(expected)
+	if !okA || !okE {
+		return false, fmt.Errorf("contains is only defined for octet and printable strings, got %v and %v", actual, expected)
+	}
+	return bytes.Contains(a, e), nil
+}
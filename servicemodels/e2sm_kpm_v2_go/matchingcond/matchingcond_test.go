@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package matchingcond
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/pdubuilder"
+	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"gotest.tools/assert"
+)
+
+func createTestUe() *UeAttributes {
+	var fiveQI int32 = 9
+	var qci int32 = 7
+	var arp int32 = 5
+	ue := &UeAttributes{
+		PlmnID: []byte{0x21, 0x22, 0x23},
+		Sst:    []byte{0x01},
+		Sd:     []byte{0x01, 0x02, 0x03},
+		FiveQI: &fiveQI,
+		Qci:    &qci,
+		Arp:    &arp,
+	}
+	ue.SetTest(TestCondRSRP, pdubuilder.CreateTestCondValueInt(-95)).
+		SetTest(TestCondGBR, pdubuilder.CreateTestCondValueBool(true)).
+		SetTest(TestCondIsCatM, pdubuilder.CreateTestCondValuePrtS("cat-m1"))
+	return ue
+}
+
+func createTestCondItem(t *testing.T, tct *e2sm_kpm_v2_go.TestCondType, tce e2sm_kpm_v2_go.TestCondExpression,
+	tcv *e2sm_kpm_v2_go.TestCondValue) *e2sm_kpm_v2_go.MatchingCondItem {
+	tci, err := pdubuilder.CreateTestCondInfo(tct, tce, tcv)
+	assert.NilError(t, err)
+	item, err := pdubuilder.CreateMatchingCondItemTestCondInfo(tci)
+	assert.NilError(t, err)
+	return item
+}
+
+func TestMatchLabel(t *testing.T) {
+	ue := createTestUe()
+
+	ml := pdubuilder.CreateMeasurementLabelEmpty()
+	assert.Assert(t, MatchLabel(ml, ue))
+
+	ml.SetPlmnID(&e2sm_kpm_v2_go.PlmnIdentity{Value: []byte{0x21, 0x22, 0x23}}).SetFiveQi(9).SetQciMin(5).SetQciMax(9)
+	assert.Assert(t, MatchLabel(ml, ue))
+
+	ml.SetSliceID(&e2sm_kpm_v2_go.Snssai{SSt: []byte{0x01}})
+	assert.Assert(t, MatchLabel(ml, ue))
+	ml.GetSliceId().SetSliceID([]byte{0x03, 0x02, 0x01})
+	assert.Assert(t, !MatchLabel(ml, ue))
+	ml.GetSliceId().SetSliceID([]byte{0x01, 0x02, 0x03})
+
+	ml.SetArpMax(4)
+	assert.Assert(t, !MatchLabel(ml, ue))
+	ml.SetArpMax(15)
+	ml.SetQfi(1)
+	assert.Assert(t, !MatchLabel(ml, ue), "UE without QFI should not match a QFI label")
+}
+
+func TestMatchTestCondInfo(t *testing.T) {
+	ue := createTestUe()
+
+	ok, err := MatchConditionItem(createTestCondItem(t, pdubuilder.CreateTestCondTypeRSRP(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_GREATERTHAN, pdubuilder.CreateTestCondValueInt(-100)), ue)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	ok, err = MatchConditionItem(createTestCondItem(t, pdubuilder.CreateTestCondTypeRSRP(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_LESSTHAN, pdubuilder.CreateTestCondValueInt(-100)), ue)
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	ok, err = MatchConditionItem(createTestCondItem(t, pdubuilder.CreateTestCondTypeGBR(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_EQUAL, pdubuilder.CreateTestCondValueBool(true)), ue)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	ok, err = MatchConditionItem(createTestCondItem(t, pdubuilder.CreateTestCondTypeIsCatM(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_CONTAINS, pdubuilder.CreateTestCondValuePrtS("cat-m")), ue)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+
+	ok, err = MatchConditionItem(createTestCondItem(t, pdubuilder.CreateTestCondTypeRSRQ(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_PRESENT, nil), ue)
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	_, err = MatchConditionItem(createTestCondItem(t, pdubuilder.CreateTestCondTypeGBR(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_GREATERTHAN, pdubuilder.CreateTestCondValueBool(false)), ue)
+	assert.ErrorContains(t, err, "ordering is only defined")
+}
+
+func TestMatchingMeasurements(t *testing.T) {
+	ue := createTestUe()
+
+	labelItem, err := pdubuilder.CreateMatchingCondItemMeasLabel(pdubuilder.CreateMeasurementLabelEmpty().SetFiveQi(9))
+	assert.NilError(t, err)
+	rsrpItem := createTestCondItem(t, pdubuilder.CreateTestCondTypeRSRP(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_LESSTHAN, pdubuilder.CreateTestCondValueInt(-90))
+	gbrItem := createTestCondItem(t, pdubuilder.CreateTestCondTypeGBR(),
+		e2sm_kpm_v2_go.TestCondExpression_TEST_COND_EXPRESSION_EQUAL, pdubuilder.CreateTestCondValueBool(false))
+
+	measName1, err := pdubuilder.CreateMeasurementTypeMeasName("RRU.PrbUsedDl")
+	assert.NilError(t, err)
+	measCondItem1, err := pdubuilder.CreateMeasurementCondItem(measName1, &e2sm_kpm_v2_go.MatchingCondList{
+		Value: []*e2sm_kpm_v2_go.MatchingCondItem{labelItem, rsrpItem},
+	})
+	assert.NilError(t, err)
+
+	measName2, err := pdubuilder.CreateMeasurementTypeMeasName("DRB.UEThpDl")
+	assert.NilError(t, err)
+	measCondItem2, err := pdubuilder.CreateMeasurementCondItem(measName2, &e2sm_kpm_v2_go.MatchingCondList{
+		Value: []*e2sm_kpm_v2_go.MatchingCondItem{labelItem, gbrItem},
+	})
+	assert.NilError(t, err)
+
+	measTypes, err := MatchingMeasurements(&e2sm_kpm_v2_go.MeasurementCondList{
+		Value: []*e2sm_kpm_v2_go.MeasurementCondItem{measCondItem1, measCondItem2},
+	}, ue)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(measTypes))
+	assert.Equal(t, "RRU.PrbUsedDl", measTypes[0].GetMeasName().GetValue())
+
+	_, err = MatchConditionList(&e2sm_kpm_v2_go.MatchingCondList{}, ue)
+	assert.ErrorContains(t, err, "at least one item")
+}
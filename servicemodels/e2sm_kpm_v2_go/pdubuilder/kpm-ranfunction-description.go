@@ -5,6 +5,8 @@ package pdubuilder
 
 import (
 	"fmt"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 )
@@ -102,6 +104,34 @@ func CreateCellGlobalIDEUTRACGI(plmnID []byte, bs *asn1.BitString) (*e2sm_kpm_v2
 	}, nil
 }
 
+// CreateCellGlobalIDNRCGIFromNci creates an NR CGI out of a 36 bit NCI. Unlike CreateCellGlobalIDNRCGI, it checks
+// that the PLMN ID is BCD encoded.
+func CreateCellGlobalIDNRCGIFromNci(plmnID plmnid.PlmnID, nci uint64) (*e2sm_kpm_v2_go.CellGlobalId, error) {
+
+	if err := plmnID.Validate(); err != nil {
+		return nil, err
+	}
+	bs, err := cellid.NciToBitString(nci)
+	if err != nil {
+		return nil, err
+	}
+	return CreateCellGlobalIDNRCGI(plmnID, bs.GetValue())
+}
+
+// CreateCellGlobalIDEUTRACGIFromEci creates an E-UTRA CGI out of a 28 bit ECI. Unlike CreateCellGlobalIDEUTRACGI,
+// it checks that the PLMN ID is BCD encoded.
+func CreateCellGlobalIDEUTRACGIFromEci(plmnID plmnid.PlmnID, eci uint32) (*e2sm_kpm_v2_go.CellGlobalId, error) {
+
+	if err := plmnID.Validate(); err != nil {
+		return nil, err
+	}
+	bs, err := cellid.EciToBitString(eci)
+	if err != nil {
+		return nil, err
+	}
+	return CreateCellGlobalIDEUTRACGI(plmnID, bs)
+}
+
 func CreateRicEventTriggerStyleItem(ricStyleType int32, ricStyleName string, ricFormatType int32) *e2sm_kpm_v2_go.RicEventTriggerStyleItem {
 
 	return &e2sm_kpm_v2_go.RicEventTriggerStyleItem{
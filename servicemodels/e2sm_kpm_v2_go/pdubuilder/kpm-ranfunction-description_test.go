@@ -7,6 +7,7 @@ package pdubuilder
 import (
 	"encoding/hex"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/encoder"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 	"gotest.tools/assert"
@@ -88,3 +89,19 @@ func TestE2SmKpmRanfunctionDescription(t *testing.T) {
 	assert.NilError(t, err)
 	t.Logf("E2SM-RANfunctionDescription PER - decoded\n%v", result)
 }
+
+func TestCreateCellGlobalIDFromNci(t *testing.T) {
+	plmnID, err := plmnid.FromMCCMNC("310", "410")
+	assert.NilError(t, err)
+
+	cellGlobalID, err := CreateCellGlobalIDNRCGIFromNci(plmnID, 0x12F0DEBCF)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x13, 0x00, 0x14}, cellGlobalID.GetNrCgi().GetPLmnIdentity().GetValue())
+	assert.DeepEqual(t, []byte{0x12, 0xF0, 0xDE, 0xBC, 0xF0}, cellGlobalID.GetNrCgi().GetNRcellIdentity().GetValue().GetValue())
+
+	_, err = CreateCellGlobalIDNRCGIFromNci(plmnid.PlmnID{0x13, 0x00, 0x1A}, 0x12F0DEBCF)
+	assert.ErrorContains(t, err, "not BCD encoded")
+	// The builders taking a []byte only check the length
+	_, err = CreateGlobalKpmnodeIDgNBID(&asn1.BitString{Value: []byte{0xd4, 0xbc, 0x08}, Len: 22}, []byte{0x13, 0x00, 0x1A})
+	assert.NilError(t, err)
+}
@@ -7,15 +7,20 @@ package servicemodel
 import (
 	"encoding/hex"
 	"fmt"
+	"strconv"
 
 	prototypes "github.com/gogo/protobuf/types"
 	types "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
 	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/encoder"
 	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"google.golang.org/protobuf/proto"
 )
 
+var log = logging.GetLogger("e2sm_kpm_v2_go", "servicemodel")
+
 type Kpm2ServiceModel string
 
 const smName = "e2sm_kpm"
@@ -197,6 +202,9 @@ func (sm Kpm2ServiceModel) ControlOutcomeProtoToASN1(protoBytes []byte) ([]byte,
 	return nil, fmt.Errorf("not implemented on KPM")
 }
 
+// OnSetup publishes the cells, report styles and measurements of the RAN function description in topo.
+// TODO: publish the cells per KPM node, with the event trigger styles and the format types of each report style,
+// once the topo API of onos-api can carry them: the KPM nodes of a CU+DU E2 agent are merged until then.
 func (sm Kpm2ServiceModel) OnSetup(request *types.OnSetupRequest) error {
 	protoBytes, err := sm.RanFuncDescriptionASN1toProto(request.RANFunctionDescription)
 	if err != nil {
@@ -215,17 +223,15 @@ func (sm Kpm2ServiceModel) OnSetup(request *types.OnSetupRequest) error {
 
 	for _, kpmNode := range ranFunctionDescription.RicKpmNodeList {
 		for _, cell := range kpmNode.CellMeasurementObjectList {
+			cellGlobalID, err := topoCellGlobalID(cell.GetCellGlobalId())
+			if err != nil {
+				// One malformed cell must not keep the others of the E2 node out of topo
+				log.Warnf("Skipping cell %q: %v", cell.GetCellObjectId().GetValue(), err)
+				continue
+			}
 			cellObject := &topoapi.E2Cell{
 				CellObjectID: cell.GetCellObjectId().GetValue(),
-				CellGlobalID: &topoapi.CellGlobalID{},
-			}
-			switch cellGlobalID := cell.CellGlobalId.GetCellGlobalId().(type) {
-			case *e2sm_kpm_v2_go.CellGlobalId_NrCgi:
-				cellObject.CellGlobalID.Value = fmt.Sprintf("%x", bitStringToUint64(cellGlobalID.NrCgi.NRcellIdentity.Value.Value, int(cellGlobalID.NrCgi.NRcellIdentity.Value.Len)))
-				cellObject.CellGlobalID.Type = topoapi.CellGlobalIDType_NRCGI
-			case *e2sm_kpm_v2_go.CellGlobalId_EUtraCgi:
-				cellObject.CellGlobalID.Value = fmt.Sprintf("%x", bitStringToUint64(cellGlobalID.EUtraCgi.EUtracellIdentity.Value.Value, int(cellGlobalID.EUtraCgi.EUtracellIdentity.Value.Len)))
-				cellObject.CellGlobalID.Type = topoapi.CellGlobalIDType_ECGI
+				CellGlobalID: cellGlobalID,
 			}
 
 			*e2Cells = append(*e2Cells, cellObject)
@@ -240,7 +246,7 @@ func (sm Kpm2ServiceModel) OnSetup(request *types.OnSetupRequest) error {
 		var measurements []*topoapi.KPMMeasurement
 		for _, meanInfoItem := range reportStyle.GetMeasInfoActionList().GetValue() {
 			measurements = append(measurements, &topoapi.KPMMeasurement{
-				ID:   meanInfoItem.GetMeasId().String(),
+				ID:   measurementID(meanInfoItem),
 				Name: meanInfoItem.GetMeasName().GetValue(),
 			})
 		}
@@ -257,13 +263,46 @@ func (sm Kpm2ServiceModel) OnSetup(request *types.OnSetupRequest) error {
 	return nil
 }
 
-func bitStringToUint64(bitString []byte, bitCount int) uint64 {
-	var result uint64
-	for i, b := range bitString {
-		result += uint64(b) << ((len(bitString) - i - 1) * 8)
+// measurementID returns the decimal MeasurementTypeID of a MeasurementInfo-Action-Item, or "" when it carries none
+func measurementID(measInfoActionItem *e2sm_kpm_v2_go.MeasurementInfoActionItem) string {
+	if measInfoActionItem.GetMeasId() == nil {
+		return ""
 	}
-	if bitCount%8 != 0 {
-		return result >> (8 - bitCount%8)
+	return strconv.FormatInt(int64(measInfoActionItem.GetMeasId().GetValue()), 10)
+}
+
+// topoCellGlobalID returns the NR CGI or E-UTRA CGI of a cell, see cellid.FormatNrcgi and cellid.FormatEcgi.
+// A PLMN ID which is not BCD encoded is left out, the value then being the NCI or ECI alone in hex.
+func topoCellGlobalID(cgi *e2sm_kpm_v2_go.CellGlobalId) (*topoapi.CellGlobalID, error) {
+	switch cellGlobalID := cgi.GetCellGlobalId().(type) {
+	case *e2sm_kpm_v2_go.CellGlobalId_NrCgi:
+		nci, err := cellid.NciFromBitString(cellGlobalID.NrCgi.GetNRcellIdentity().GetValue())
+		if err != nil {
+			return nil, err
+		}
+		nrcgi, err := cellid.FormatNrcgi(cellGlobalID.NrCgi.GetPLmnIdentity().GetValue(), nci)
+		if err != nil {
+			log.Warnf("Publishing the NCI of cell %x without its PLMN ID: %v", nci, err)
+			nrcgi = fmt.Sprintf("%x", nci)
+		}
+		return &topoapi.CellGlobalID{
+			Value: nrcgi,
+			Type:  topoapi.CellGlobalIDType_NRCGI,
+		}, nil
+	case *e2sm_kpm_v2_go.CellGlobalId_EUtraCgi:
+		eci, err := cellid.EciFromBitString(cellGlobalID.EUtraCgi.GetEUtracellIdentity().GetValue())
+		if err != nil {
+			return nil, err
+		}
+		ecgi, err := cellid.FormatEcgi(cellGlobalID.EUtraCgi.GetPLmnIdentity().GetValue(), eci)
+		if err != nil {
+			log.Warnf("Publishing the ECI of cell %x without its PLMN ID: %v", eci, err)
+			ecgi = fmt.Sprintf("%x", eci)
+		}
+		return &topoapi.CellGlobalID{
+			Value: ecgi,
+			Type:  topoapi.CellGlobalIDType_ECGI,
+		}, nil
 	}
-	return result
+	return nil, fmt.Errorf("unexpected CellGlobalId %v", cgi)
 }
@@ -6,6 +6,9 @@ package servicemodel
 
 import (
 	"encoding/hex"
+	prototypes "github.com/gogo/protobuf/types"
+	types "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/pdubuilder"
 	e2sm_kpm_v2_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
@@ -386,3 +389,74 @@ func TestServicemodel_ActionDefinitionASN1toProto(t *testing.T) {
 	mc := mcl.GetMatchingCond().GetValue()[0]
 	assert.Equal(t, int64(201), mc.GetTestCondInfo().GetTestValue().GetValueEnum())
 }
+
+func TestServicemodel_OnSetup(t *testing.T) {
+	// This message is taken from TestServicemodel_RanFuncDescriptionASN1toProto
+	ranFuncDescriptionAsn1 := []byte{0x74, 0x04, 0x6f, 0x6e, 0x66, 0x00, 0x00, 0x05, 0x6f, 0x69, 0x64, 0x31, 0x32, 0x33, 0x07, 0x00,
+		0x73, 0x6f, 0x6d, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x00,
+		0x15, 0x00, 0x00, 0x43, 0x00, 0x21, 0x22, 0x23, 0x00, 0xd4, 0xbc, 0x08, 0x80, 0x30, 0x39, 0x20,
+		0x1a, 0x85, 0x00, 0x00, 0x00, 0x00, 0x03, 0x4f, 0x4e, 0x46, 0x00, 0x21, 0x22, 0x23, 0x00, 0x00,
+		0x00, 0x20, 0x00, 0x00, 0x0b, 0x01, 0x00, 0x6f, 0x6e, 0x66, 0x00, 0x0f, 0x00, 0x0b, 0x01, 0x00,
+		0x6f, 0x6e, 0x66, 0x00, 0x0f, 0x00, 0x00, 0x41, 0xa0, 0x4f, 0x70, 0x65, 0x6e, 0x4e, 0x65, 0x74,
+		0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x00, 0x00, 0x17, 0x00, 0x2f, 0x00, 0x18}
+
+	serviceModels := map[string]*topoapi.ServiceModelInfo{
+		smOIDKpmV2: {
+			OID: smOIDKpmV2,
+		},
+	}
+	e2Cells := make([]*topoapi.E2Cell, 0)
+	err := kpmv2TestSm.OnSetup(&types.OnSetupRequest{
+		ServiceModels:          serviceModels,
+		E2Cells:                &e2Cells,
+		RANFunctionDescription: ranFuncDescriptionAsn1,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, "onf", serviceModels[smOIDKpmV2].Name)
+	assert.Equal(t, 1, len(e2Cells))
+	assert.Equal(t, "ONF", e2Cells[0].CellObjectID)
+	assert.Equal(t, topoapi.CellGlobalIDType_NRCGI, e2Cells[0].CellGlobalID.Type)
+	assert.Equal(t, "212223000000200", e2Cells[0].CellGlobalID.Value)
+
+	assert.Equal(t, 1, len(serviceModels[smOIDKpmV2].RanFunctions))
+	ranFunction := &topoapi.KPMRanFunction{}
+	err = prototypes.UnmarshalAny(serviceModels[smOIDKpmV2].RanFunctions[0], ranFunction)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(ranFunction.ReportStyles))
+	assert.Equal(t, "OpenNetworking", ranFunction.ReportStyles[0].Measurements[0].Name)
+	assert.Equal(t, "24", ranFunction.ReportStyles[0].Measurements[0].ID)
+}
+
+func TestTopoCellGlobalID(t *testing.T) {
+	nrCgi := func(plmnID []byte) *e2sm_kpm_v2_go.CellGlobalId {
+		return &e2sm_kpm_v2_go.CellGlobalId{
+			CellGlobalId: &e2sm_kpm_v2_go.CellGlobalId_NrCgi{
+				NrCgi: &e2sm_kpm_v2_go.Nrcgi{
+					PLmnIdentity: &e2sm_kpm_v2_go.PlmnIdentity{Value: plmnID},
+					NRcellIdentity: &e2sm_kpm_v2_go.NrcellIdentity{Value: &asn1.BitString{
+						Value: []byte{0x00, 0x00, 0x00, 0x20, 0x00},
+						Len:   36,
+					}},
+				},
+			},
+		}
+	}
+
+	cellGlobalID, err := topoCellGlobalID(nrCgi([]byte{0x21, 0x22, 0x23}))
+	assert.NilError(t, err)
+	assert.Equal(t, topoapi.CellGlobalIDType_NRCGI, cellGlobalID.Type)
+	assert.Equal(t, "212223000000200", cellGlobalID.Value)
+
+	// A PLMN ID which is not BCD encoded is left out
+	cellGlobalID, err = topoCellGlobalID(nrCgi([]byte{0xaa, 0xfd, 0xd4}))
+	assert.NilError(t, err)
+	assert.Equal(t, topoapi.CellGlobalIDType_NRCGI, cellGlobalID.Type)
+	assert.Equal(t, "200", cellGlobalID.Value)
+
+	_, err = topoCellGlobalID(nil)
+	assert.ErrorContains(t, err, "unexpected CellGlobalId")
+	_, err = topoCellGlobalID(&e2sm_kpm_v2_go.CellGlobalId{
+		CellGlobalId: &e2sm_kpm_v2_go.CellGlobalId_EUtraCgi{EUtraCgi: &e2sm_kpm_v2_go.Eutracgi{}},
+	})
+	assert.ErrorContains(t, err, "EUTRACellIdentity should be of length 28")
+}
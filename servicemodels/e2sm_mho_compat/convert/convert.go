@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package convert converts the top-level PDUs of E2SM-MHO between the v1 protos of the CGo e2sm_mho model
+// and the v2 protos of the pure-Go e2sm_mho_go model.
+//
+// Both versions share the same ASN.1 definitions except for the OPTIONAL fiveQI of E2SM-MHO-MeasurementReportItem,
+// which only exists in v2: converting a measurement report carrying a 5QI to v1 is an error rather than a silent loss.
+// Absent OPTIONAL INTEGERs are -1 in v1, as expected by the v1 CGo encoder.
+package convert
+
+import (
+	"fmt"
+
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/v1/e2sm-mho"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+)
+
+// EventTriggerDefinitionToV2 converts a v1 E2SmMhoEventTriggerDefinition
+func EventTriggerDefinitionToV2(etd *e2sm_mho.E2SmMhoEventTriggerDefinition) (*e2sm_mho_go.E2SmMhoEventTriggerDefinition, error) {
+	format1 := etd.GetEventDefinitionFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("EventTriggerDefinitionToV2(): unexpected E2SmMhoEventTriggerDefinition %v", etd)
+	}
+	return &e2sm_mho_go.E2SmMhoEventTriggerDefinition{
+		EventDefinitionFormats: &e2sm_mho_go.MhoEventTriggerDefinitionFormats{
+			E2SmMhoEventTriggerDefinition: &e2sm_mho_go.MhoEventTriggerDefinitionFormats_EventDefinitionFormat1{
+				EventDefinitionFormat1: &e2sm_mho_go.E2SmMhoEventTriggerDefinitionFormat1{
+					TriggerType:       e2sm_mho_go.MhoTriggerType(format1.GetTriggerType()),
+					ReportingPeriodMs: optionalToV2(format1.GetReportingPeriodMs()),
+				},
+			},
+		},
+	}, nil
+}
+
+// EventTriggerDefinitionToV1 converts a v2 E2SmMhoEventTriggerDefinition
+func EventTriggerDefinitionToV1(etd *e2sm_mho_go.E2SmMhoEventTriggerDefinition) (*e2sm_mho.E2SmMhoEventTriggerDefinition, error) {
+	format1 := etd.GetEventDefinitionFormats().GetEventDefinitionFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("EventTriggerDefinitionToV1(): unexpected E2SmMhoEventTriggerDefinition %v", etd)
+	}
+	return &e2sm_mho.E2SmMhoEventTriggerDefinition{
+		E2SmMhoEventTriggerDefinition: &e2sm_mho.E2SmMhoEventTriggerDefinition_EventDefinitionFormat1{
+			EventDefinitionFormat1: &e2sm_mho.E2SmMhoEventTriggerDefinitionFormat1{
+				TriggerType:       e2sm_mho.MhoTriggerType(format1.GetTriggerType()),
+				ReportingPeriodMs: optionalToV1(format1.ReportingPeriodMs),
+			},
+		},
+	}, nil
+}
+
+// IndicationHeaderToV2 converts a v1 E2SmMhoIndicationHeader
+func IndicationHeaderToV2(ih *e2sm_mho.E2SmMhoIndicationHeader) (*e2sm_mho_go.E2SmMhoIndicationHeader, error) {
+	format1 := ih.GetIndicationHeaderFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("IndicationHeaderToV2(): unexpected E2SmMhoIndicationHeader %v", ih)
+	}
+	cgi, err := CellGlobalIDToV2(format1.GetCgi())
+	if err != nil {
+		return nil, fmt.Errorf("IndicationHeaderToV2(): %v", err)
+	}
+	return &e2sm_mho_go.E2SmMhoIndicationHeader{
+		E2SmMhoIndicationHeader: &e2sm_mho_go.E2SmMhoIndicationHeader_IndicationHeaderFormat1{
+			IndicationHeaderFormat1: &e2sm_mho_go.E2SmMhoIndicationHeaderFormat1{
+				Cgi: cgi,
+			},
+		},
+	}, nil
+}
+
+// IndicationHeaderToV1 converts a v2 E2SmMhoIndicationHeader
+func IndicationHeaderToV1(ih *e2sm_mho_go.E2SmMhoIndicationHeader) (*e2sm_mho.E2SmMhoIndicationHeader, error) {
+	format1 := ih.GetIndicationHeaderFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("IndicationHeaderToV1(): unexpected E2SmMhoIndicationHeader %v", ih)
+	}
+	cgi, err := CellGlobalIDToV1(format1.GetCgi())
+	if err != nil {
+		return nil, fmt.Errorf("IndicationHeaderToV1(): %v", err)
+	}
+	return &e2sm_mho.E2SmMhoIndicationHeader{
+		E2SmMhoIndicationHeader: &e2sm_mho.E2SmMhoIndicationHeader_IndicationHeaderFormat1{
+			IndicationHeaderFormat1: &e2sm_mho.E2SmMhoIndicationHeaderFormat1{
+				Cgi: cgi,
+			},
+		},
+	}, nil
+}
+
+// IndicationMessageToV2 converts a v1 E2SmMhoIndicationMessage
+func IndicationMessageToV2(im *e2sm_mho.E2SmMhoIndicationMessage) (*e2sm_mho_go.E2SmMhoIndicationMessage, error) {
+	switch msg := im.GetE2SmMhoIndicationMessage().(type) {
+	case *e2sm_mho.E2SmMhoIndicationMessage_IndicationMessageFormat1:
+		measReport := make([]*e2sm_mho_go.E2SmMhoMeasurementReportItem, 0, len(msg.IndicationMessageFormat1.GetMeasReport()))
+		for i, item := range msg.IndicationMessageFormat1.GetMeasReport() {
+			cgi, err := CellGlobalIDToV2(item.GetCgi())
+			if err != nil {
+				return nil, fmt.Errorf("IndicationMessageToV2(): measReport item %d: %v", i, err)
+			}
+			measReport = append(measReport, &e2sm_mho_go.E2SmMhoMeasurementReportItem{
+				Cgi:  cgi,
+				Rsrp: &e2sm_mho_go.Rsrp{Value: item.GetRsrp().GetValue()},
+			})
+		}
+		return &e2sm_mho_go.E2SmMhoIndicationMessage{
+			E2SmMhoIndicationMessage: &e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat1{
+				IndicationMessageFormat1: &e2sm_mho_go.E2SmMhoIndicationMessageFormat1{
+					UeId:       ueIdentityToV2(msg.IndicationMessageFormat1.GetUeId()),
+					MeasReport: measReport,
+				},
+			},
+		}, nil
+	case *e2sm_mho.E2SmMhoIndicationMessage_IndicationMessageFormat2:
+		return &e2sm_mho_go.E2SmMhoIndicationMessage{
+			E2SmMhoIndicationMessage: &e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat2{
+				IndicationMessageFormat2: &e2sm_mho_go.E2SmMhoIndicationMessageFormat2{
+					UeId:      ueIdentityToV2(msg.IndicationMessageFormat2.GetUeId()),
+					RrcStatus: e2sm_mho_go.Rrcstatus(msg.IndicationMessageFormat2.GetRrcStatus()),
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("IndicationMessageToV2(): unexpected E2SmMhoIndicationMessage %v", im)
+	}
+}
+
+// IndicationMessageToV1 converts a v2 E2SmMhoIndicationMessage. Measurement report items carrying a 5QI cannot be converted.
+func IndicationMessageToV1(im *e2sm_mho_go.E2SmMhoIndicationMessage) (*e2sm_mho.E2SmMhoIndicationMessage, error) {
+	switch msg := im.GetE2SmMhoIndicationMessage().(type) {
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat1:
+		measReport := make([]*e2sm_mho.E2SmMhoMeasurementReportItem, 0, len(msg.IndicationMessageFormat1.GetMeasReport()))
+		for i, item := range msg.IndicationMessageFormat1.GetMeasReport() {
+			if item.FiveQi != nil {
+				return nil, fmt.Errorf("IndicationMessageToV1(): measReport item %d: fiveQI does not exist in E2SM-MHO v1", i)
+			}
+			cgi, err := CellGlobalIDToV1(item.GetCgi())
+			if err != nil {
+				return nil, fmt.Errorf("IndicationMessageToV1(): measReport item %d: %v", i, err)
+			}
+			measReport = append(measReport, &e2sm_mho.E2SmMhoMeasurementReportItem{
+				Cgi:  cgi,
+				Rsrp: &e2sm_mho.Rsrp{Value: item.GetRsrp().GetValue()},
+			})
+		}
+		return &e2sm_mho.E2SmMhoIndicationMessage{
+			E2SmMhoIndicationMessage: &e2sm_mho.E2SmMhoIndicationMessage_IndicationMessageFormat1{
+				IndicationMessageFormat1: &e2sm_mho.E2SmMhoIndicationMessageFormat1{
+					UeId:       ueIdentityToV1(msg.IndicationMessageFormat1.GetUeId()),
+					MeasReport: measReport,
+				},
+			},
+		}, nil
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat2:
+		return &e2sm_mho.E2SmMhoIndicationMessage{
+			E2SmMhoIndicationMessage: &e2sm_mho.E2SmMhoIndicationMessage_IndicationMessageFormat2{
+				IndicationMessageFormat2: &e2sm_mho.E2SmMhoIndicationMessageFormat2{
+					UeId:      ueIdentityToV1(msg.IndicationMessageFormat2.GetUeId()),
+					RrcStatus: e2sm_mho.Rrcstatus(msg.IndicationMessageFormat2.GetRrcStatus()),
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("IndicationMessageToV1(): unexpected E2SmMhoIndicationMessage %v", im)
+	}
+}
+
+// ControlHeaderToV2 converts a v1 E2SmMhoControlHeader
+func ControlHeaderToV2(ch *e2sm_mho.E2SmMhoControlHeader) (*e2sm_mho_go.E2SmMhoControlHeader, error) {
+	format1 := ch.GetControlHeaderFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("ControlHeaderToV2(): unexpected E2SmMhoControlHeader %v", ch)
+	}
+	header := &e2sm_mho_go.E2SmMhoControlHeaderFormat1{
+		RcCommand: e2sm_mho_go.MhoCommand(format1.GetRcCommand()),
+	}
+	if format1.GetRicControlMessagePriority() != nil {
+		header.RicControlMessagePriority = &e2sm_mho_go.RicControlMessagePriority{
+			Value: format1.GetRicControlMessagePriority().GetValue(),
+		}
+	}
+	return &e2sm_mho_go.E2SmMhoControlHeader{
+		E2SmMhoControlHeader: &e2sm_mho_go.E2SmMhoControlHeader_ControlHeaderFormat1{
+			ControlHeaderFormat1: header,
+		},
+	}, nil
+}
+
+// ControlHeaderToV1 converts a v2 E2SmMhoControlHeader
+func ControlHeaderToV1(ch *e2sm_mho_go.E2SmMhoControlHeader) (*e2sm_mho.E2SmMhoControlHeader, error) {
+	format1 := ch.GetControlHeaderFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("ControlHeaderToV1(): unexpected E2SmMhoControlHeader %v", ch)
+	}
+	header := &e2sm_mho.E2SmMhoControlHeaderFormat1{
+		RcCommand: e2sm_mho.MhoCommand(format1.GetRcCommand()),
+	}
+	if format1.GetRicControlMessagePriority() != nil {
+		header.RicControlMessagePriority = &e2sm_mho.RicControlMessagePriority{
+			Value: format1.GetRicControlMessagePriority().GetValue(),
+		}
+	}
+	return &e2sm_mho.E2SmMhoControlHeader{
+		E2SmMhoControlHeader: &e2sm_mho.E2SmMhoControlHeader_ControlHeaderFormat1{
+			ControlHeaderFormat1: header,
+		},
+	}, nil
+}
+
+// ControlMessageToV2 converts a v1 E2SmMhoControlMessage
+func ControlMessageToV2(cm *e2sm_mho.E2SmMhoControlMessage) (*e2sm_mho_go.E2SmMhoControlMessage, error) {
+	format1 := cm.GetControlMessageFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("ControlMessageToV2(): unexpected E2SmMhoControlMessage %v", cm)
+	}
+	servingCgi, err := CellGlobalIDToV2(format1.GetServingCgi())
+	if err != nil {
+		return nil, fmt.Errorf("ControlMessageToV2(): serving_cgi: %v", err)
+	}
+	targetCgi, err := CellGlobalIDToV2(format1.GetTargetCgi())
+	if err != nil {
+		return nil, fmt.Errorf("ControlMessageToV2(): target_cgi: %v", err)
+	}
+	return &e2sm_mho_go.E2SmMhoControlMessage{
+		E2SmMhoControlMessage: &e2sm_mho_go.E2SmMhoControlMessage_ControlMessageFormat1{
+			ControlMessageFormat1: &e2sm_mho_go.E2SmMhoControlMessageFormat1{
+				ServingCgi: servingCgi,
+				UedId:      ueIdentityToV2(format1.GetUedId()),
+				TargetCgi:  targetCgi,
+			},
+		},
+	}, nil
+}
+
+// ControlMessageToV1 converts a v2 E2SmMhoControlMessage
+func ControlMessageToV1(cm *e2sm_mho_go.E2SmMhoControlMessage) (*e2sm_mho.E2SmMhoControlMessage, error) {
+	format1 := cm.GetControlMessageFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("ControlMessageToV1(): unexpected E2SmMhoControlMessage %v", cm)
+	}
+	servingCgi, err := CellGlobalIDToV1(format1.GetServingCgi())
+	if err != nil {
+		return nil, fmt.Errorf("ControlMessageToV1(): serving_cgi: %v", err)
+	}
+	targetCgi, err := CellGlobalIDToV1(format1.GetTargetCgi())
+	if err != nil {
+		return nil, fmt.Errorf("ControlMessageToV1(): target_cgi: %v", err)
+	}
+	return &e2sm_mho.E2SmMhoControlMessage{
+		E2SmMhoControlMessage: &e2sm_mho.E2SmMhoControlMessage_ControlMessageFormat1{
+			ControlMessageFormat1: &e2sm_mho.E2SmMhoControlMessageFormat1{
+				ServingCgi: servingCgi,
+				UedId:      ueIdentityToV1(format1.GetUedId()),
+				TargetCgi:  targetCgi,
+			},
+		},
+	}, nil
+}
+
+// RanFunctionDescriptionToV2 converts a v1 E2SmMhoRanfunctionDescription
+func RanFunctionDescriptionToV2(rfd *e2sm_mho.E2SmMhoRanfunctionDescription) (*e2sm_mho_go.E2SmMhoRanfunctionDescription, error) {
+	if rfd.GetRanFunctionName() == nil {
+		return nil, fmt.Errorf("RanFunctionDescriptionToV2(): ranFunction_Name is missing")
+	}
+	item := &e2sm_mho_go.E2SmMhoRanfunctionDescription_E2SmMhoRanfunctionItem001{
+		RicEventTriggerStyleList: make([]*e2sm_mho_go.RicEventTriggerStyleList, 0, len(rfd.GetRicEventTriggerStyleList())),
+		RicReportStyleList:       make([]*e2sm_mho_go.RicReportStyleList, 0, len(rfd.GetRicReportStyleList())),
+	}
+	for _, style := range rfd.GetRicEventTriggerStyleList() {
+		item.RicEventTriggerStyleList = append(item.RicEventTriggerStyleList, &e2sm_mho_go.RicEventTriggerStyleList{
+			RicEventTriggerStyleType:  &e2sm_mho_go.RicStyleType{Value: style.GetRicEventTriggerStyleType().GetValue()},
+			RicEventTriggerStyleName:  &e2sm_mho_go.RicStyleName{Value: style.GetRicEventTriggerStyleName().GetValue()},
+			RicEventTriggerFormatType: &e2sm_mho_go.RicFormatType{Value: style.GetRicEventTriggerFormatType().GetValue()},
+		})
+	}
+	for _, style := range rfd.GetRicReportStyleList() {
+		item.RicReportStyleList = append(item.RicReportStyleList, &e2sm_mho_go.RicReportStyleList{
+			RicReportStyleType:             &e2sm_mho_go.RicStyleType{Value: style.GetRicReportStyleType().GetValue()},
+			RicReportStyleName:             &e2sm_mho_go.RicStyleName{Value: style.GetRicReportStyleName().GetValue()},
+			RicIndicationHeaderFormatType:  &e2sm_mho_go.RicFormatType{Value: style.GetRicIndicationHeaderFormatType().GetValue()},
+			RicIndicationMessageFormatType: &e2sm_mho_go.RicFormatType{Value: style.GetRicIndicationMessageFormatType().GetValue()},
+		})
+	}
+	name := rfd.GetRanFunctionName()
+	return &e2sm_mho_go.E2SmMhoRanfunctionDescription{
+		RanFunctionName: &e2sm_mho_go.RanfunctionName{
+			RanFunctionShortName:   name.GetRanFunctionShortName(),
+			RanFunctionE2SmOid:     name.GetRanFunctionE2SmOid(),
+			RanFunctionDescription: name.GetRanFunctionDescription(),
+			RanFunctionInstance:    optionalToV2(name.GetRanFunctionInstance()),
+		},
+		E2SmMhoRanfunctionItem: item,
+	}, nil
+}
+
+// RanFunctionDescriptionToV1 converts a v2 E2SmMhoRanfunctionDescription
+func RanFunctionDescriptionToV1(rfd *e2sm_mho_go.E2SmMhoRanfunctionDescription) (*e2sm_mho.E2SmMhoRanfunctionDescription, error) {
+	if rfd.GetRanFunctionName() == nil {
+		return nil, fmt.Errorf("RanFunctionDescriptionToV1(): ranFunction_Name is missing")
+	}
+	item := rfd.GetE2SmMhoRanfunctionItem()
+	name := rfd.GetRanFunctionName()
+	result := &e2sm_mho.E2SmMhoRanfunctionDescription{
+		RanFunctionName: &e2sm_mho.RanfunctionName{
+			RanFunctionShortName:   name.GetRanFunctionShortName(),
+			RanFunctionE2SmOid:     name.GetRanFunctionE2SmOid(),
+			RanFunctionDescription: name.GetRanFunctionDescription(),
+			RanFunctionInstance:    optionalToV1(name.RanFunctionInstance),
+		},
+		RicEventTriggerStyleList: make([]*e2sm_mho.RicEventTriggerStyleList, 0, len(item.GetRicEventTriggerStyleList())),
+		RicReportStyleList:       make([]*e2sm_mho.RicReportStyleList, 0, len(item.GetRicReportStyleList())),
+	}
+	for _, style := range item.GetRicEventTriggerStyleList() {
+		result.RicEventTriggerStyleList = append(result.RicEventTriggerStyleList, &e2sm_mho.RicEventTriggerStyleList{
+			RicEventTriggerStyleType:  &e2sm_mho.RicStyleType{Value: style.GetRicEventTriggerStyleType().GetValue()},
+			RicEventTriggerStyleName:  &e2sm_mho.RicStyleName{Value: style.GetRicEventTriggerStyleName().GetValue()},
+			RicEventTriggerFormatType: &e2sm_mho.RicFormatType{Value: style.GetRicEventTriggerFormatType().GetValue()},
+		})
+	}
+	for _, style := range item.GetRicReportStyleList() {
+		result.RicReportStyleList = append(result.RicReportStyleList, &e2sm_mho.RicReportStyleList{
+			RicReportStyleType:             &e2sm_mho.RicStyleType{Value: style.GetRicReportStyleType().GetValue()},
+			RicReportStyleName:             &e2sm_mho.RicStyleName{Value: style.GetRicReportStyleName().GetValue()},
+			RicIndicationHeaderFormatType:  &e2sm_mho.RicFormatType{Value: style.GetRicIndicationHeaderFormatType().GetValue()},
+			RicIndicationMessageFormatType: &e2sm_mho.RicFormatType{Value: style.GetRicIndicationMessageFormatType().GetValue()},
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/pdubuilder"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/v1/e2sm-mho"
+	mhobuilder "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/pdubuilder"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+var plmnID = []byte{0x12, 0xf4, 0x10}
+
+func TestBitString(t *testing.T) {
+	bs, err := BitStringToV2(&e2sm_mho.BitString{Value: 0xbcd4abef, Len: 36})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0xef, 0xab, 0xd4, 0xbc, 0x00}, bs.GetValue())
+	assert.Equal(t, uint32(36), bs.GetLen())
+
+	// Bits beyond the length are cleared
+	bs, err = BitStringToV2(&e2sm_mho.BitString{Value: 0xf9bcd4ab, Len: 28})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0xab, 0xd4, 0xbc, 0xf0}, bs.GetValue())
+
+	v1, err := BitStringToV1(&asn1.BitString{Value: []byte{0xef, 0xab, 0xd4, 0xbc, 0x00}, Len: 36})
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(0xbcd4abef), v1.GetValue())
+	assert.Equal(t, uint32(36), v1.GetLen())
+
+	_, err = BitStringToV1(&asn1.BitString{Value: []byte{0xef}, Len: 36})
+	assert.ErrorContains(t, err, "expected 5 octets to contain 36 bits, got 1")
+	_, err = BitStringToV2(&e2sm_mho.BitString{Len: 65})
+	assert.ErrorContains(t, err, "longer than 64 bits")
+}
+
+func TestRoundTrip(t *testing.T) {
+	nrCgi, err := pdubuilder.CreateCellGlobalIDNrCgi(plmnID, &e2sm_mho.BitString{Value: 0xbcd4abef, Len: 36})
+	assert.NilError(t, err)
+	eutraCgi, err := pdubuilder.CreateCellGlobalIDEUTRACGI(plmnID, &e2sm_mho.BitString{Value: 0xbcd4ab, Len: 28})
+	assert.NilError(t, err)
+
+	etd, err := pdubuilder.CreateE2SmMhoEventTriggerDefinition(e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS, -1)
+	assert.NilError(t, err)
+	etdV2, err := EventTriggerDefinitionToV2(etd)
+	assert.NilError(t, err)
+	assert.Assert(t, etdV2.GetEventDefinitionFormats().GetEventDefinitionFormat1().ReportingPeriodMs == nil)
+	assert.NilError(t, etdV2.Validate())
+	etdV1, err := EventTriggerDefinitionToV1(etdV2)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(etd, etdV1), "%v", etdV1)
+
+	ih, err := pdubuilder.CreateE2SmMhoIndicationHeader(eutraCgi)
+	assert.NilError(t, err)
+	ihV2, err := IndicationHeaderToV2(ih)
+	assert.NilError(t, err)
+	assert.NilError(t, ihV2.Validate())
+	ihV1, err := IndicationHeaderToV1(ihV2)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(ih, ihV1), "%v", ihV1)
+
+	im, err := pdubuilder.CreateE2SmMhoIndicationMsgFormat1(&e2sm_mho.UeIdentity{Value: "1234"}, &e2sm_mho.Rsrp{Value: -156})
+	assert.NilError(t, err)
+	// The builder sets bits beyond the length, which do not survive the conversion
+	im.GetIndicationMessageFormat1().GetMeasReport()[0].GetCgi().GetEUtraCgi().GetEUtracellIdentity().GetValue().Value = 0xbcd4ab
+	imV2, err := IndicationMessageToV2(im)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte("1234"), imV2.GetIndicationMessageFormat1().GetUeId().GetValue())
+	assert.NilError(t, imV2.Validate())
+	imV1, err := IndicationMessageToV1(imV2)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(im, imV1), "%v", imV1)
+
+	ch, err := pdubuilder.CreateE2SmMhoControlHeader(10)
+	assert.NilError(t, err)
+	chV2, err := ControlHeaderToV2(ch)
+	assert.NilError(t, err)
+	assert.NilError(t, chV2.Validate())
+	chV1, err := ControlHeaderToV1(chV2)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(ch, chV1), "%v", chV1)
+
+	cm, err := pdubuilder.CreateE2SmMhoControlMessage(nrCgi, &e2sm_mho.UeIdentity{Value: "1234"}, eutraCgi)
+	assert.NilError(t, err)
+	cmV2, err := ControlMessageToV2(cm)
+	assert.NilError(t, err)
+	assert.NilError(t, cmV2.Validate())
+	cmV1, err := ControlMessageToV1(cmV2)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(cm, cmV1), "%v", cmV1)
+
+	rfd, err := pdubuilder.CreateE2SmMhoRanfunctionDescriptionMsg("ONF-MHO", "1.3.6.1.4.1.53148.1.1.2.101", "MHO",
+		[]*e2sm_mho.RicEventTriggerStyleList{pdubuilder.CreateRicEventTriggerStyleItem(1, "Periodic report", 1)},
+		[]*e2sm_mho.RicReportStyleList{pdubuilder.CreateRicReportStyleItem(1, "Measurement report", 1, 1)})
+	assert.NilError(t, err)
+	rfdV2, err := RanFunctionDescriptionToV2(rfd)
+	assert.NilError(t, err)
+	assert.Assert(t, rfdV2.GetRanFunctionName().RanFunctionInstance == nil)
+	assert.Equal(t, 1, len(rfdV2.GetE2SmMhoRanfunctionItem().GetRicReportStyleList()))
+	assert.NilError(t, rfdV2.Validate())
+	rfdV1, err := RanFunctionDescriptionToV1(rfdV2)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(rfd, rfdV1), "%v", rfdV1)
+}
+
+func TestIndicationMessageToV1_FiveQi(t *testing.T) {
+	cgi, err := mhobuilder.CreateCellGlobalIDNrCGIFromNci(plmnID, 0xbcd4abef)
+	assert.NilError(t, err)
+	item, err := mhobuilder.CreateMeasurementRecordItem(cgi, &e2sm_mho_go.Rsrp{Value: -100})
+	assert.NilError(t, err)
+	item.FiveQi = &e2sm_mho_go.FiveQi{Value: 9}
+	im, err := mhobuilder.CreateE2SmMhoIndicationMsgFormat1(&e2sm_mho_go.UeIdentity{Value: []byte("1234")},
+		[]*e2sm_mho_go.E2SmMhoMeasurementReportItem{item})
+	assert.NilError(t, err)
+
+	_, err = IndicationMessageToV1(im)
+	assert.ErrorContains(t, err, "measReport item 0: fiveQI does not exist in E2SM-MHO v1")
+
+	_, err = ControlMessageToV1(&e2sm_mho_go.E2SmMhoControlMessage{})
+	assert.ErrorContains(t, err, "unexpected E2SmMhoControlMessage")
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package convert
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/v1/e2sm-mho"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+)
+
+// BitStringToV2 converts a v1 BitString, whose value holds the BIT STRING octets in little-endian order
+// (the layout the v1 CGo encoder expects), to the octets of an asn1 BitString.
+// Bits beyond the length are not encoded by the v1 CGo encoder, so they are cleared.
+func BitStringToV2(bs *e2sm_mho.BitString) (*asn1.BitString, error) {
+	if bs == nil {
+		return nil, fmt.Errorf("BitString is nil")
+	}
+	if bs.GetLen() > 64 {
+		return nil, fmt.Errorf("BitString length %d is longer than 64 bits", bs.GetLen())
+	}
+	octets := make([]byte, 8)
+	binary.LittleEndian.PutUint64(octets, bs.GetValue())
+	octets = octets[:(bs.GetLen()+7)/8]
+	if unused := uint(len(octets))*8 - uint(bs.GetLen()); unused > 0 {
+		octets[len(octets)-1] &^= 1<<unused - 1
+	}
+	return &asn1.BitString{
+		Value: octets,
+		Len:   bs.GetLen(),
+	}, nil
+}
+
+// BitStringToV1 converts an asn1 BitString of at most 64 bits to a v1 BitString
+func BitStringToV1(bs *asn1.BitString) (*e2sm_mho.BitString, error) {
+	if bs == nil {
+		return nil, fmt.Errorf("BitString is nil")
+	}
+	if bs.GetLen() > 64 {
+		return nil, fmt.Errorf("BitString length %d is longer than 64 bits", bs.GetLen())
+	}
+	if len(bs.GetValue()) != int(bs.GetLen()+7)/8 {
+		return nil, fmt.Errorf("expected %d octets to contain %d bits, got %d", (bs.GetLen()+7)/8, bs.GetLen(), len(bs.GetValue()))
+	}
+	octets := make([]byte, 8)
+	copy(octets, bs.GetValue())
+	return &e2sm_mho.BitString{
+		Value: binary.LittleEndian.Uint64(octets),
+		Len:   bs.GetLen(),
+	}, nil
+}
+
+// CellGlobalIDToV2 converts a v1 CellGlobalId
+func CellGlobalIDToV2(cgi *e2sm_mho.CellGlobalId) (*e2sm_mho_go.CellGlobalId, error) {
+	switch c := cgi.GetCellGlobalId().(type) {
+	case *e2sm_mho.CellGlobalId_NrCgi:
+		nci, err := BitStringToV2(c.NrCgi.GetNRcellIdentity().GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("NRCellIdentity: %v", err)
+		}
+		return &e2sm_mho_go.CellGlobalId{
+			CellGlobalId: &e2sm_mho_go.CellGlobalId_NrCgi{
+				NrCgi: &e2sm_mho_go.Nrcgi{
+					PLmnIdentity:   &e2sm_mho_go.PlmnIdentity{Value: c.NrCgi.GetPLmnIdentity().GetValue()},
+					NRcellIdentity: &e2sm_mho_go.NrcellIdentity{Value: nci},
+				},
+			},
+		}, nil
+	case *e2sm_mho.CellGlobalId_EUtraCgi:
+		eci, err := BitStringToV2(c.EUtraCgi.GetEUtracellIdentity().GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("EUTRACellIdentity: %v", err)
+		}
+		return &e2sm_mho_go.CellGlobalId{
+			CellGlobalId: &e2sm_mho_go.CellGlobalId_EUtraCgi{
+				EUtraCgi: &e2sm_mho_go.Eutracgi{
+					PLmnIdentity:      &e2sm_mho_go.PlmnIdentity{Value: c.EUtraCgi.GetPLmnIdentity().GetValue()},
+					EUtracellIdentity: &e2sm_mho_go.EutracellIdentity{Value: eci},
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected CellGlobalId %v", cgi)
+	}
+}
+
+// CellGlobalIDToV1 converts a v2 CellGlobalId
+func CellGlobalIDToV1(cgi *e2sm_mho_go.CellGlobalId) (*e2sm_mho.CellGlobalId, error) {
+	switch c := cgi.GetCellGlobalId().(type) {
+	case *e2sm_mho_go.CellGlobalId_NrCgi:
+		nci, err := BitStringToV1(c.NrCgi.GetNRcellIdentity().GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("NRCellIdentity: %v", err)
+		}
+		return &e2sm_mho.CellGlobalId{
+			CellGlobalId: &e2sm_mho.CellGlobalId_NrCgi{
+				NrCgi: &e2sm_mho.Nrcgi{
+					PLmnIdentity:   &e2sm_mho.PlmnIdentity{Value: c.NrCgi.GetPLmnIdentity().GetValue()},
+					NRcellIdentity: &e2sm_mho.NrcellIdentity{Value: nci},
+				},
+			},
+		}, nil
+	case *e2sm_mho_go.CellGlobalId_EUtraCgi:
+		eci, err := BitStringToV1(c.EUtraCgi.GetEUtracellIdentity().GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("EUTRACellIdentity: %v", err)
+		}
+		return &e2sm_mho.CellGlobalId{
+			CellGlobalId: &e2sm_mho.CellGlobalId_EUtraCgi{
+				EUtraCgi: &e2sm_mho.Eutracgi{
+					PLmnIdentity:      &e2sm_mho.PlmnIdentity{Value: c.EUtraCgi.GetPLmnIdentity().GetValue()},
+					EUtracellIdentity: &e2sm_mho.EutracellIdentity{Value: eci},
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected CellGlobalId %v", cgi)
+	}
+}
+
+func ueIdentityToV2(ueID *e2sm_mho.UeIdentity) *e2sm_mho_go.UeIdentity {
+	return &e2sm_mho_go.UeIdentity{Value: []byte(ueID.GetValue())}
+}
+
+func ueIdentityToV1(ueID *e2sm_mho_go.UeIdentity) *e2sm_mho.UeIdentity {
+	return &e2sm_mho.UeIdentity{Value: string(ueID.GetValue())}
+}
+
+// optionalToV2 maps the v1 convention of -1 standing for an absent OPTIONAL INTEGER to a v2 optional field
+func optionalToV2(value int32) *int32 {
+	if value == -1 {
+		return nil
+	}
+	return &value
+}
+
+func optionalToV1(value *int32) int32 {
+	if value == nil {
+		return -1
+	}
+	return *value
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encoder is a pure-Go APER encoder and decoder for the v1 protos of E2SM-MHO, producing the same bytes
+// as the CGo mhoctypes package so that E2SM-MHO v1 nodes can be served without CGo.
+// Messages are converted to v2 and encoded with the e2sm_mho_go encoder, except for indication messages:
+// the v2 E2SM-MHO-MeasurementReportItem carries an extra OPTIONAL fiveQI, so they are encoded with the v1 layout below.
+package encoder
+
+import (
+	"fmt"
+	"reflect"
+
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/v1/e2sm-mho"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_compat/convert"
+	mhoencoder "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/encoder"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/pkg/asn1/aper"
+)
+
+// indicationMessage mirrors E2SmMhoIndicationMessage with the v1 E2SM-MHO-MeasurementReportItem
+type indicationMessage struct {
+	E2SmMhoIndicationMessage isIndicationMessage `protobuf_oneof:"e2_sm_mho_indication_message"`
+}
+
+type isIndicationMessage interface {
+	isIndicationMessage()
+}
+
+type indicationMessageFormat1 struct {
+	IndicationMessageFormat1 *indicationMessageFormat1V1 `aper:"choiceIdx:1,valueExt"`
+}
+
+type indicationMessageFormat2 struct {
+	IndicationMessageFormat2 *e2sm_mho_go.E2SmMhoIndicationMessageFormat2 `aper:"choiceIdx:2,valueExt"`
+}
+
+func (*indicationMessageFormat1) isIndicationMessage() {}
+
+func (*indicationMessageFormat2) isIndicationMessage() {}
+
+type indicationMessageFormat1V1 struct {
+	UeID       *e2sm_mho_go.UeIdentity
+	MeasReport []*measurementReportItemV1 `aper:"sizeLB:1,sizeUB:100,valueExt"`
+}
+
+type measurementReportItemV1 struct {
+	Cgi  *e2sm_mho_go.CellGlobalId `aper:"choiceExt"`
+	Rsrp *e2sm_mho_go.Rsrp
+}
+
+var v1Choicemap = map[string]map[int]reflect.Type{
+	"e2_sm_mho_indication_message": {
+		1: reflect.TypeOf(indicationMessageFormat1{}),
+		2: reflect.TypeOf(indicationMessageFormat2{}),
+	},
+}
+
+func init() {
+	for name, choices := range e2sm_mho_go.MhoChoicemap {
+		if _, ok := v1Choicemap[name]; !ok {
+			v1Choicemap[name] = choices
+		}
+	}
+}
+
+// PerEncodeE2SmMhoEventTriggerDefinition encodes a v1 E2SmMhoEventTriggerDefinition
+func PerEncodeE2SmMhoEventTriggerDefinition(etd *e2sm_mho.E2SmMhoEventTriggerDefinition) ([]byte, error) {
+	v2, err := convert.EventTriggerDefinitionToV2(etd)
+	if err != nil {
+		return nil, err
+	}
+	return mhoencoder.PerEncodeE2SmMhoEventTriggerDefinition(v2)
+}
+
+// PerDecodeE2SmMhoEventTriggerDefinition decodes a v1 E2SmMhoEventTriggerDefinition
+func PerDecodeE2SmMhoEventTriggerDefinition(per []byte) (*e2sm_mho.E2SmMhoEventTriggerDefinition, error) {
+	v2, err := mhoencoder.PerDecodeE2SmMhoEventTriggerDefinition(per)
+	if err != nil {
+		return nil, err
+	}
+	return convert.EventTriggerDefinitionToV1(v2)
+}
+
+// PerEncodeE2SmMhoIndicationHeader encodes a v1 E2SmMhoIndicationHeader
+func PerEncodeE2SmMhoIndicationHeader(ih *e2sm_mho.E2SmMhoIndicationHeader) ([]byte, error) {
+	v2, err := convert.IndicationHeaderToV2(ih)
+	if err != nil {
+		return nil, err
+	}
+	return mhoencoder.PerEncodeE2SmMhoIndicationHeader(v2)
+}
+
+// PerDecodeE2SmMhoIndicationHeader decodes a v1 E2SmMhoIndicationHeader
+func PerDecodeE2SmMhoIndicationHeader(per []byte) (*e2sm_mho.E2SmMhoIndicationHeader, error) {
+	v2, err := mhoencoder.PerDecodeE2SmMhoIndicationHeader(per)
+	if err != nil {
+		return nil, err
+	}
+	return convert.IndicationHeaderToV1(v2)
+}
+
+// PerEncodeE2SmMhoIndicationMessage encodes a v1 E2SmMhoIndicationMessage
+func PerEncodeE2SmMhoIndicationMessage(im *e2sm_mho.E2SmMhoIndicationMessage) ([]byte, error) {
+	v2, err := convert.IndicationMessageToV2(im)
+	if err != nil {
+		return nil, err
+	}
+	if err := v2.Validate(); err != nil {
+		return nil, fmt.Errorf("PerEncodeE2SmMhoIndicationMessage(): %v", err)
+	}
+	msg := &indicationMessage{}
+	switch m := v2.GetE2SmMhoIndicationMessage().(type) {
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat1:
+		format1 := &indicationMessageFormat1V1{
+			UeID:       m.IndicationMessageFormat1.GetUeId(),
+			MeasReport: make([]*measurementReportItemV1, 0, len(m.IndicationMessageFormat1.GetMeasReport())),
+		}
+		for _, item := range m.IndicationMessageFormat1.GetMeasReport() {
+			format1.MeasReport = append(format1.MeasReport, &measurementReportItemV1{
+				Cgi:  item.GetCgi(),
+				Rsrp: item.GetRsrp(),
+			})
+		}
+		msg.E2SmMhoIndicationMessage = &indicationMessageFormat1{IndicationMessageFormat1: format1}
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat2:
+		msg.E2SmMhoIndicationMessage = &indicationMessageFormat2{IndicationMessageFormat2: m.IndicationMessageFormat2}
+	}
+	return aper.MarshalWithParams(msg, "choiceExt", v1Choicemap, nil)
+}
+
+// PerDecodeE2SmMhoIndicationMessage decodes a v1 E2SmMhoIndicationMessage
+func PerDecodeE2SmMhoIndicationMessage(per []byte) (*e2sm_mho.E2SmMhoIndicationMessage, error) {
+	msg := &indicationMessage{}
+	if err := aper.UnmarshalWithParams(per, msg, "choiceExt", v1Choicemap, nil); err != nil {
+		return nil, err
+	}
+	v2 := &e2sm_mho_go.E2SmMhoIndicationMessage{}
+	switch m := msg.E2SmMhoIndicationMessage.(type) {
+	case *indicationMessageFormat1:
+		format1 := &e2sm_mho_go.E2SmMhoIndicationMessageFormat1{
+			UeId:       m.IndicationMessageFormat1.UeID,
+			MeasReport: make([]*e2sm_mho_go.E2SmMhoMeasurementReportItem, 0, len(m.IndicationMessageFormat1.MeasReport)),
+		}
+		for _, item := range m.IndicationMessageFormat1.MeasReport {
+			format1.MeasReport = append(format1.MeasReport, &e2sm_mho_go.E2SmMhoMeasurementReportItem{
+				Cgi:  item.Cgi,
+				Rsrp: item.Rsrp,
+			})
+		}
+		v2.E2SmMhoIndicationMessage = &e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat1{IndicationMessageFormat1: format1}
+	case *indicationMessageFormat2:
+		v2.E2SmMhoIndicationMessage = &e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat2{IndicationMessageFormat2: m.IndicationMessageFormat2}
+	default:
+		return nil, fmt.Errorf("PerDecodeE2SmMhoIndicationMessage(): unexpected E2SmMhoIndicationMessage %v", msg)
+	}
+	return convert.IndicationMessageToV1(v2)
+}
+
+// PerEncodeE2SmMhoControlHeader encodes a v1 E2SmMhoControlHeader
+func PerEncodeE2SmMhoControlHeader(ch *e2sm_mho.E2SmMhoControlHeader) ([]byte, error) {
+	v2, err := convert.ControlHeaderToV2(ch)
+	if err != nil {
+		return nil, err
+	}
+	return mhoencoder.PerEncodeE2SmMhoControlHeader(v2)
+}
+
+// PerDecodeE2SmMhoControlHeader decodes a v1 E2SmMhoControlHeader
+func PerDecodeE2SmMhoControlHeader(per []byte) (*e2sm_mho.E2SmMhoControlHeader, error) {
+	v2, err := mhoencoder.PerDecodeE2SmMhoControlHeader(per)
+	if err != nil {
+		return nil, err
+	}
+	return convert.ControlHeaderToV1(v2)
+}
+
+// PerEncodeE2SmMhoControlMessage encodes a v1 E2SmMhoControlMessage
+func PerEncodeE2SmMhoControlMessage(cm *e2sm_mho.E2SmMhoControlMessage) ([]byte, error) {
+	v2, err := convert.ControlMessageToV2(cm)
+	if err != nil {
+		return nil, err
+	}
+	return mhoencoder.PerEncodeE2SmMhoControlMessage(v2)
+}
+
+// PerDecodeE2SmMhoControlMessage decodes a v1 E2SmMhoControlMessage
+func PerDecodeE2SmMhoControlMessage(per []byte) (*e2sm_mho.E2SmMhoControlMessage, error) {
+	v2, err := mhoencoder.PerDecodeE2SmMhoControlMessage(per)
+	if err != nil {
+		return nil, err
+	}
+	return convert.ControlMessageToV1(v2)
+}
+
+// PerEncodeE2SmMhoRanfunctionDescription encodes a v1 E2SmMhoRanfunctionDescription
+func PerEncodeE2SmMhoRanfunctionDescription(rfd *e2sm_mho.E2SmMhoRanfunctionDescription) ([]byte, error) {
+	v2, err := convert.RanFunctionDescriptionToV2(rfd)
+	if err != nil {
+		return nil, err
+	}
+	return mhoencoder.PerEncodeE2SmMhoRanFunctionDescription(v2)
+}
+
+// PerDecodeE2SmMhoRanfunctionDescription decodes a v1 E2SmMhoRanfunctionDescription
+func PerDecodeE2SmMhoRanfunctionDescription(per []byte) (*e2sm_mho.E2SmMhoRanfunctionDescription, error) {
+	v2, err := mhoencoder.PerDecodeE2SmMhoRanFunctionDescription(per)
+	if err != nil {
+		return nil, err
+	}
+	return convert.RanFunctionDescriptionToV1(v2)
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package encoder
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/pdubuilder"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho/v1/e2sm-mho"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_compat/convert"
+	mhoencoder "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/encoder"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+// Reference bytes obtained from MHO SM v1 with CGo
+var (
+	refEventTriggerDefinition = []byte{0x10, 0x02, 0x03, 0xe8}
+	refIndicationHeader       = []byte{0x00, 0x12, 0xf4, 0x10, 0xef, 0xab, 0xd4, 0xbc, 0x00}
+	refIndicationMessageF1    = []byte{0x00, 0x04, 0x31, 0x32, 0x33, 0x34, 0x00, 0x40, 0x12, 0xf4, 0x10, 0xab, 0xd4, 0xbc, 0x02, 0xff,
+		0x64}
+	refIndicationMessageF2 = []byte{0x40, 0x04, 0x31, 0x32, 0x33, 0x34, 0x20}
+	refControlHeader       = []byte{0x20, 0x0a}
+	refControlMessage      = []byte{0x00, 0x12, 0xf4, 0x10, 0xef, 0xab, 0xd4, 0xbc, 0x00, 0x04, 0x31, 0x32, 0x33, 0x34, 0x40, 0x12,
+		0xf4, 0x10, 0xab, 0xd4, 0xbc, 0x00}
+	refRanFunctionDescription = []byte{0x00, 0x60, 0x4f, 0x4e, 0x46, 0x2d, 0x4d, 0x48, 0x4f, 0x00, 0x00, 0x1a, 0x31, 0x2e, 0x33, 0x2e,
+		0x36, 0x2e, 0x31, 0x2e, 0x34, 0x2e, 0x31, 0x2e, 0x35, 0x33, 0x31, 0x34, 0x38, 0x2e, 0x31, 0x2e,
+		0x31, 0x2e, 0x32, 0x2e, 0x31, 0x30, 0x31, 0x01, 0x00, 0x4d, 0x48, 0x4f, 0x60, 0x00, 0x83, 0x80,
+		0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x69, 0x63, 0x20, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x00,
+		0x01, 0x00, 0x04, 0x22, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x20,
+		0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x00, 0x01, 0x00, 0x01}
+)
+
+var plmnID = []byte{0x12, 0xf4, 0x10}
+
+func createNrCgi(t *testing.T) *e2sm_mho.CellGlobalId {
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCgi(plmnID, &e2sm_mho.BitString{Value: 0x9bcd4abef, Len: 36})
+	assert.NilError(t, err)
+	return cgi
+}
+
+func createEutraCgi(t *testing.T) *e2sm_mho.CellGlobalId {
+	cgi, err := pdubuilder.CreateCellGlobalIDEUTRACGI(plmnID, &e2sm_mho.BitString{Value: 0x9bcd4ab, Len: 28})
+	assert.NilError(t, err)
+	return cgi
+}
+
+func TestEventTriggerDefinition(t *testing.T) {
+	etd, err := pdubuilder.CreateE2SmMhoEventTriggerDefinitionPeriodic(1000)
+	assert.NilError(t, err)
+	per, err := PerEncodeE2SmMhoEventTriggerDefinition(etd)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refEventTriggerDefinition, per)
+
+	result, err := PerDecodeE2SmMhoEventTriggerDefinition(per)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(etd, result), "%v", result)
+}
+
+func TestIndicationHeader(t *testing.T) {
+	ih, err := pdubuilder.CreateE2SmMhoIndicationHeader(createNrCgi(t))
+	assert.NilError(t, err)
+	per, err := PerEncodeE2SmMhoIndicationHeader(ih)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refIndicationHeader, per)
+
+	result, err := PerDecodeE2SmMhoIndicationHeader(per)
+	assert.NilError(t, err)
+	// Like the v1 CGo encoder, only the high bits of the last octet of the little-endian value are encoded
+	ih.GetIndicationHeaderFormat1().GetCgi().GetNrCgi().GetNRcellIdentity().GetValue().Value = 0xbcd4abef
+	assert.Assert(t, proto.Equal(ih, result), "%v", result)
+}
+
+func TestIndicationMessage(t *testing.T) {
+	im, err := pdubuilder.CreateE2SmMhoIndicationMsgFormat1(&e2sm_mho.UeIdentity{Value: "1234"}, &e2sm_mho.Rsrp{Value: -156})
+	assert.NilError(t, err)
+	per, err := PerEncodeE2SmMhoIndicationMessage(im)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refIndicationMessageF1, per)
+
+	// The v2 encoding differs by the presence bit of fiveQI
+	v2, err := convert.IndicationMessageToV2(im)
+	assert.NilError(t, err)
+	v2Per, err := mhoencoder.PerEncodeE2SmMhoIndicationMessage(v2)
+	assert.NilError(t, err)
+	assert.Assert(t, string(v2Per) != string(per))
+
+	result, err := PerDecodeE2SmMhoIndicationMessage(per)
+	assert.NilError(t, err)
+	im.GetIndicationMessageFormat1().GetMeasReport()[0].GetCgi().GetEUtraCgi().GetEUtracellIdentity().GetValue().Value = 0xbcd4ab
+	assert.Assert(t, proto.Equal(im, result), "%v", result)
+
+	im, err = pdubuilder.CreateE2SmMhoIndicationMsgFormat2(&e2sm_mho.UeIdentity{Value: "1234"}, e2sm_mho.Rrcstatus_RRCSTATUS_INACTIVE)
+	assert.NilError(t, err)
+	per, err = PerEncodeE2SmMhoIndicationMessage(im)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refIndicationMessageF2, per)
+
+	result, err = PerDecodeE2SmMhoIndicationMessage(per)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(im, result), "%v", result)
+
+	_, err = PerEncodeE2SmMhoIndicationMessage(&e2sm_mho.E2SmMhoIndicationMessage{})
+	assert.ErrorContains(t, err, "unexpected E2SmMhoIndicationMessage")
+}
+
+func TestControlHeader(t *testing.T) {
+	ch, err := pdubuilder.CreateE2SmMhoControlHeader(10)
+	assert.NilError(t, err)
+	per, err := PerEncodeE2SmMhoControlHeader(ch)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refControlHeader, per)
+
+	result, err := PerDecodeE2SmMhoControlHeader(per)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(ch, result), "%v", result)
+}
+
+func TestControlMessage(t *testing.T) {
+	cm, err := pdubuilder.CreateE2SmMhoControlMessage(createNrCgi(t), &e2sm_mho.UeIdentity{Value: "1234"}, createEutraCgi(t))
+	assert.NilError(t, err)
+	per, err := PerEncodeE2SmMhoControlMessage(cm)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refControlMessage, per)
+
+	result, err := PerDecodeE2SmMhoControlMessage(per)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(0xbcd4abef), result.GetControlMessageFormat1().GetServingCgi().GetNrCgi().GetNRcellIdentity().GetValue().GetValue())
+	assert.Equal(t, uint64(0xbcd4ab), result.GetControlMessageFormat1().GetTargetCgi().GetEUtraCgi().GetEUtracellIdentity().GetValue().GetValue())
+	assert.Equal(t, "1234", result.GetControlMessageFormat1().GetUedId().GetValue())
+}
+
+func TestRanFunctionDescription(t *testing.T) {
+	rfd, err := pdubuilder.CreateE2SmMhoRanfunctionDescriptionMsg("ONF-MHO", "1.3.6.1.4.1.53148.1.1.2.101", "MHO",
+		[]*e2sm_mho.RicEventTriggerStyleList{pdubuilder.CreateRicEventTriggerStyleItem(1, "Periodic report", 1)},
+		[]*e2sm_mho.RicReportStyleList{pdubuilder.CreateRicReportStyleItem(1, "Measurement report", 1, 1)})
+	assert.NilError(t, err)
+	per, err := PerEncodeE2SmMhoRanfunctionDescription(rfd)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, refRanFunctionDescription, per)
+
+	result, err := PerDecodeE2SmMhoRanfunctionDescription(per)
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(rfd, result), "%v", result)
+}
@@ -0,0 +1,17 @@
+module github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_compat
+
+go 1.16
+
+require (
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho v0.0.0-00010101000000-000000000000
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go v0.0.0-00010101000000-000000000000
+	github.com/onosproject/onos-lib-go v0.8.9
+	google.golang.org/protobuf v1.27.1
+	gotest.tools v2.2.0+incompatible
+)
+
+replace (
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common => ../e2sm_common
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho => ../e2sm_mho
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go => ../e2sm_mho_go
+)
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package handover evaluates the A3 event (neighbour becomes offset better than serving, 3GPP TS 38.331 5.5.4.4)
+// on the measurement reports carried by E2SM-MHO indications and builds the control messages initiating the handovers.
+package handover
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/mhouekey"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/pdubuilder"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+)
+
+// Config holds the A3 event parameters. Offsets and hysteresis are in dB, like the RSRP reported by the E2 node.
+type Config struct {
+	// Offset is the a3-Offset added to the serving cell measurement
+	Offset float64
+	// Hysteresis is subtracted from the neighbour cell measurement
+	Hysteresis float64
+	// TimeToTrigger is how long the same neighbour must fulfil the entering condition before a handover is decided
+	TimeToTrigger time.Duration
+	// CellIndividualOffsets are the cell individual offsets (Ocn/Ocp), indexed by CgiKey
+	CellIndividualOffsets map[string]float64
+	// ControlMessagePriority is set in the header of the control messages
+	ControlMessagePriority int32
+}
+
+// Decision is a handover decided for a UE, with the control header and message to send to the E2 node
+type Decision struct {
+	UeID        *e2sm_mho_go.UeIdentity
+	ServingCgi  *e2sm_mho_go.CellGlobalId
+	TargetCgi   *e2sm_mho_go.CellGlobalId
+	ServingRsrp int32
+	TargetRsrp  int32
+	Header      *e2sm_mho_go.E2SmMhoControlHeader
+	Message     *e2sm_mho_go.E2SmMhoControlMessage
+}
+
+// ueState is the A3 evaluation state of a UE
+type ueState struct {
+	serving string
+	// candidate is the neighbour fulfilling the entering condition since the time given by since
+	candidate string
+	since     time.Time
+	last      time.Time
+}
+
+// A3Evaluator evaluates the A3 event for every UE reported by an E2 node
+type A3Evaluator struct {
+	config Config
+	ues    map[uekey.Key]*ueState
+}
+
+// NewA3Evaluator creates an evaluator with the given A3 parameters
+func NewA3Evaluator(config Config) *A3Evaluator {
+	return &A3Evaluator{
+		config: config,
+		ues:    make(map[uekey.Key]*ueState),
+	}
+}
+
+// CgiKey returns the NR-CGI or ECGI string of a CellGlobalId, as formatted by the cellid package
+func CgiKey(cgi *e2sm_mho_go.CellGlobalId) (string, error) {
+	switch c := cgi.GetCellGlobalId().(type) {
+	case *e2sm_mho_go.CellGlobalId_NrCgi:
+		return cellid.FormatNrcgiBitString(c.NrCgi.GetPLmnIdentity().GetValue(), c.NrCgi.GetNRcellIdentity().GetValue())
+	case *e2sm_mho_go.CellGlobalId_EUtraCgi:
+		return cellid.FormatEcgiBitString(c.EUtraCgi.GetPLmnIdentity().GetValue(), c.EUtraCgi.GetEUtracellIdentity().GetValue())
+	default:
+		return "", fmt.Errorf("unexpected CellGlobalId %v", cgi)
+	}
+}
+
+// Evaluate processes an indication received at time t. It returns the handover decided for the reported UE,
+// or nil if the A3 event has not been fulfilled for long enough.
+// A format 2 indication reporting that the UE is no longer RRC connected discards the state of the UE.
+func (e *A3Evaluator) Evaluate(t time.Time, header *e2sm_mho_go.E2SmMhoIndicationHeader, message *e2sm_mho_go.E2SmMhoIndicationMessage) (*Decision, error) {
+	switch msg := message.GetE2SmMhoIndicationMessage().(type) {
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat1:
+		return e.evaluateReport(t, header.GetIndicationHeaderFormat1().GetCgi(), msg.IndicationMessageFormat1)
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat2:
+		if msg.IndicationMessageFormat2.GetRrcStatus() != e2sm_mho_go.Rrcstatus_RRCSTATUS_CONNECTED {
+			key, err := mhouekey.FromUeIdentity(msg.IndicationMessageFormat2.GetUeId())
+			if err != nil {
+				return nil, err
+			}
+			delete(e.ues, key)
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected E2SmMhoIndicationMessage %v", message)
+	}
+}
+
+// Forget discards the state of a UE, e.g. once it has left the E2 node
+func (e *A3Evaluator) Forget(ueID *e2sm_mho_go.UeIdentity) {
+	if key, err := mhouekey.FromUeIdentity(ueID); err == nil {
+		delete(e.ues, key)
+	}
+}
+
+func (e *A3Evaluator) evaluateReport(t time.Time, servingCgi *e2sm_mho_go.CellGlobalId, report *e2sm_mho_go.E2SmMhoIndicationMessageFormat1) (*Decision, error) {
+	key, err := mhouekey.FromUeIdentity(report.GetUeId())
+	if err != nil {
+		return nil, err
+	}
+	servingKey, err := CgiKey(servingCgi)
+	if err != nil {
+		return nil, fmt.Errorf("serving cell: %v", err)
+	}
+
+	var serving *e2sm_mho_go.E2SmMhoMeasurementReportItem
+	cgiKeys := make([]string, len(report.GetMeasReport()))
+	for i, item := range report.GetMeasReport() {
+		if cgiKeys[i], err = CgiKey(item.GetCgi()); err != nil {
+			return nil, fmt.Errorf("measurement report: %v", err)
+		}
+		if cgiKeys[i] == servingKey {
+			serving = item
+		}
+	}
+	if serving == nil {
+		return nil, fmt.Errorf("UE %s: no measurement of serving cell %s", key, servingKey)
+	}
+
+	var target *e2sm_mho_go.E2SmMhoMeasurementReportItem
+	var targetKey string
+	var bestMargin float64
+	servingValue := float64(serving.GetRsrp().GetValue()) + e.config.CellIndividualOffsets[servingKey] + e.config.Offset
+	for i, item := range report.GetMeasReport() {
+		if cgiKeys[i] == servingKey {
+			continue
+		}
+		// Entering condition: Mn + Ocn - Hys > Mp + Ocp + Off
+		margin := float64(item.GetRsrp().GetValue()) + e.config.CellIndividualOffsets[cgiKeys[i]] - e.config.Hysteresis - servingValue
+		if margin > 0 && (target == nil || margin > bestMargin) {
+			target, targetKey, bestMargin = item, cgiKeys[i], margin
+		}
+	}
+
+	state, ok := e.ues[key]
+	if !ok || state.serving != servingKey {
+		state = &ueState{serving: servingKey}
+		e.ues[key] = state
+	} else if t.Before(state.last) {
+		return nil, fmt.Errorf("UE %s: report at %v is older than the last report at %v", key, t, state.last)
+	}
+	state.last = t
+	if target == nil {
+		state.candidate = ""
+		return nil, nil
+	}
+	if state.candidate != targetKey {
+		state.candidate, state.since = targetKey, t
+	}
+	if t.Sub(state.since) < e.config.TimeToTrigger {
+		return nil, nil
+	}
+	state.candidate = ""
+
+	header, err := pdubuilder.CreateE2SmMhoControlHeader(e.config.ControlMessagePriority)
+	if err != nil {
+		return nil, err
+	}
+	message, err := pdubuilder.CreateE2SmMhoControlMessage(servingCgi, report.GetUeId(), target.GetCgi())
+	if err != nil {
+		return nil, err
+	}
+	return &Decision{
+		UeID:        report.GetUeId(),
+		ServingCgi:  servingCgi,
+		TargetCgi:   target.GetCgi(),
+		ServingRsrp: serving.GetRsrp().GetValue(),
+		TargetRsrp:  target.GetRsrp().GetValue(),
+		Header:      header,
+		Message:     message,
+	}, nil
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handover
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/pdubuilder"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"gotest.tools/assert"
+)
+
+var plmnID = []byte{0x13, 0x00, 0x14}
+
+func createCgi(t *testing.T, nci uint64) *e2sm_mho_go.CellGlobalId {
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCGIFromNci(plmnID, nci)
+	assert.NilError(t, err)
+	return cgi
+}
+
+func createReport(t *testing.T, ueID string, serving uint64, rsrp map[uint64]int32) (*e2sm_mho_go.E2SmMhoIndicationHeader, *e2sm_mho_go.E2SmMhoIndicationMessage) {
+	header, err := pdubuilder.CreateE2SmMhoIndicationHeader(createCgi(t, serving))
+	assert.NilError(t, err)
+	items := make([]*e2sm_mho_go.E2SmMhoMeasurementReportItem, 0, len(rsrp))
+	for nci, value := range rsrp {
+		item, err := pdubuilder.CreateMeasurementRecordItem(createCgi(t, nci), &e2sm_mho_go.Rsrp{Value: value})
+		assert.NilError(t, err)
+		items = append(items, item)
+	}
+	message, err := pdubuilder.CreateE2SmMhoIndicationMsgFormat1(&e2sm_mho_go.UeIdentity{Value: []byte(ueID)}, items)
+	assert.NilError(t, err)
+	return header, message
+}
+
+func TestCgiKey(t *testing.T) {
+	key, err := CgiKey(createCgi(t, 0x1))
+	assert.NilError(t, err)
+	assert.Equal(t, "130014000000001", key)
+	eutraCgi, err := pdubuilder.CreateCellGlobalIDEutraCGIFromEci(plmnID, 0x2)
+	assert.NilError(t, err)
+	key, err = CgiKey(eutraCgi)
+	assert.NilError(t, err)
+	assert.Equal(t, "1300140000002", key)
+	_, err = CgiKey(&e2sm_mho_go.CellGlobalId{})
+	assert.ErrorContains(t, err, "unexpected CellGlobalId")
+}
+
+func TestA3Evaluator(t *testing.T) {
+	evaluator := NewA3Evaluator(Config{
+		Offset:                 3,
+		Hysteresis:             1,
+		TimeToTrigger:          2 * time.Second,
+		ControlMessagePriority: 10,
+	})
+	t0 := time.Unix(1600000000, 0)
+
+	// Cell 2 is only 3 dB better: 3 - 1 is not above the offset
+	header, message := createReport(t, "1234", 1, map[uint64]int32{1: -100, 2: -97})
+	decision, err := evaluator.Evaluate(t0, header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+
+	// Cell 3 enters, then cell 2 becomes the best neighbour and restarts the time to trigger
+	header, message = createReport(t, "1234", 1, map[uint64]int32{1: -100, 2: -97, 3: -95})
+	decision, err = evaluator.Evaluate(t0, header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+	header, message = createReport(t, "1234", 1, map[uint64]int32{1: -100, 2: -90, 3: -95})
+	decision, err = evaluator.Evaluate(t0.Add(time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+	decision, err = evaluator.Evaluate(t0.Add(2*time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+
+	// Another UE is evaluated separately
+	other, otherMessage := createReport(t, "5678", 1, map[uint64]int32{1: -100, 3: -80})
+	decision, err = evaluator.Evaluate(t0.Add(2*time.Second), other, otherMessage)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+
+	decision, err = evaluator.Evaluate(t0.Add(3*time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision != nil)
+	assert.Equal(t, "1234", string(decision.UeID.GetValue()))
+	assert.Equal(t, int32(-100), decision.ServingRsrp)
+	assert.Equal(t, int32(-90), decision.TargetRsrp)
+	targetKey, err := CgiKey(decision.TargetCgi)
+	assert.NilError(t, err)
+	assert.Equal(t, "130014000000002", targetKey)
+	assert.Equal(t, e2sm_mho_go.MhoCommand_MHO_COMMAND_INITIATE_HANDOVER, decision.Header.GetControlHeaderFormat1().GetRcCommand())
+	assert.Equal(t, int32(10), decision.Header.GetControlHeaderFormat1().GetRicControlMessagePriority().GetValue())
+	controlMessage := decision.Message.GetControlMessageFormat1()
+	assert.Equal(t, "1234", string(controlMessage.GetUedId().GetValue()))
+	assert.DeepEqual(t, decision.TargetCgi.GetNrCgi().GetNRcellIdentity().GetValue().GetValue(),
+		controlMessage.GetTargetCgi().GetNrCgi().GetNRcellIdentity().GetValue().GetValue())
+
+	// The time to trigger starts over once a decision has been made
+	decision, err = evaluator.Evaluate(t0.Add(4*time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+
+	// Falling below the entering condition stops the time to trigger
+	weak, weakMessage := createReport(t, "1234", 1, map[uint64]int32{1: -100, 2: -97})
+	_, err = evaluator.Evaluate(t0.Add(5*time.Second), weak, weakMessage)
+	assert.NilError(t, err)
+	decision, err = evaluator.Evaluate(t0.Add(6*time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+
+	_, err = evaluator.Evaluate(t0, header, message)
+	assert.ErrorContains(t, err, "is older than the last report")
+	header, message = createReport(t, "1234", 4, map[uint64]int32{1: -100})
+	_, err = evaluator.Evaluate(t0.Add(7*time.Second), header, message)
+	assert.ErrorContains(t, err, "no measurement of serving cell 130014000000004")
+}
+
+func TestA3Evaluator_CellIndividualOffsets(t *testing.T) {
+	evaluator := NewA3Evaluator(Config{
+		CellIndividualOffsets: map[string]float64{"130014000000002": -6},
+	})
+	t0 := time.Unix(1600000000, 0)
+
+	// Without time to trigger, the decision is immediate. Cell 2 would be better but is penalised.
+	header, message := createReport(t, "1234", 1, map[uint64]int32{1: -100, 2: -95, 3: -98})
+	decision, err := evaluator.Evaluate(t0, header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision != nil)
+	assert.Equal(t, int32(-98), decision.TargetRsrp)
+}
+
+func TestA3Evaluator_RrcStatus(t *testing.T) {
+	evaluator := NewA3Evaluator(Config{TimeToTrigger: time.Second})
+	t0 := time.Unix(1600000000, 0)
+
+	header, message := createReport(t, "1234", 1, map[uint64]int32{1: -100, 2: -90})
+	decision, err := evaluator.Evaluate(t0, header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+
+	// The UE going idle discards the time to trigger already elapsed
+	idle, err := pdubuilder.CreateE2SmMhoIndicationMsgFormat2(&e2sm_mho_go.UeIdentity{Value: []byte("1234")}, pdubuilder.CreateRrcStatusIdle())
+	assert.NilError(t, err)
+	decision, err = evaluator.Evaluate(t0, header, idle)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+	decision, err = evaluator.Evaluate(t0.Add(time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision == nil)
+	decision, err = evaluator.Evaluate(t0.Add(2*time.Second), header, message)
+	assert.NilError(t, err)
+	assert.Assert(t, decision != nil)
+
+	_, err = evaluator.Evaluate(t0, header, &e2sm_mho_go.E2SmMhoIndicationMessage{})
+	assert.ErrorContains(t, err, "unexpected E2SmMhoIndicationMessage")
+}
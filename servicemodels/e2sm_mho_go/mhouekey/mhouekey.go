@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mhouekey converts the UE identities carried by E2SM-MHO to canonical UE keys
+package mhouekey
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+)
+
+// FromUeIdentity returns the opaque key of an E2SM-MHO UE-Identity, which is an OCTET STRING
+func FromUeIdentity(ueID *e2sm_mho_go.UeIdentity) (uekey.Key, error) {
+	if len(ueID.GetValue()) == 0 {
+		return uekey.Key{}, fmt.Errorf("UeIdentity has no value")
+	}
+	return uekey.Opaque(ueID.GetValue()), nil
+}
+
+// FromUeIdentityAs returns the key of an E2SM-MHO UE-Identity whose octets carry the decimal string of an ID of the given type,
+// as sent by E2 nodes which fill the UE-Identity with e.g. their RAN UE NGAP ID, so that it can be matched with the keys of other SMs
+func FromUeIdentityAs(ueID *e2sm_mho_go.UeIdentity, t uekey.Type) (uekey.Key, error) {
+	value, err := strconv.ParseInt(string(ueID.GetValue()), 10, 64)
+	if err != nil || value < 0 {
+		return uekey.Key{}, fmt.Errorf("UeIdentity %x is not the decimal string of a %s", ueID.GetValue(), t)
+	}
+	key := uekey.New(t, value)
+	if err := key.Validate(); err != nil {
+		return uekey.Key{}, err
+	}
+	return key, nil
+}
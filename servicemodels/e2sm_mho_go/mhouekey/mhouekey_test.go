@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mhouekey
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"gotest.tools/assert"
+)
+
+func TestFromUeIdentity(t *testing.T) {
+	ueID := &e2sm_mho_go.UeIdentity{
+		Value: []byte("1234"),
+	}
+	key, err := FromUeIdentity(ueID)
+	assert.NilError(t, err)
+	assert.Equal(t, "UE-ID/31323334", key.String())
+
+	key, err = FromUeIdentityAs(ueID, uekey.TypeRanUeNgapID)
+	assert.NilError(t, err)
+	assert.Equal(t, "RAN-UE-NGAP-ID/1234", key.String())
+	assert.Assert(t, key.Equal(uekey.New(uekey.TypeRanUeNgapID, 1234)))
+
+	_, err = FromUeIdentityAs(&e2sm_mho_go.UeIdentity{Value: []byte{0x01, 0x02}}, uekey.TypeRanUeNgapID)
+	assert.ErrorContains(t, err, "UeIdentity 0102 is not the decimal string of a RAN-UE-NGAP-ID")
+	_, err = FromUeIdentityAs(ueID, uekey.Type("IMSI"))
+	assert.ErrorContains(t, err, `unknown UE key type "IMSI"`)
+	_, err = FromUeIdentity(&e2sm_mho_go.UeIdentity{})
+	assert.ErrorContains(t, err, "UeIdentity has no value")
+}
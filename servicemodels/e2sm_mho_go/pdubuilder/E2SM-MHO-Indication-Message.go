@@ -5,6 +5,8 @@ package pdubuilder
 
 import (
 	"fmt"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 )
@@ -121,6 +123,34 @@ func CreateCellGlobalIDEutraCGI(plmnID []byte, eutraCGI *asn1.BitString) (*e2sm_
 	return cgi, nil
 }
 
+// CreateCellGlobalIDNrCGIFromNci creates an NR CGI out of a 36 bit NCI. Unlike CreateCellGlobalIDNrCGI, it checks
+// that the PLMN ID is BCD encoded.
+func CreateCellGlobalIDNrCGIFromNci(plmnID plmnid.PlmnID, nci uint64) (*e2sm_mho_go.CellGlobalId, error) {
+
+	if err := plmnID.Validate(); err != nil {
+		return nil, fmt.Errorf("CreateCellGlobalIDNrCGIFromNci(): %v", err)
+	}
+	nrCGI, err := cellid.NciToBitString(nci)
+	if err != nil {
+		return nil, fmt.Errorf("CreateCellGlobalIDNrCGIFromNci(): %v", err)
+	}
+	return CreateCellGlobalIDNrCGI(plmnID, nrCGI)
+}
+
+// CreateCellGlobalIDEutraCGIFromEci creates an E-UTRA CGI out of a 28 bit ECI. Unlike CreateCellGlobalIDEutraCGI,
+// it checks that the PLMN ID is BCD encoded.
+func CreateCellGlobalIDEutraCGIFromEci(plmnID plmnid.PlmnID, eci uint32) (*e2sm_mho_go.CellGlobalId, error) {
+
+	if err := plmnID.Validate(); err != nil {
+		return nil, fmt.Errorf("CreateCellGlobalIDEutraCGIFromEci(): %v", err)
+	}
+	eutraCGI, err := cellid.EciToBitString(eci)
+	if err != nil {
+		return nil, fmt.Errorf("CreateCellGlobalIDEutraCGIFromEci(): %v", err)
+	}
+	return CreateCellGlobalIDEutraCGI(plmnID, eutraCGI)
+}
+
 func CreateRrcStatusConnected() e2sm_mho_go.Rrcstatus {
 	return e2sm_mho_go.Rrcstatus_RRCSTATUS_CONNECTED
 }
@@ -7,6 +7,7 @@ package pdubuilder
 import (
 	"encoding/hex"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/encoder"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 	"gotest.tools/assert"
@@ -68,3 +69,16 @@ func TestE2SmMhoIndicationMsgF2(t *testing.T) {
 	t.Logf("Decoded E2SM-MHO-IndicationMessage is \n%v", result)
 	assert.Equal(t, newE2SmMhoPdu.String(), result.String())
 }
+
+func TestCreateCellGlobalIDFromEci(t *testing.T) {
+	plmnID, err := plmnid.FromMCCMNC("208", "93")
+	assert.NilError(t, err)
+
+	cgi, err := CreateCellGlobalIDEutraCGIFromEci(plmnID, 0x9bcd4ab)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x02, 0xf8, 0x39}, cgi.GetEUtraCgi().GetPLmnIdentity().GetValue())
+	assert.DeepEqual(t, []byte{0x9b, 0xcd, 0x4a, 0xb0}, cgi.GetEUtraCgi().GetEUtracellIdentity().GetValue().GetValue())
+
+	_, err = CreateCellGlobalIDEutraCGIFromEci(plmnid.PlmnID{0xAA, 0xFD, 0xD4}, 0x9bcd4ab)
+	assert.ErrorContains(t, err, "not BCD encoded")
+}
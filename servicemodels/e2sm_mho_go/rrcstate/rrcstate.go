@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rrcstate keeps the RRC state of the UEs of an E2 node from E2SM-MHO format 2 indications,
+// along with the history of their transitions. Transitions which 3GPP TS 38.331 does not allow
+// (IDLE to INACTIVE) are kept in the history but flagged, to help debugging E2 node behaviour.
+package rrcstate
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/mhouekey"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+)
+
+// Transition is a change of the RRC state of a UE. The first state reported for a UE is recorded
+// as an initial transition, whose From state is meaningless.
+type Transition struct {
+	Time       time.Time
+	UeKey      uekey.Key
+	From       e2sm_mho_go.Rrcstatus
+	To         e2sm_mho_go.Rrcstatus
+	Initial    bool
+	Impossible bool
+}
+
+func (t *Transition) String() string {
+	if t.Initial {
+		return fmt.Sprintf("%s: %s at %v", t.UeKey, t.To, t.Time)
+	}
+	return fmt.Sprintf("%s: %s -> %s at %v", t.UeKey, t.From, t.To, t.Time)
+}
+
+// Ue is a UE known to the registry
+type Ue struct {
+	Key        uekey.Key
+	UeID       *e2sm_mho_go.UeIdentity
+	State      e2sm_mho_go.Rrcstatus
+	LastUpdate time.Time
+	// History holds the transitions of the UE, oldest first
+	History []*Transition
+}
+
+// Registry holds the RRC state of the UEs of one E2 node
+type Registry struct {
+	maxHistory int
+	ues        map[uekey.Key]*Ue
+	impossible []*Transition
+}
+
+// NewRegistry creates a registry keeping at most maxHistory transitions per UE, and as many impossible transitions;
+// maxHistory <= 0 keeps all transitions
+func NewRegistry(maxHistory int) *Registry {
+	return &Registry{
+		maxHistory: maxHistory,
+		ues:        make(map[uekey.Key]*Ue),
+	}
+}
+
+// IsPossible returns whether a UE can move from one RRC state to another: every transition is allowed
+// except from RRC_IDLE to RRC_INACTIVE, as a UE has to be connected to be suspended
+func IsPossible(from e2sm_mho_go.Rrcstatus, to e2sm_mho_go.Rrcstatus) bool {
+	return !(from == e2sm_mho_go.Rrcstatus_RRCSTATUS_IDLE && to == e2sm_mho_go.Rrcstatus_RRCSTATUS_INACTIVE)
+}
+
+// Apply applies an indication received at time t and returns the resulting transition. It returns nil
+// if the RRC state of the UE did not change, or if the message is a format 1 indication (a measurement report).
+func (r *Registry) Apply(t time.Time, message *e2sm_mho_go.E2SmMhoIndicationMessage) (*Transition, error) {
+	switch msg := message.GetE2SmMhoIndicationMessage().(type) {
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat1:
+		return nil, nil
+	case *e2sm_mho_go.E2SmMhoIndicationMessage_IndicationMessageFormat2:
+		return r.apply(t, msg.IndicationMessageFormat2)
+	default:
+		return nil, fmt.Errorf("unexpected E2SmMhoIndicationMessage %v", message)
+	}
+}
+
+// Ue returns the UE with the given identity
+func (r *Registry) Ue(ueID *e2sm_mho_go.UeIdentity) (*Ue, bool) {
+	key, err := mhouekey.FromUeIdentity(ueID)
+	if err != nil {
+		return nil, false
+	}
+	ue, ok := r.ues[key]
+	return ue, ok
+}
+
+// State returns the current RRC state of the UE with the given identity
+func (r *Registry) State(ueID *e2sm_mho_go.UeIdentity) (e2sm_mho_go.Rrcstatus, bool) {
+	ue, ok := r.Ue(ueID)
+	if !ok {
+		return e2sm_mho_go.Rrcstatus_RRCSTATUS_IDLE, false
+	}
+	return ue.State, true
+}
+
+// Ues returns the UEs known to the registry, ordered by key
+func (r *Registry) Ues() []*Ue {
+	ues := make([]*Ue, 0, len(r.ues))
+	for _, ue := range r.ues {
+		ues = append(ues, ue)
+	}
+	sort.Slice(ues, func(i, j int) bool {
+		return ues[i].Key.String() < ues[j].Key.String()
+	})
+	return ues
+}
+
+// UesInState returns the UEs whose current RRC state is the given one, ordered by key
+func (r *Registry) UesInState(state e2sm_mho_go.Rrcstatus) []*Ue {
+	ues := make([]*Ue, 0)
+	for _, ue := range r.Ues() {
+		if ue.State == state {
+			ues = append(ues, ue)
+		}
+	}
+	return ues
+}
+
+// ImpossibleTransitions returns the impossible transitions seen on any UE, oldest first
+func (r *Registry) ImpossibleTransitions() []*Transition {
+	return r.impossible
+}
+
+// Forget discards a UE, e.g. once it has left the E2 node
+func (r *Registry) Forget(ueID *e2sm_mho_go.UeIdentity) {
+	if key, err := mhouekey.FromUeIdentity(ueID); err == nil {
+		delete(r.ues, key)
+	}
+}
+
+func (r *Registry) apply(t time.Time, format2 *e2sm_mho_go.E2SmMhoIndicationMessageFormat2) (*Transition, error) {
+	key, err := mhouekey.FromUeIdentity(format2.GetUeId())
+	if err != nil {
+		return nil, err
+	}
+	state := format2.GetRrcStatus()
+	if _, ok := e2sm_mho_go.Rrcstatus_name[int32(state)]; !ok {
+		return nil, fmt.Errorf("UE %s: unexpected Rrcstatus %d", key, state)
+	}
+
+	ue, ok := r.ues[key]
+	if !ok {
+		ue = &Ue{
+			Key:  key,
+			UeID: format2.GetUeId(),
+		}
+		r.ues[key] = ue
+	} else if t.Before(ue.LastUpdate) {
+		return nil, fmt.Errorf("UE %s: indication at %v is older than the last one at %v", key, t, ue.LastUpdate)
+	}
+	ue.LastUpdate = t
+	if ok && ue.State == state {
+		return nil, nil
+	}
+
+	transition := &Transition{
+		Time:       t,
+		UeKey:      key,
+		From:       ue.State,
+		To:         state,
+		Initial:    !ok,
+		Impossible: ok && !IsPossible(ue.State, state),
+	}
+	ue.State = state
+	ue.History = r.trim(append(ue.History, transition))
+	if transition.Impossible {
+		r.impossible = r.trim(append(r.impossible, transition))
+	}
+	return transition, nil
+}
+
+func (r *Registry) trim(transitions []*Transition) []*Transition {
+	if r.maxHistory > 0 && len(transitions) > r.maxHistory {
+		return transitions[len(transitions)-r.maxHistory:]
+	}
+	return transitions
+}
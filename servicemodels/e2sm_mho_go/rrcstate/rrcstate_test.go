@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rrcstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/pdubuilder"
+	e2sm_mho_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"gotest.tools/assert"
+)
+
+func createRrcStatus(t *testing.T, ueID string, status e2sm_mho_go.Rrcstatus) *e2sm_mho_go.E2SmMhoIndicationMessage {
+	message, err := pdubuilder.CreateE2SmMhoIndicationMsgFormat2(&e2sm_mho_go.UeIdentity{Value: []byte(ueID)}, status)
+	assert.NilError(t, err)
+	return message
+}
+
+func TestIsPossible(t *testing.T) {
+	assert.Assert(t, IsPossible(pdubuilder.CreateRrcStatusIdle(), pdubuilder.CreateRrcStatusConnected()))
+	assert.Assert(t, IsPossible(pdubuilder.CreateRrcStatusConnected(), pdubuilder.CreateRrcStatusInactive()))
+	assert.Assert(t, IsPossible(pdubuilder.CreateRrcStatusInactive(), pdubuilder.CreateRrcStatusIdle()))
+	assert.Assert(t, !IsPossible(pdubuilder.CreateRrcStatusIdle(), pdubuilder.CreateRrcStatusInactive()))
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(3)
+	t0 := time.Unix(1600000000, 0)
+	ueID := &e2sm_mho_go.UeIdentity{Value: []byte("1234")}
+
+	transition, err := registry.Apply(t0, createRrcStatus(t, "1234", pdubuilder.CreateRrcStatusConnected()))
+	assert.NilError(t, err)
+	assert.Assert(t, transition.Initial)
+	assert.Equal(t, "UE-ID/31323334: RRCSTATUS_CONNECTED at "+t0.String(), transition.String())
+
+	// Repeated states are not transitions
+	transition, err = registry.Apply(t0.Add(time.Second), createRrcStatus(t, "1234", pdubuilder.CreateRrcStatusConnected()))
+	assert.NilError(t, err)
+	assert.Assert(t, transition == nil)
+	ue, ok := registry.Ue(ueID)
+	assert.Assert(t, ok)
+	assert.Equal(t, t0.Add(time.Second), ue.LastUpdate)
+
+	transition, err = registry.Apply(t0.Add(2*time.Second), createRrcStatus(t, "1234", pdubuilder.CreateRrcStatusInactive()))
+	assert.NilError(t, err)
+	assert.Assert(t, !transition.Impossible)
+	_, err = registry.Apply(t0.Add(3*time.Second), createRrcStatus(t, "1234", pdubuilder.CreateRrcStatusIdle()))
+	assert.NilError(t, err)
+	transition, err = registry.Apply(t0.Add(4*time.Second), createRrcStatus(t, "1234", pdubuilder.CreateRrcStatusInactive()))
+	assert.NilError(t, err)
+	assert.Assert(t, transition.Impossible)
+	assert.Equal(t, "UE-ID/31323334: RRCSTATUS_IDLE -> RRCSTATUS_INACTIVE at "+t0.Add(4*time.Second).String(), transition.String())
+
+	state, ok := registry.State(ueID)
+	assert.Assert(t, ok)
+	assert.Equal(t, pdubuilder.CreateRrcStatusInactive(), state)
+	ue, _ = registry.Ue(ueID)
+	assert.Equal(t, 3, len(ue.History))
+	assert.Equal(t, pdubuilder.CreateRrcStatusConnected(), ue.History[0].From)
+	assert.Equal(t, pdubuilder.CreateRrcStatusInactive(), ue.History[0].To)
+	assert.Equal(t, 1, len(registry.ImpossibleTransitions()))
+
+	// Measurement reports do not change the RRC state
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCGIFromNci([]byte{0x13, 0x00, 0x14}, 1)
+	assert.NilError(t, err)
+	item, err := pdubuilder.CreateMeasurementRecordItem(cgi, &e2sm_mho_go.Rsrp{Value: -100})
+	assert.NilError(t, err)
+	report, err := pdubuilder.CreateE2SmMhoIndicationMsgFormat1(ueID, []*e2sm_mho_go.E2SmMhoMeasurementReportItem{item})
+	assert.NilError(t, err)
+	transition, err = registry.Apply(t0.Add(5*time.Second), report)
+	assert.NilError(t, err)
+	assert.Assert(t, transition == nil)
+
+	_, err = registry.Apply(t0.Add(5*time.Second), createRrcStatus(t, "5678", pdubuilder.CreateRrcStatusIdle()))
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(registry.Ues()))
+	idle := registry.UesInState(pdubuilder.CreateRrcStatusIdle())
+	assert.Equal(t, 1, len(idle))
+	assert.Equal(t, "5678", string(idle[0].UeID.GetValue()))
+
+	_, err = registry.Apply(t0, createRrcStatus(t, "1234", pdubuilder.CreateRrcStatusConnected()))
+	assert.ErrorContains(t, err, "is older than the last one")
+	_, err = registry.Apply(t0, &e2sm_mho_go.E2SmMhoIndicationMessage{})
+	assert.ErrorContains(t, err, "unexpected E2SmMhoIndicationMessage")
+
+	registry.Forget(ueID)
+	_, ok = registry.State(ueID)
+	assert.Assert(t, !ok)
+	assert.Equal(t, 1, len(registry.Ues()))
+}
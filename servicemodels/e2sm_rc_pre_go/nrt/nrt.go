@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nrt keeps the neighbour relation table (NRT) of every serving cell reported by E2SM-RC-PRE indications.
+// Each indication replaces the NRT of its serving cell; the differences with the previous one are returned as events.
+// PCI conflicts are detected within each table: a collision is a neighbour sharing the PCI and frequency
+// of the serving cell, a confusion is two neighbours sharing a PCI and frequency.
+package nrt
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+)
+
+// Frequency is the downlink ARFCN of a cell, either an EARFCN or an NR-ARFCN
+type Frequency struct {
+	NR    bool
+	Arfcn int32
+}
+
+func (f Frequency) String() string {
+	if f.NR {
+		return fmt.Sprintf("NR-ARFCN %d", f.Arfcn)
+	}
+	return fmt.Sprintf("EARFCN %d", f.Arfcn)
+}
+
+// Cell is a cell as reported in an indication, either the serving cell or one of its neighbours
+type Cell struct {
+	// Key is the NR-CGI or ECGI string of the cell, see CgiKey
+	Key       string
+	Cgi       *e2sm_rc_pre_go.CellGlobalId
+	Frequency Frequency
+	CellSize  e2sm_rc_pre_go.CellSize
+	Pci       int32
+}
+
+// sameAttributes returns whether both cells have the same frequency, size and PCI
+func (c *Cell) sameAttributes(other *Cell) bool {
+	return c.Frequency == other.Frequency && c.CellSize == other.CellSize && c.Pci == other.Pci
+}
+
+// Table is the neighbour relation table of a serving cell
+type Table struct {
+	Serving   *Cell
+	neighbors map[string]*Cell
+}
+
+// Neighbor returns the neighbour with the given key
+func (t *Table) Neighbor(key string) (*Cell, bool) {
+	cell, ok := t.neighbors[key]
+	return cell, ok
+}
+
+// Neighbors returns the neighbours of the serving cell, ordered by key
+func (t *Table) Neighbors() []*Cell {
+	cells := make([]*Cell, 0, len(t.neighbors))
+	for _, cell := range t.neighbors {
+		cells = append(cells, cell)
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].Key < cells[j].Key
+	})
+	return cells
+}
+
+// EventType is the type of a change of a neighbour relation table
+type EventType int
+
+const (
+	// EventNeighborAdded is a neighbour which was not in the previous table
+	EventNeighborAdded EventType = iota
+	// EventNeighborRemoved is a neighbour which is no longer in the table
+	EventNeighborRemoved
+	// EventNeighborChanged is a neighbour whose frequency, size or PCI changed
+	EventNeighborChanged
+	// EventServingChanged is a serving cell whose frequency, size or PCI changed
+	EventServingChanged
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventNeighborAdded:
+		return "NEIGHBOR_ADDED"
+	case EventNeighborRemoved:
+		return "NEIGHBOR_REMOVED"
+	case EventNeighborChanged:
+		return "NEIGHBOR_CHANGED"
+	case EventServingChanged:
+		return "SERVING_CHANGED"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event is a change of the table of a serving cell. Cell is nil for a removed neighbour,
+// and Previous is nil for an added one.
+type Event struct {
+	Type       EventType
+	ServingKey string
+	Cell       *Cell
+	Previous   *Cell
+}
+
+// ConflictType is the type of a PCI conflict
+type ConflictType int
+
+const (
+	// PciCollision is a neighbour using the PCI of the serving cell on the same frequency
+	PciCollision ConflictType = iota
+	// PciConfusion is several neighbours of a serving cell using the same PCI on the same frequency
+	PciConfusion
+)
+
+func (t ConflictType) String() string {
+	switch t {
+	case PciCollision:
+		return "PCI_COLLISION"
+	case PciConfusion:
+		return "PCI_CONFUSION"
+	default:
+		return fmt.Sprintf("ConflictType(%d)", int(t))
+	}
+}
+
+// Conflict is a PCI conflict found in the table of a serving cell. Cells are the keys of the cells
+// involved, ordered by key, including the serving cell for a collision.
+type Conflict struct {
+	Type       ConflictType
+	ServingKey string
+	Pci        int32
+	Frequency  Frequency
+	Cells      []string
+}
+
+func (c *Conflict) String() string {
+	return fmt.Sprintf("%s of PCI %d on %s in the NRT of %s: %v", c.Type, c.Pci, c.Frequency, c.ServingKey, c.Cells)
+}
+
+// Tables holds the neighbour relation tables of the serving cells of one or more E2 nodes
+type Tables struct {
+	tables map[string]*Table
+}
+
+// NewTables creates an empty set of tables
+func NewTables() *Tables {
+	return &Tables{
+		tables: make(map[string]*Table),
+	}
+}
+
+// CgiKey returns the NR-CGI or ECGI string of a CellGlobalId, as formatted by the cellid package
+func CgiKey(cgi *e2sm_rc_pre_go.CellGlobalId) (string, error) {
+	switch c := cgi.GetCellGlobalId().(type) {
+	case *e2sm_rc_pre_go.CellGlobalId_NrCgi:
+		return cellid.FormatNrcgiBitString(c.NrCgi.GetPLmnIdentity().GetValue(), c.NrCgi.GetNRcellIdentity().GetValue())
+	case *e2sm_rc_pre_go.CellGlobalId_EUtraCgi:
+		return cellid.FormatEcgiBitString(c.EUtraCgi.GetPLmnIdentity().GetValue(), c.EUtraCgi.GetEUtracellIdentity().GetValue())
+	default:
+		return "", fmt.Errorf("unexpected CellGlobalId %v", cgi)
+	}
+}
+
+// FrequencyOf returns the frequency of an Arfcn
+func FrequencyOf(arfcn *e2sm_rc_pre_go.Arfcn) (Frequency, error) {
+	switch a := arfcn.GetArfcn().(type) {
+	case *e2sm_rc_pre_go.Arfcn_EArfcn:
+		return Frequency{Arfcn: a.EArfcn.GetValue()}, nil
+	case *e2sm_rc_pre_go.Arfcn_NrArfcn:
+		return Frequency{NR: true, Arfcn: a.NrArfcn.GetValue()}, nil
+	default:
+		return Frequency{}, fmt.Errorf("unexpected Arfcn %v", arfcn)
+	}
+}
+
+// Update replaces the table of the serving cell of an indication and returns the changes, serving cell first
+// then neighbours ordered by key. A neighbour reported twice in the same indication is an error.
+func (t *Tables) Update(header *e2sm_rc_pre_go.E2SmRcPreIndicationHeader, message *e2sm_rc_pre_go.E2SmRcPreIndicationMessage) ([]*Event, error) {
+	format1 := message.GetIndicationMessageFormat1()
+	if format1 == nil {
+		return nil, fmt.Errorf("unexpected E2SmRcPreIndicationMessage %v", message)
+	}
+	serving, err := newCell(header.GetIndicationHeaderFormat1().GetCgi(), format1.GetDlArfcn(), format1.GetCellSize(), format1.GetPci())
+	if err != nil {
+		return nil, fmt.Errorf("serving cell: %v", err)
+	}
+	neighbors := make(map[string]*Cell, len(format1.GetNeighbors()))
+	for i, nrt := range format1.GetNeighbors() {
+		cell, err := newCell(nrt.GetCgi(), nrt.GetDlArfcn(), nrt.GetCellSize(), nrt.GetPci())
+		if err != nil {
+			return nil, fmt.Errorf("neighbor %d: %v", i, err)
+		}
+		if cell.Key == serving.Key {
+			return nil, fmt.Errorf("neighbor %d: %s is the serving cell", i, cell.Key)
+		}
+		if _, ok := neighbors[cell.Key]; ok {
+			return nil, fmt.Errorf("neighbor %d: %s is reported more than once", i, cell.Key)
+		}
+		neighbors[cell.Key] = cell
+	}
+
+	table := &Table{
+		Serving:   serving,
+		neighbors: neighbors,
+	}
+	previous, ok := t.tables[serving.Key]
+	if !ok {
+		previous = &Table{neighbors: make(map[string]*Cell)}
+	}
+	t.tables[serving.Key] = table
+
+	events := make([]*Event, 0)
+	if previous.Serving != nil && !previous.Serving.sameAttributes(serving) {
+		events = append(events, &Event{Type: EventServingChanged, ServingKey: serving.Key, Cell: serving, Previous: previous.Serving})
+	}
+	for _, cell := range table.Neighbors() {
+		old, ok := previous.neighbors[cell.Key]
+		if !ok {
+			events = append(events, &Event{Type: EventNeighborAdded, ServingKey: serving.Key, Cell: cell})
+		} else if !old.sameAttributes(cell) {
+			events = append(events, &Event{Type: EventNeighborChanged, ServingKey: serving.Key, Cell: cell, Previous: old})
+		}
+	}
+	for _, old := range previous.Neighbors() {
+		if _, ok := neighbors[old.Key]; !ok {
+			events = append(events, &Event{Type: EventNeighborRemoved, ServingKey: serving.Key, Previous: old})
+		}
+	}
+	return events, nil
+}
+
+// Remove removes the table of a serving cell, e.g. once its E2 node is gone
+func (t *Tables) Remove(servingKey string) {
+	delete(t.tables, servingKey)
+}
+
+// Table returns the table of the serving cell with the given key
+func (t *Tables) Table(servingKey string) (*Table, bool) {
+	table, ok := t.tables[servingKey]
+	return table, ok
+}
+
+// Tables returns every table, ordered by serving cell key
+func (t *Tables) Tables() []*Table {
+	tables := make([]*Table, 0, len(t.tables))
+	for _, table := range t.tables {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Serving.Key < tables[j].Serving.Key
+	})
+	return tables
+}
+
+// Conflicts returns the PCI conflicts of the table of a serving cell, collisions first,
+// then ordered by frequency and PCI
+func (t *Table) Conflicts() []*Conflict {
+	type pciKey struct {
+		frequency Frequency
+		pci       int32
+	}
+	groups := make(map[pciKey][]string)
+	for _, cell := range t.Neighbors() {
+		key := pciKey{frequency: cell.Frequency, pci: cell.Pci}
+		groups[key] = append(groups[key], cell.Key)
+	}
+
+	conflicts := make([]*Conflict, 0)
+	servingKey := pciKey{frequency: t.Serving.Frequency, pci: t.Serving.Pci}
+	if cells, ok := groups[servingKey]; ok {
+		conflicts = append(conflicts, &Conflict{
+			Type:       PciCollision,
+			ServingKey: t.Serving.Key,
+			Pci:        t.Serving.Pci,
+			Frequency:  t.Serving.Frequency,
+			Cells:      sortedKeys(append([]string{t.Serving.Key}, cells...)),
+		})
+	}
+	confusions := make([]*Conflict, 0)
+	for key, cells := range groups {
+		if len(cells) > 1 {
+			confusions = append(confusions, &Conflict{
+				Type:       PciConfusion,
+				ServingKey: t.Serving.Key,
+				Pci:        key.pci,
+				Frequency:  key.frequency,
+				Cells:      cells,
+			})
+		}
+	}
+	sort.Slice(confusions, func(i, j int) bool {
+		a, b := confusions[i], confusions[j]
+		if a.Frequency != b.Frequency {
+			if a.Frequency.NR != b.Frequency.NR {
+				return !a.Frequency.NR
+			}
+			return a.Frequency.Arfcn < b.Frequency.Arfcn
+		}
+		return a.Pci < b.Pci
+	})
+	return append(conflicts, confusions...)
+}
+
+// Conflicts returns the PCI conflicts of every table, ordered by serving cell key
+func (t *Tables) Conflicts() []*Conflict {
+	conflicts := make([]*Conflict, 0)
+	for _, table := range t.Tables() {
+		conflicts = append(conflicts, table.Conflicts()...)
+	}
+	return conflicts
+}
+
+func newCell(cgi *e2sm_rc_pre_go.CellGlobalId, arfcn *e2sm_rc_pre_go.Arfcn, cellSize e2sm_rc_pre_go.CellSize, pci *e2sm_rc_pre_go.Pci) (*Cell, error) {
+	key, err := CgiKey(cgi)
+	if err != nil {
+		return nil, err
+	}
+	frequency, err := FrequencyOf(arfcn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", key, err)
+	}
+	if pci == nil {
+		return nil, fmt.Errorf("%s: PCI is missing", key)
+	}
+	return &Cell{
+		Key:       key,
+		Cgi:       cgi,
+		Frequency: frequency,
+		CellSize:  cellSize,
+		Pci:       pci.GetValue(),
+	}, nil
+}
+
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
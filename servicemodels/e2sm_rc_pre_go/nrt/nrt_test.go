@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package nrt
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/pdubuilder"
+	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	"gotest.tools/assert"
+)
+
+var plmnID = []byte{0x13, 0x00, 0x14}
+
+type neighbor struct {
+	nci   uint64
+	arfcn int32
+	pci   int32
+}
+
+func createIndication(t *testing.T, nci uint64, arfcn int32, pci int32, neighbors ...neighbor) (*e2sm_rc_pre_go.E2SmRcPreIndicationHeader, *e2sm_rc_pre_go.E2SmRcPreIndicationMessage) {
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCgiFromNci(plmnID, nci)
+	assert.NilError(t, err)
+	header, err := pdubuilder.CreateE2SmRcPreIndicationHeader(cgi)
+	assert.NilError(t, err)
+	nrts := make([]*e2sm_rc_pre_go.Nrt, 0, len(neighbors))
+	for _, n := range neighbors {
+		cgi, err := pdubuilder.CreateCellGlobalIDNrCgiFromNci(plmnID, n.nci)
+		assert.NilError(t, err)
+		nrt, err := pdubuilder.CreateNrt(cgi, pdubuilder.CreateNrArfcn(n.arfcn), e2sm_rc_pre_go.CellSize_CELL_SIZE_MACRO, &e2sm_rc_pre_go.Pci{Value: n.pci})
+		assert.NilError(t, err)
+		nrts = append(nrts, nrt)
+	}
+	message, err := pdubuilder.CreateE2SmRcPreIndicationMsgFormat1(plmnID, pdubuilder.CreateNrArfcn(arfcn), e2sm_rc_pre_go.CellSize_CELL_SIZE_MACRO, pci, nrts)
+	assert.NilError(t, err)
+	return header, message
+}
+
+func TestTables_Update(t *testing.T) {
+	tables := NewTables()
+
+	events, err := tables.Update(createIndication(t, 1, 630000, 10, neighbor{2, 630000, 20}, neighbor{3, 630000, 30}))
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, EventNeighborAdded, events[0].Type)
+	assert.Equal(t, "130014000000002", events[0].Cell.Key)
+	assert.Equal(t, "130014000000001", events[0].ServingKey)
+	assert.Assert(t, events[0].Previous == nil)
+
+	// The same table does not change anything
+	events, err = tables.Update(createIndication(t, 1, 630000, 10, neighbor{3, 630000, 30}, neighbor{2, 630000, 20}))
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(events))
+
+	events, err = tables.Update(createIndication(t, 1, 630000, 11, neighbor{2, 630000, 21}, neighbor{4, 630000, 40}))
+	assert.NilError(t, err)
+	assert.Equal(t, 4, len(events))
+	assert.Equal(t, EventServingChanged, events[0].Type)
+	assert.Equal(t, int32(10), events[0].Previous.Pci)
+	assert.Equal(t, int32(11), events[0].Cell.Pci)
+	assert.Equal(t, EventNeighborChanged, events[1].Type)
+	assert.Equal(t, "130014000000002", events[1].Cell.Key)
+	assert.Equal(t, EventNeighborAdded, events[2].Type)
+	assert.Equal(t, "130014000000004", events[2].Cell.Key)
+	assert.Equal(t, EventNeighborRemoved, events[3].Type)
+	assert.Equal(t, "130014000000003", events[3].Previous.Key)
+	assert.Assert(t, events[3].Cell == nil)
+	assert.Equal(t, "NEIGHBOR_REMOVED", events[3].Type.String())
+
+	table, ok := tables.Table("130014000000001")
+	assert.Assert(t, ok)
+	assert.Equal(t, 2, len(table.Neighbors()))
+	cell, ok := table.Neighbor("130014000000004")
+	assert.Assert(t, ok)
+	assert.Equal(t, Frequency{NR: true, Arfcn: 630000}, cell.Frequency)
+
+	_, err = tables.Update(createIndication(t, 5, 630000, 50))
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(tables.Tables()))
+	tables.Remove("130014000000005")
+	assert.Equal(t, 1, len(tables.Tables()))
+
+	_, err = tables.Update(createIndication(t, 1, 630000, 10, neighbor{2, 630000, 20}, neighbor{2, 630000, 20}))
+	assert.ErrorContains(t, err, "neighbor 1: 130014000000002 is reported more than once")
+	_, err = tables.Update(createIndication(t, 1, 630000, 10, neighbor{1, 630000, 20}))
+	assert.ErrorContains(t, err, "neighbor 0: 130014000000001 is the serving cell")
+	// A rejected indication leaves the table untouched
+	table, _ = tables.Table("130014000000001")
+	assert.Equal(t, int32(11), table.Serving.Pci)
+
+	header, _ := createIndication(t, 1, 630000, 10)
+	message, err := pdubuilder.CreateE2SmRcPreIndicationMsgRicStyleType(1)
+	assert.NilError(t, err)
+	_, err = tables.Update(header, message)
+	assert.ErrorContains(t, err, "unexpected E2SmRcPreIndicationMessage")
+}
+
+func TestTables_Conflicts(t *testing.T) {
+	tables := NewTables()
+
+	// Cell 2 collides with the serving cell, cells 3 and 4 are confused; cell 5 uses another frequency
+	_, err := tables.Update(createIndication(t, 1, 630000, 10,
+		neighbor{2, 630000, 10}, neighbor{3, 630000, 30}, neighbor{4, 630000, 30}, neighbor{5, 640000, 30}))
+	assert.NilError(t, err)
+	_, err = tables.Update(createIndication(t, 6, 630000, 60, neighbor{7, 630000, 70}))
+	assert.NilError(t, err)
+
+	conflicts := tables.Conflicts()
+	assert.Equal(t, 2, len(conflicts))
+	assert.Equal(t, PciCollision, conflicts[0].Type)
+	assert.DeepEqual(t, []string{"130014000000001", "130014000000002"}, conflicts[0].Cells)
+	assert.Equal(t, PciConfusion, conflicts[1].Type)
+	assert.Equal(t, int32(30), conflicts[1].Pci)
+	assert.DeepEqual(t, []string{"130014000000003", "130014000000004"}, conflicts[1].Cells)
+	assert.Equal(t, "PCI_CONFUSION of PCI 30 on NR-ARFCN 630000 in the NRT of 130014000000001: [130014000000003 130014000000004]",
+		conflicts[1].String())
+
+	table, _ := tables.Table("130014000000006")
+	assert.Equal(t, 0, len(table.Conflicts()))
+}
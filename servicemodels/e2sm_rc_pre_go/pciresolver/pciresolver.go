@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pciresolver assigns PCIs to the serving cells of the neighbour relation tables kept by the nrt package
+// so that none of them is in conflict, and builds the E2SM-RC-PRE control messages setting the new PCIs.
+//
+// Two cells on the same frequency must have different PCIs when one is a neighbour of the other (collision)
+// or when both are neighbours of the same cell (confusion). For NR cells, neighbours should also have
+// different PCIs modulo 3 (PSS) and modulo 30 (uplink reference signals); these constraints are optional
+// and only applied when they can be met. Only serving cells are reassigned: a neighbour which is not
+// the serving cell of any table keeps its PCI.
+package pciresolver
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/nrt"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/ranparam"
+	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+)
+
+const (
+	// MaxNrPci is the largest PCI of an NR cell
+	MaxNrPci int32 = 1007
+	// MaxEutraPci is the largest PCI of an E-UTRA cell
+	MaxEutraPci int32 = 503
+)
+
+// Config is the configuration of a Resolver
+type Config struct {
+	// MinPci and MaxPci bound the PCIs which can be assigned. MaxPci is further limited
+	// to MaxEutraPci for E-UTRA cells.
+	MinPci int32
+	MaxPci int32
+	// Mod3 avoids NR neighbours having the same PCI modulo 3
+	Mod3 bool
+	// Mod30 avoids NR neighbours having the same PCI modulo 30
+	Mod30 bool
+	// ControlMessagePriority is set in the header of the control messages
+	ControlMessagePriority int32
+}
+
+// Change is a new PCI assigned to a serving cell, with the control message setting it
+type Change struct {
+	Key     string
+	Cgi     *e2sm_rc_pre_go.CellGlobalId
+	OldPci  int32
+	NewPci  int32
+	Header  *e2sm_rc_pre_go.E2SmRcPreControlHeader
+	Message *e2sm_rc_pre_go.E2SmRcPreControlMessage
+}
+
+// Resolver resolves the PCI conflicts of neighbour relation tables
+type Resolver struct {
+	config Config
+}
+
+// NewResolver creates a Resolver, checking the PCI range of the configuration
+func NewResolver(config Config) (*Resolver, error) {
+	if config.MinPci < 0 || config.MaxPci > MaxNrPci || config.MinPci > config.MaxPci {
+		return nil, fmt.Errorf("NewResolver(): PCI range [%d, %d] is not within [0, %d]", config.MinPci, config.MaxPci, MaxNrPci)
+	}
+	return &Resolver{
+		config: config,
+	}, nil
+}
+
+// cell is a cell of the conflict graph
+type cell struct {
+	key       string
+	cgi       *e2sm_rc_pre_go.CellGlobalId
+	frequency nrt.Frequency
+	pci       int32
+	serving   bool
+	// neighbors are the cells on the same frequency which are neighbours of this one, or whose neighbour this one is
+	neighbors map[string]*cell
+	// confusable are the cells on the same frequency sharing a serving cell with this one
+	confusable map[string]*cell
+}
+
+// score counts the violated constraints of a cell for a PCI: conflicts first, then mod 3 and mod 30
+type score struct {
+	conflicts int
+	mod3      int
+	mod30     int
+}
+
+func (s score) less(other score) bool {
+	if s.conflicts != other.conflicts {
+		return s.conflicts < other.conflicts
+	}
+	if s.mod3 != other.mod3 {
+		return s.mod3 < other.mod3
+	}
+	return s.mod30 < other.mod30
+}
+
+func (s score) zero() bool {
+	return s == score{}
+}
+
+// Resolve assigns new PCIs to serving cells until no conflict is left, and returns the changes ordered by key.
+// A conflicting serving cell keeps its PCI only if no PCI in range is free of conflicts, which is an error.
+// Changes are computed against the tables but not applied to them; the tables are updated by the next
+// indications once the control messages are acknowledged.
+func (r *Resolver) Resolve(tables *nrt.Tables) ([]*Change, error) {
+	cells := r.graph(tables)
+	keys := make([]string, 0, len(cells))
+	original := make(map[string]int32, len(cells))
+	for key, c := range cells {
+		keys = append(keys, key)
+		original[key] = c.pci
+	}
+	sort.Strings(keys)
+
+	// Each reassignment strictly lowers the sum of the scores of all cells, so this terminates
+	for changed := true; changed; {
+		changed = false
+		for _, key := range keys {
+			c := cells[key]
+			if !c.serving {
+				continue
+			}
+			current := r.score(c, c.pci)
+			if current.zero() {
+				continue
+			}
+			best, bestScore := c.pci, current
+			for pci := r.config.MinPci; pci <= r.maxPci(c); pci++ {
+				if s := r.score(c, pci); s.less(bestScore) {
+					best, bestScore = pci, s
+				}
+			}
+			if bestScore.conflicts > 0 {
+				return nil, fmt.Errorf("Resolve(): no PCI in [%d, %d] is free of conflicts for %s", r.config.MinPci, r.maxPci(c), key)
+			}
+			if best != c.pci {
+				c.pci = best
+				changed = true
+			}
+		}
+	}
+
+	changes := make([]*Change, 0)
+	for _, key := range keys {
+		c := cells[key]
+		if c.pci == original[key] {
+			continue
+		}
+		change, err := r.change(c, original[key])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// graph builds the cells of the tables and the constraints between them. A serving cell takes
+// the attributes of its own report over those reported by its neighbours.
+func (r *Resolver) graph(tables *nrt.Tables) map[string]*cell {
+	cells := make(map[string]*cell)
+	get := func(c *nrt.Cell, serving bool) *cell {
+		existing, ok := cells[c.Key]
+		if ok && (existing.serving || !serving) {
+			return existing
+		}
+		if !ok {
+			existing = &cell{
+				neighbors:  make(map[string]*cell),
+				confusable: make(map[string]*cell),
+			}
+			cells[c.Key] = existing
+		}
+		existing.key = c.Key
+		existing.cgi = c.Cgi
+		existing.frequency = c.Frequency
+		existing.pci = c.Pci
+		existing.serving = serving
+		return existing
+	}
+
+	all := tables.Tables()
+	for _, table := range all {
+		get(table.Serving, true)
+	}
+	for _, table := range all {
+		serving := cells[table.Serving.Key]
+		neighbors := make([]*cell, 0)
+		for _, n := range table.Neighbors() {
+			neighbor := get(n, false)
+			if neighbor.frequency != serving.frequency {
+				continue
+			}
+			serving.neighbors[neighbor.key] = neighbor
+			neighbor.neighbors[serving.key] = serving
+			neighbors = append(neighbors, neighbor)
+		}
+		for i, a := range neighbors {
+			for _, b := range neighbors[i+1:] {
+				a.confusable[b.key] = b
+				b.confusable[a.key] = a
+			}
+		}
+	}
+	return cells
+}
+
+// score returns the constraints which a cell would violate with a PCI
+func (r *Resolver) score(c *cell, pci int32) score {
+	s := score{}
+	for _, other := range c.confusable {
+		if other.pci == pci {
+			s.conflicts++
+		}
+	}
+	for _, other := range c.neighbors {
+		if other.pci == pci {
+			s.conflicts++
+			continue
+		}
+		if !c.frequency.NR {
+			continue
+		}
+		if r.config.Mod3 && other.pci%3 == pci%3 {
+			s.mod3++
+		}
+		if r.config.Mod30 && other.pci%30 == pci%30 {
+			s.mod30++
+		}
+	}
+	return s
+}
+
+func (r *Resolver) maxPci(c *cell) int32 {
+	if !c.frequency.NR && r.config.MaxPci > MaxEutraPci {
+		return MaxEutraPci
+	}
+	return r.config.MaxPci
+}
+
+func (r *Resolver) change(c *cell, oldPci int32) (*Change, error) {
+	header, message, err := ranparam.SetPCI(c.cgi, c.pci)
+	if err != nil {
+		return nil, err
+	}
+	header.GetControlHeaderFormat1().SetRicControlMessagePriority(r.config.ControlMessagePriority)
+	return &Change{
+		Key:     c.key,
+		Cgi:     c.cgi,
+		OldPci:  oldPci,
+		NewPci:  c.pci,
+		Header:  header,
+		Message: message,
+	}, nil
+}
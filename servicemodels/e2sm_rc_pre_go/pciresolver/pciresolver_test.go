@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pciresolver
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/nrt"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/pdubuilder"
+	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	"gotest.tools/assert"
+)
+
+var plmnID = []byte{0x13, 0x00, 0x14}
+
+type neighbor struct {
+	nci   uint64
+	arfcn int32
+	pci   int32
+}
+
+func update(t *testing.T, tables *nrt.Tables, nci uint64, arfcn int32, pci int32, neighbors ...neighbor) {
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCgiFromNci(plmnID, nci)
+	assert.NilError(t, err)
+	header, err := pdubuilder.CreateE2SmRcPreIndicationHeader(cgi)
+	assert.NilError(t, err)
+	nrts := make([]*e2sm_rc_pre_go.Nrt, 0, len(neighbors))
+	for _, n := range neighbors {
+		cgi, err := pdubuilder.CreateCellGlobalIDNrCgiFromNci(plmnID, n.nci)
+		assert.NilError(t, err)
+		nrt, err := pdubuilder.CreateNrt(cgi, pdubuilder.CreateNrArfcn(n.arfcn), e2sm_rc_pre_go.CellSize_CELL_SIZE_MACRO, &e2sm_rc_pre_go.Pci{Value: n.pci})
+		assert.NilError(t, err)
+		nrts = append(nrts, nrt)
+	}
+	message, err := pdubuilder.CreateE2SmRcPreIndicationMsgFormat1(plmnID, pdubuilder.CreateNrArfcn(arfcn), e2sm_rc_pre_go.CellSize_CELL_SIZE_MACRO, pci, nrts)
+	assert.NilError(t, err)
+	_, err = tables.Update(header, message)
+	assert.NilError(t, err)
+}
+
+func TestNewResolver(t *testing.T) {
+	_, err := NewResolver(Config{MinPci: 0, MaxPci: MaxNrPci})
+	assert.NilError(t, err)
+	_, err = NewResolver(Config{MinPci: 10, MaxPci: 9})
+	assert.ErrorContains(t, err, "PCI range [10, 9]")
+	_, err = NewResolver(Config{MinPci: 0, MaxPci: 1008})
+	assert.ErrorContains(t, err, "is not within [0, 1007]")
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	tables := tablesWithConflicts(t)
+	resolver, err := NewResolver(Config{MinPci: 10, MaxPci: 20, ControlMessagePriority: 2})
+	assert.NilError(t, err)
+
+	changes, err := resolver.Resolve(tables)
+	assert.NilError(t, err)
+	// Cell 1 collides with cell 2, which is resolved by the first serving cell in key order.
+	// Cell 3 confuses with cell 4 in the NRT of cell 1, but only cell 3 is a serving cell.
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, "130014000000001", changes[0].Key)
+	assert.Equal(t, int32(10), changes[0].OldPci)
+	assert.Equal(t, int32(11), changes[0].NewPci)
+	assert.Equal(t, "130014000000003", changes[1].Key)
+	assert.Equal(t, int32(12), changes[1].OldPci)
+	assert.Equal(t, int32(13), changes[1].NewPci)
+
+	header := changes[0].Header.GetControlHeaderFormat1()
+	assert.Equal(t, e2sm_rc_pre_go.RcPreCommand_RC_PRE_COMMAND_SET_PARAMETERS, header.GetRcCommand())
+	assert.Equal(t, int32(2), header.GetRicControlMessagePriority().GetValue())
+	key, err := nrt.CgiKey(header.GetCgi())
+	assert.NilError(t, err)
+	assert.Equal(t, "130014000000001", key)
+	message := changes[0].Message.GetControlMessage()
+	assert.Equal(t, int32(1), message.GetParameterType().GetRanParameterId().GetValue())
+	assert.Equal(t, "PCI", message.GetParameterType().GetRanParameterName().GetValue())
+	assert.Equal(t, e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_INTEGER, message.GetParameterType().GetRanParameterType())
+	assert.Equal(t, int64(11), message.GetParameterVal().GetValueInt())
+
+	// Resolving again the same tables gives the same changes
+	again, err := resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, len(changes), len(again))
+	for i := range changes {
+		assert.Equal(t, changes[i].Key, again[i].Key)
+		assert.Equal(t, changes[i].NewPci, again[i].NewPci)
+	}
+}
+
+// tablesWithConflicts creates three serving cells on the same frequency:
+// cell 1 (PCI 10) sees 2 (PCI 10), 3 (PCI 12) and 4 (PCI 12); 2 sees 1; 3 sees 1.
+func tablesWithConflicts(t *testing.T) *nrt.Tables {
+	tables := nrt.NewTables()
+	update(t, tables, 1, 630000, 10, neighbor{2, 630000, 10}, neighbor{3, 630000, 12}, neighbor{4, 630000, 12})
+	update(t, tables, 2, 630000, 10, neighbor{1, 630000, 10})
+	update(t, tables, 3, 630000, 12, neighbor{1, 630000, 10})
+	return tables
+}
+
+func TestResolver_ResolveFrequencies(t *testing.T) {
+	tables := nrt.NewTables()
+	update(t, tables, 1, 630000, 10, neighbor{2, 630000, 10}, neighbor{3, 640000, 11})
+	resolver, err := NewResolver(Config{MinPci: 0, MaxPci: MaxNrPci})
+	assert.NilError(t, err)
+
+	// Cell 2 is on the same frequency but is not a serving cell, so cell 1 moves
+	changes, err := resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, int32(0), changes[0].NewPci)
+
+	// Neighbours on other frequencies do not constrain the serving cell
+	tables = nrt.NewTables()
+	update(t, tables, 1, 630000, 10, neighbor{2, 640000, 10})
+	changes, err = resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(changes))
+}
+
+func TestResolver_ResolveModulo(t *testing.T) {
+	tables := nrt.NewTables()
+	update(t, tables, 1, 630000, 10, neighbor{2, 630000, 13}, neighbor{3, 630000, 41})
+	resolver, err := NewResolver(Config{MinPci: 0, MaxPci: MaxNrPci})
+	assert.NilError(t, err)
+	changes, err := resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(changes))
+
+	// 10 and 13 are the same modulo 3: 0 is the first PCI differing modulo 3 from 13 and 41
+	resolver, err = NewResolver(Config{MinPci: 0, MaxPci: MaxNrPci, Mod3: true})
+	assert.NilError(t, err)
+	changes, err = resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, int32(0), changes[0].NewPci)
+
+	// 41 and 11 are the same modulo 30: 12 differs from 13 and 41 modulo 3 and 30
+	resolver, err = NewResolver(Config{MinPci: 11, MaxPci: MaxNrPci, Mod3: true, Mod30: true})
+	assert.NilError(t, err)
+	changes, err = resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, int32(12), changes[0].NewPci)
+
+	// Modulo constraints which cannot be met are not an error
+	resolver, err = NewResolver(Config{MinPci: 10, MaxPci: 10, Mod3: true})
+	assert.NilError(t, err)
+	changes, err = resolver.Resolve(tables)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(changes))
+}
+
+func TestResolver_ResolveExhausted(t *testing.T) {
+	tables := nrt.NewTables()
+	update(t, tables, 1, 630000, 10, neighbor{2, 630000, 10}, neighbor{3, 630000, 11})
+	resolver, err := NewResolver(Config{MinPci: 10, MaxPci: 11})
+	assert.NilError(t, err)
+	_, err = resolver.Resolve(tables)
+	assert.ErrorContains(t, err, "no PCI in [10, 11] is free of conflicts for 130014000000001")
+}
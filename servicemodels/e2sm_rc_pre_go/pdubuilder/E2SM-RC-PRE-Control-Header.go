@@ -5,6 +5,8 @@ package pdubuilder
 
 import (
 	"fmt"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 )
@@ -82,3 +84,31 @@ func CreateCellGlobalIDNrCgi(plmnIDBytes []byte, cellID *asn1.BitString) (*e2sm_
 	//}
 	return &cgi, nil
 }
+
+// CreateCellGlobalIDEUTRACGIFromEci creates an E-UTRA CGI out of a 28 bit ECI. Unlike CreateCellGlobalIDEUTRACGI,
+// it checks that the PLMN ID is BCD encoded.
+func CreateCellGlobalIDEUTRACGIFromEci(plmnIDBytes plmnid.PlmnID, eci uint32) (*e2sm_rc_pre_go.CellGlobalId, error) {
+
+	if err := plmnIDBytes.Validate(); err != nil {
+		return nil, err
+	}
+	cellID, err := cellid.EciToBitString(eci)
+	if err != nil {
+		return nil, err
+	}
+	return CreateCellGlobalIDEUTRACGI(plmnIDBytes, cellID)
+}
+
+// CreateCellGlobalIDNrCgiFromNci creates an NR CGI out of a 36 bit NCI. Unlike CreateCellGlobalIDNrCgi, it checks
+// that the PLMN ID is BCD encoded.
+func CreateCellGlobalIDNrCgiFromNci(plmnIDBytes plmnid.PlmnID, nci uint64) (*e2sm_rc_pre_go.CellGlobalId, error) {
+
+	if err := plmnIDBytes.Validate(); err != nil {
+		return nil, err
+	}
+	cellID, err := cellid.NciToBitString(nci)
+	if err != nil {
+		return nil, err
+	}
+	return CreateCellGlobalIDNrCgi(plmnIDBytes, cellID)
+}
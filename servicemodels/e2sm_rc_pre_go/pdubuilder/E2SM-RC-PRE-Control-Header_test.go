@@ -7,6 +7,7 @@ package pdubuilder
 import (
 	"encoding/hex"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/encoder"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 	"gotest.tools/assert"
 	"testing"
@@ -81,3 +82,16 @@ func TestE2SmRcPreControlHeaderExcludeAllOptionalFields(t *testing.T) {
 	t.Logf("PER ControlHeader - decoded \n%v", resultPer)
 	assert.Equal(t, newE2SmRcPrePdu.String(), resultPer.String())
 }
+
+func TestCreateCellGlobalIDFromNci(t *testing.T) {
+	plmnID, err := plmnid.FromMCCMNC("001", "01")
+	assert.NilError(t, err)
+
+	cgi, err := CreateCellGlobalIDNrCgiFromNci(plmnID, 0x9bcd4abef)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x00, 0xf1, 0x10}, cgi.GetNrCgi().GetPLmnIdentity().GetValue())
+	assert.DeepEqual(t, []byte{0x9b, 0xcd, 0x4a, 0xbe, 0xf0}, cgi.GetNrCgi().GetNRcellIdentity().GetValue().GetValue())
+
+	_, err = CreateCellGlobalIDNrCgiFromNci(plmnid.PlmnID{0x00, 0x01, 0x0F}, 0x9bcd4abef)
+	assert.ErrorContains(t, err, "not BCD encoded")
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ranparam is a catalogue of the RAN parameters known to E2SM-RC-PRE control messages, with typed
+// constructors of the control messages setting them and their validation against RANparameterDef-Item lists.
+//
+// The RAN function description of E2SM-RC-PRE v2 does not carry the RANparameterDef-Item list supported by
+// the node, so the list to validate against is provided by the caller, e.g. from the node configuration.
+package ranparam
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/pdubuilder"
+	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+)
+
+// Parameter is a RAN parameter of the catalogue. Min and Max bound integer and enumerated values.
+type Parameter struct {
+	ID   int32
+	Name string
+	Type e2sm_rc_pre_go.RanparameterType
+	Min  int64
+	Max  int64
+}
+
+// PCI is the physical cell identity of the cell of the control header, 0..1007 (NR) or 0..503 (E-UTRA)
+var PCI = &Parameter{
+	ID:   1,
+	Name: "PCI",
+	Type: e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_INTEGER,
+	Min:  0,
+	Max:  1007,
+}
+
+var catalogue = map[int32]*Parameter{
+	PCI.ID: PCI,
+}
+
+// Parameters returns the catalogue, ordered by ID
+func Parameters() []*Parameter {
+	parameters := make([]*Parameter, 0, len(catalogue))
+	for _, p := range catalogue {
+		parameters = append(parameters, p)
+	}
+	sort.Slice(parameters, func(i, j int) bool {
+		return parameters[i].ID < parameters[j].ID
+	})
+	return parameters
+}
+
+// Lookup returns the parameter of the catalogue with the given ID
+func Lookup(id int32) (*Parameter, bool) {
+	p, ok := catalogue[id]
+	return p, ok
+}
+
+// DefItem returns the RANparameterDef-Item of the parameter
+func (p *Parameter) DefItem() *e2sm_rc_pre_go.RanparameterDefItem {
+	return &e2sm_rc_pre_go.RanparameterDefItem{
+		RanParameterId: &e2sm_rc_pre_go.RanparameterId{
+			Value: p.ID,
+		},
+		RanParameterName: &e2sm_rc_pre_go.RanparameterName{
+			Value: p.Name,
+		},
+		RanParameterType: p.Type,
+	}
+}
+
+// CheckValue checks that a value has the type of the parameter and is within its range
+func (p *Parameter) CheckValue(value *e2sm_rc_pre_go.RanparameterValue) error {
+	valueType, err := TypeOf(value)
+	if err != nil {
+		return err
+	}
+	if valueType != p.Type {
+		return fmt.Errorf("RAN parameter %s (%d) is %v, got %v", p.Name, p.ID, p.Type, valueType)
+	}
+	var v int64
+	switch val := value.GetRanparameterValue().(type) {
+	case *e2sm_rc_pre_go.RanparameterValue_ValueInt:
+		v = val.ValueInt
+	case *e2sm_rc_pre_go.RanparameterValue_ValueEnum:
+		v = int64(val.ValueEnum)
+	default:
+		return nil
+	}
+	if v < p.Min || v > p.Max {
+		return fmt.Errorf("RAN parameter %s (%d) should be within range %d to %d, got %d", p.Name, p.ID, p.Min, p.Max, v)
+	}
+	return nil
+}
+
+// CreateControlMessage creates the control message setting the parameter to a value, after checking it
+func (p *Parameter) CreateControlMessage(value *e2sm_rc_pre_go.RanparameterValue) (*e2sm_rc_pre_go.E2SmRcPreControlMessage, error) {
+	if err := p.CheckValue(value); err != nil {
+		return nil, err
+	}
+	return pdubuilder.CreateE2SmRcPreControlMessage(p.ID, p.Name, value)
+}
+
+// SetPCI creates the control header and message setting the PCI of a cell
+func SetPCI(cgi *e2sm_rc_pre_go.CellGlobalId, pci int32) (*e2sm_rc_pre_go.E2SmRcPreControlHeader, *e2sm_rc_pre_go.E2SmRcPreControlMessage, error) {
+	if cgi == nil {
+		return nil, nil, fmt.Errorf("SetPCI(): CellGlobalId is missing")
+	}
+	if _, ok := cgi.GetCellGlobalId().(*e2sm_rc_pre_go.CellGlobalId_EUtraCgi); ok && pci > 503 {
+		return nil, nil, fmt.Errorf("SetPCI(): PCI of an E-UTRA cell should be within range 0 to 503, got %d", pci)
+	}
+	value, err := pdubuilder.CreateRanParameterValueInt(int64(pci))
+	if err != nil {
+		return nil, nil, fmt.Errorf("SetPCI(): %v", err)
+	}
+	message, err := PCI.CreateControlMessage(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SetPCI(): %v", err)
+	}
+	header, err := pdubuilder.CreateE2SmRcPreControlHeader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("SetPCI(): %v", err)
+	}
+	header.GetControlHeaderFormat1().SetCGI(cgi)
+	return header, message, nil
+}
+
+// TypeOf returns the RanparameterType of a value
+func TypeOf(value *e2sm_rc_pre_go.RanparameterValue) (e2sm_rc_pre_go.RanparameterType, error) {
+	switch value.GetRanparameterValue().(type) {
+	case *e2sm_rc_pre_go.RanparameterValue_ValueInt:
+		return e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_INTEGER, nil
+	case *e2sm_rc_pre_go.RanparameterValue_ValueEnum:
+		return e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_ENUMERATED, nil
+	case *e2sm_rc_pre_go.RanparameterValue_ValueBool:
+		return e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_BOOLEAN, nil
+	case *e2sm_rc_pre_go.RanparameterValue_ValueBitS:
+		return e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_BIT_STRING, nil
+	case *e2sm_rc_pre_go.RanparameterValue_ValueOctS:
+		return e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_OCTET_STRING, nil
+	case *e2sm_rc_pre_go.RanparameterValue_ValuePrtS:
+		return e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_PRINTABLE_STRING, nil
+	default:
+		return 0, fmt.Errorf("unexpected RanparameterValue %v", value)
+	}
+}
+
+// Validate checks a control message against the RANparameterDef-Item list supported by a node:
+// the parameter must be listed with the same name and type as in the message, and its value must
+// have that type. Parameters of the catalogue are also checked against their range.
+func Validate(message *e2sm_rc_pre_go.E2SmRcPreControlMessage, defs []*e2sm_rc_pre_go.RanparameterDefItem) error {
+	format1 := message.GetControlMessage()
+	if format1 == nil {
+		return fmt.Errorf("Validate(): unexpected E2SmRcPreControlMessage %v", message)
+	}
+	paramType := format1.GetParameterType()
+	id := paramType.GetRanParameterId().GetValue()
+	name := paramType.GetRanParameterName().GetValue()
+
+	var def *e2sm_rc_pre_go.RanparameterDefItem
+	for _, d := range defs {
+		if d.GetRanParameterId().GetValue() == id {
+			def = d
+			break
+		}
+	}
+	if def == nil {
+		return fmt.Errorf("Validate(): RAN parameter %s (%d) is not supported", name, id)
+	}
+	if def.GetRanParameterName().GetValue() != name {
+		return fmt.Errorf("Validate(): RAN parameter %d is %s, got %s", id, def.GetRanParameterName().GetValue(), name)
+	}
+	if def.GetRanParameterType() != paramType.GetRanParameterType() {
+		return fmt.Errorf("Validate(): RAN parameter %s (%d) is %v, got %v", name, id, def.GetRanParameterType(), paramType.GetRanParameterType())
+	}
+
+	valueType, err := TypeOf(format1.GetParameterVal())
+	if err != nil {
+		return fmt.Errorf("Validate(): %v", err)
+	}
+	if valueType != paramType.GetRanParameterType() {
+		return fmt.Errorf("Validate(): RAN parameter %s (%d) is %v, got a value of %v", name, id, paramType.GetRanParameterType(), valueType)
+	}
+	if p, ok := Lookup(id); ok && p.Name == name {
+		if err := p.CheckValue(format1.GetParameterVal()); err != nil {
+			return fmt.Errorf("Validate(): %v", err)
+		}
+	}
+	return nil
+}
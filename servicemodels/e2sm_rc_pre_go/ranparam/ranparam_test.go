@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ranparam
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/encoder"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/pdubuilder"
+	e2sm_rc_pre_go "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	"gotest.tools/assert"
+)
+
+var plmnID = []byte{0x13, 0x00, 0x14}
+
+func TestCatalogue(t *testing.T) {
+	parameters := Parameters()
+	assert.Equal(t, 1, len(parameters))
+	assert.Equal(t, PCI, parameters[0])
+
+	p, ok := Lookup(1)
+	assert.Assert(t, ok)
+	assert.Equal(t, "PCI", p.Name)
+	_, ok = Lookup(2)
+	assert.Assert(t, !ok)
+
+	def := PCI.DefItem()
+	assert.Equal(t, int32(1), def.GetRanParameterId().GetValue())
+	assert.Equal(t, "PCI", def.GetRanParameterName().GetValue())
+	assert.Equal(t, e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_INTEGER, def.GetRanParameterType())
+}
+
+func TestParameter_CreateControlMessage(t *testing.T) {
+	value, err := pdubuilder.CreateRanParameterValueInt(1007)
+	assert.NilError(t, err)
+	message, err := PCI.CreateControlMessage(value)
+	assert.NilError(t, err)
+	// Same message as built by hand with CreateE2SmRcPreControlMessage
+	expected, err := pdubuilder.CreateE2SmRcPreControlMessage(1, "PCI", value)
+	assert.NilError(t, err)
+	assert.Equal(t, expected.String(), message.String())
+
+	value, err = pdubuilder.CreateRanParameterValueInt(1008)
+	assert.NilError(t, err)
+	_, err = PCI.CreateControlMessage(value)
+	assert.ErrorContains(t, err, "should be within range 0 to 1007, got 1008")
+
+	_, err = PCI.CreateControlMessage(pdubuilder.CreateRanParameterValueEnum(10))
+	assert.ErrorContains(t, err, "RAN parameter PCI (1) is RANPARAMETER_TYPE_INTEGER, got RANPARAMETER_TYPE_ENUMERATED")
+}
+
+func TestSetPCI(t *testing.T) {
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCgiFromNci(plmnID, 1)
+	assert.NilError(t, err)
+	header, message, err := SetPCI(cgi, 42)
+	assert.NilError(t, err)
+	assert.Equal(t, e2sm_rc_pre_go.RcPreCommand_RC_PRE_COMMAND_SET_PARAMETERS, header.GetControlHeaderFormat1().GetRcCommand())
+	assert.Equal(t, cgi.String(), header.GetControlHeaderFormat1().GetCgi().String())
+	assert.Equal(t, int64(42), message.GetControlMessage().GetParameterVal().GetValueInt())
+
+	_, err = encoder.PerEncodeE2SmRcPreControlHeader(header)
+	assert.NilError(t, err)
+	_, err = encoder.PerEncodeE2SmRcPreControlMessage(message)
+	assert.NilError(t, err)
+
+	_, _, err = SetPCI(cgi, -1)
+	assert.ErrorContains(t, err, "SetPCI()")
+	_, _, err = SetPCI(nil, 42)
+	assert.ErrorContains(t, err, "CellGlobalId is missing")
+
+	ecgi, err := pdubuilder.CreateCellGlobalIDEUTRACGIFromEci(plmnID, 1)
+	assert.NilError(t, err)
+	_, _, err = SetPCI(ecgi, 503)
+	assert.NilError(t, err)
+	_, _, err = SetPCI(ecgi, 504)
+	assert.ErrorContains(t, err, "E-UTRA cell should be within range 0 to 503")
+}
+
+func TestValidate(t *testing.T) {
+	cgi, err := pdubuilder.CreateCellGlobalIDNrCgiFromNci(plmnID, 1)
+	assert.NilError(t, err)
+	_, message, err := SetPCI(cgi, 42)
+	assert.NilError(t, err)
+
+	defs := []*e2sm_rc_pre_go.RanparameterDefItem{PCI.DefItem()}
+	assert.NilError(t, Validate(message, defs))
+
+	err = Validate(message, []*e2sm_rc_pre_go.RanparameterDefItem{})
+	assert.ErrorContains(t, err, "RAN parameter PCI (1) is not supported")
+
+	renamed := PCI.DefItem()
+	renamed.RanParameterName.Value = "pci"
+	err = Validate(message, []*e2sm_rc_pre_go.RanparameterDefItem{renamed})
+	assert.ErrorContains(t, err, "RAN parameter 1 is pci, got PCI")
+
+	retyped := PCI.DefItem()
+	retyped.RanParameterType = e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_ENUMERATED
+	err = Validate(message, []*e2sm_rc_pre_go.RanparameterDefItem{retyped})
+	assert.ErrorContains(t, err, "is RANPARAMETER_TYPE_ENUMERATED, got RANPARAMETER_TYPE_INTEGER")
+
+	// A message built by hand with an out of range PCI
+	value, err := pdubuilder.CreateRanParameterValueInt(2000)
+	assert.NilError(t, err)
+	message, err = pdubuilder.CreateE2SmRcPreControlMessage(1, "PCI", value)
+	assert.NilError(t, err)
+	err = Validate(message, defs)
+	assert.ErrorContains(t, err, "should be within range 0 to 1007, got 2000")
+
+	// Parameters outside the catalogue are only checked against the list
+	other := &Parameter{ID: 10, Name: "txPower", Type: e2sm_rc_pre_go.RanparameterType_RANPARAMETER_TYPE_BOOLEAN}
+	message, err = pdubuilder.CreateE2SmRcPreControlMessage(other.ID, other.Name, pdubuilder.CreateRanParameterValueBool(true))
+	assert.NilError(t, err)
+	assert.NilError(t, Validate(message, []*e2sm_rc_pre_go.RanparameterDefItem{PCI.DefItem(), other.DefItem()}))
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package capability checks E2SM-RSM control messages against the slicing capabilities
+// an E2 node advertises in its RAN function description, before the messages are dispatched.
+package capability
+
+import (
+	"fmt"
+
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+)
+
+// Fields of the capability or of the control message a Violation refers to
+const (
+	FieldRsmCommand          = "RsmCommand"
+	FieldSupportedConfig     = "SupportedConfig"
+	FieldSlicingType         = "SlicingType"
+	FieldSchedulerType       = "SchedulerType"
+	FieldMaxNumberOfSlicesDl = "MaxNumberOfSlicesDl"
+	FieldMaxNumberOfSlicesUl = "MaxNumberOfSlicesUl"
+)
+
+// Violation is one reason why a node does not permit a control message
+type Violation struct {
+	// NodeIndex is the index of the NodeSlicingCapabilityItem in the RicSlicingNodeCapabilityList
+	NodeIndex int
+	// Field is the capability or control message field that is violated, one of the Field constants
+	Field   string
+	Message string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("slicing node %d: %s: %s", v.NodeIndex, v.Field, v.Message)
+}
+
+// Usage is the number of slices already configured on the node
+type Usage struct {
+	DlSlices int
+	UlSlices int
+}
+
+// Check checks a control message against every slicing node of a RAN function description.
+// It returns no violations if at least one slicing node permits the message, and the violations of all nodes otherwise.
+func Check(description *e2sm_rsm_ies.E2SmRsmRanfunctionDescription, header *e2sm_rsm_ies.E2SmRsmControlHeader,
+	message *e2sm_rsm_ies.E2SmRsmControlMessage, usage Usage) ([]*Violation, error) {
+	capabilities := description.GetRicSlicingNodeCapabilityList()
+	if len(capabilities) == 0 {
+		return nil, fmt.Errorf("E2SmRsmRanfunctionDescription has no RicSlicingNodeCapabilityList")
+	}
+
+	violations := make([]*Violation, 0)
+	for i, capability := range capabilities {
+		nodeViolations, err := CheckItem(capability, header, message, usage)
+		if err != nil {
+			return nil, err
+		}
+		if len(nodeViolations) == 0 {
+			return nil, nil
+		}
+		for _, v := range nodeViolations {
+			v.NodeIndex = i
+		}
+		violations = append(violations, nodeViolations...)
+	}
+	return violations, nil
+}
+
+// CheckItem checks a control message against the capability of one slicing node
+func CheckItem(capability *e2sm_rsm_ies.NodeSlicingCapabilityItem, header *e2sm_rsm_ies.E2SmRsmControlHeader,
+	message *e2sm_rsm_ies.E2SmRsmControlMessage, usage Usage) ([]*Violation, error) {
+	command, err := MessageCommand(message)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]*Violation, 0)
+	if header.GetRsmCommand() != command {
+		violations = append(violations, &Violation{
+			Field:   FieldRsmCommand,
+			Message: fmt.Sprintf("control header command %v does not match control message %v", header.GetRsmCommand(), command),
+		})
+	}
+	if !supports(capability, command) {
+		violations = append(violations, &Violation{
+			Field:   FieldSupportedConfig,
+			Message: fmt.Sprintf("command %v is not in the supported slicing configurations of the node", command),
+		})
+	}
+
+	var config *e2sm_rsm_ies.SliceConfig
+	switch msg := message.GetE2SmRsmControlMessage().(type) {
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceCreate:
+		config = msg.SliceCreate
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceUpdate:
+		config = msg.SliceUpdate
+	}
+
+	if command != e2sm_rsm_ies.E2SmRsmCommand_E2_SM_RSM_COMMAND_UE_ASSOCIATE &&
+		capability.GetSlicingType() == e2sm_rsm_ies.SlicingType_SLICING_TYPE_STATIC {
+		violations = append(violations, &Violation{
+			Field:   FieldSlicingType,
+			Message: fmt.Sprintf("command %v cannot be applied to a node with %v", command, capability.GetSlicingType()),
+		})
+	}
+
+	if config != nil {
+		violations = append(violations, checkSchedulerType(config.GetSliceConfigParameters())...)
+	}
+
+	if command == e2sm_rsm_ies.E2SmRsmCommand_E2_SM_RSM_COMMAND_SLICE_CREATE {
+		if config.GetSliceType() == e2sm_rsm_ies.SliceType_SLICE_TYPE_UL_SLICE {
+			if max := capability.GetMaxNumberOfSlicesUl(); max > 0 && usage.UlSlices >= int(max) {
+				violations = append(violations, &Violation{
+					Field:   FieldMaxNumberOfSlicesUl,
+					Message: fmt.Sprintf("node already has the maximum number of %d %v slices", max, config.GetSliceType()),
+				})
+			}
+		} else {
+			if max := capability.GetMaxNumberOfSlicesDl(); max > 0 && usage.DlSlices >= int(max) {
+				violations = append(violations, &Violation{
+					Field:   FieldMaxNumberOfSlicesDl,
+					Message: fmt.Sprintf("node already has the maximum number of %d %v slices", max, config.GetSliceType()),
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// MessageCommand returns the E2SmRsmCommand matching a control message
+func MessageCommand(message *e2sm_rsm_ies.E2SmRsmControlMessage) (e2sm_rsm_ies.E2SmRsmCommand, error) {
+	switch message.GetE2SmRsmControlMessage().(type) {
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceCreate:
+		return e2sm_rsm_ies.E2SmRsmCommand_E2_SM_RSM_COMMAND_SLICE_CREATE, nil
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceUpdate:
+		return e2sm_rsm_ies.E2SmRsmCommand_E2_SM_RSM_COMMAND_SLICE_UPDATE, nil
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceDelete:
+		return e2sm_rsm_ies.E2SmRsmCommand_E2_SM_RSM_COMMAND_SLICE_DELETE, nil
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceAssociate:
+		return e2sm_rsm_ies.E2SmRsmCommand_E2_SM_RSM_COMMAND_UE_ASSOCIATE, nil
+	}
+	return 0, fmt.Errorf("unexpected E2SmRsmControlMessage %v", message)
+}
+
+func supports(capability *e2sm_rsm_ies.NodeSlicingCapabilityItem, command e2sm_rsm_ies.E2SmRsmCommand) bool {
+	for _, item := range capability.GetSupportedConfig() {
+		if item.GetSlicingConfigType() == command {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSchedulerType checks that the scheduler is known and carries the parameters it needs:
+// the RAN function description does not advertise the schedulers a node supports
+func checkSchedulerType(parameters *e2sm_rsm_ies.SliceParameters) []*Violation {
+	schedulerType := parameters.GetSchedulerType()
+	if _, ok := e2sm_rsm_ies.SchedulerType_name[int32(schedulerType)]; !ok {
+		return []*Violation{{
+			Field:   FieldSchedulerType,
+			Message: fmt.Sprintf("unknown scheduler type %d", schedulerType),
+		}}
+	}
+	if schedulerType == e2sm_rsm_ies.SchedulerType_SCHEDULER_TYPE_QOS_BASED && parameters.QosLevel == nil {
+		return []*Violation{{
+			Field:   FieldSchedulerType,
+			Message: fmt.Sprintf("%v requires a QoS level", schedulerType),
+		}}
+	}
+	return nil
+}
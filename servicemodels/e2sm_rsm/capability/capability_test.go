@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package capability
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/pdubuilder"
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+	"gotest.tools/assert"
+)
+
+func createCapability(t *testing.T, slicingType e2sm_rsm_ies.SlicingType, commands ...e2sm_rsm_ies.E2SmRsmCommand) *e2sm_rsm_ies.NodeSlicingCapabilityItem {
+	supportedConfig := make([]*e2sm_rsm_ies.SupportedSlicingConfigItem, 0)
+	for _, command := range commands {
+		supportedConfig = append(supportedConfig, pdubuilder.CreateSupportedSlicingConfigItem(command))
+	}
+	capability, err := pdubuilder.CreateSlicingCapabilityItem(4, 2, slicingType, 10, supportedConfig)
+	assert.NilError(t, err)
+	return capability
+}
+
+func fields(violations []*Violation) []string {
+	result := make([]string, 0, len(violations))
+	for _, v := range violations {
+		result = append(result, v.Field)
+	}
+	return result
+}
+
+func TestCheckItem(t *testing.T) {
+	dynamic := createCapability(t, pdubuilder.CreateSlicingTypeDynamic(),
+		pdubuilder.CreateE2SmRsmCommandSliceCreate(), pdubuilder.CreateE2SmRsmCommandUeAssociate())
+	createHeader := pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceCreate())
+	createDl := pdubuilder.CreateE2SmRsmControlMessageSliceCreate(pdubuilder.CreateSliceConfig(1,
+		pdubuilder.CreateSliceParameters(pdubuilder.CreateSchedulerTypeRoundRobin()), pdubuilder.CreateSliceTypeDL()))
+
+	violations, err := CheckItem(dynamic, createHeader, createDl, Usage{DlSlices: 3})
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(violations))
+
+	violations, err = CheckItem(dynamic, createHeader, createDl, Usage{DlSlices: 4})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{FieldMaxNumberOfSlicesDl}, fields(violations))
+	assert.ErrorContains(t, violations[0], "slicing node 0: MaxNumberOfSlicesDl: node already has the maximum number of 4")
+
+	createUl := pdubuilder.CreateE2SmRsmControlMessageSliceCreate(pdubuilder.CreateSliceConfig(1,
+		pdubuilder.CreateSliceParameters(pdubuilder.CreateSchedulerTypeQosBased()), pdubuilder.CreateSliceTypeUL()))
+	violations, err = CheckItem(dynamic, createHeader, createUl, Usage{DlSlices: 4, UlSlices: 2})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{FieldSchedulerType, FieldMaxNumberOfSlicesUl}, fields(violations))
+	assert.ErrorContains(t, violations[0], "SCHEDULER_TYPE_QOS_BASED requires a QoS level")
+
+	updateHeader := pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceUpdate())
+	update := pdubuilder.CreateE2SmRsmControlMessageSliceUpdate(pdubuilder.CreateSliceConfig(1,
+		pdubuilder.CreateSliceParameters(pdubuilder.CreateSchedulerTypeQosBased()).SetQoSLevel(3), pdubuilder.CreateSliceTypeDL()))
+	violations, err = CheckItem(dynamic, createHeader, update, Usage{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{FieldRsmCommand, FieldSupportedConfig}, fields(violations))
+
+	static := createCapability(t, pdubuilder.CreateSlicingTypeStatic(),
+		pdubuilder.CreateE2SmRsmCommandSliceUpdate(), pdubuilder.CreateE2SmRsmCommandUeAssociate())
+	violations, err = CheckItem(static, updateHeader, update, Usage{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{FieldSlicingType}, fields(violations))
+
+	_, err = CheckItem(static, updateHeader, &e2sm_rsm_ies.E2SmRsmControlMessage{}, Usage{})
+	assert.ErrorContains(t, err, "unexpected E2SmRsmControlMessage")
+}
+
+func TestCheck(t *testing.T) {
+	static := createCapability(t, pdubuilder.CreateSlicingTypeStatic(), pdubuilder.CreateE2SmRsmCommandUeAssociate())
+	dynamic := createCapability(t, pdubuilder.CreateSlicingTypeDynamic(), pdubuilder.CreateE2SmRsmCommandSliceDelete())
+	header := pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceDelete())
+	message := pdubuilder.CreateE2SmRsmControlMessageSliceDelete(1, pdubuilder.CreateSliceTypeDL())
+
+	// The dynamic slicing node permits the message
+	description := pdubuilder.CreateE2SmRsmRanFunctionDescription("ORAN-E2SM-RSM", "1.3.6.1.4.1.53148.1.1.2.102", "RAN Slicing",
+		[]*e2sm_rsm_ies.NodeSlicingCapabilityItem{static, dynamic})
+	violations, err := Check(description, header, message, Usage{})
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(violations))
+
+	description = pdubuilder.CreateE2SmRsmRanFunctionDescription("ORAN-E2SM-RSM", "1.3.6.1.4.1.53148.1.1.2.102", "RAN Slicing",
+		[]*e2sm_rsm_ies.NodeSlicingCapabilityItem{static, static})
+	violations, err = Check(description, header, message, Usage{})
+	assert.NilError(t, err)
+	assert.Equal(t, 4, len(violations))
+	assert.Equal(t, 0, violations[0].NodeIndex)
+	assert.Equal(t, 1, violations[3].NodeIndex)
+
+	_, err = Check(&e2sm_rsm_ies.E2SmRsmRanfunctionDescription{}, header, message, Usage{})
+	assert.ErrorContains(t, err, "has no RicSlicingNodeCapabilityList")
+}
@@ -7,7 +7,10 @@ require (
 	github.com/gogo/protobuf v1.3.2
 	github.com/google/martian v2.1.0+incompatible
 	github.com/onosproject/onos-api/go v0.7.110
+	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common v0.0.0-00010101000000-000000000000
 	github.com/onosproject/onos-lib-go v0.8.9
 	google.golang.org/protobuf v1.27.1
 	gotest.tools v2.2.0+incompatible
 )
+
+replace github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common => ../e2sm_common
@@ -6,6 +6,8 @@ package pdubuilder
 
 import (
 	"fmt"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
 	e2sm_v2_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-v2-ies"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
@@ -77,3 +79,31 @@ func CreateEutraCGI(plmnID []byte, eutraCellID *asn1.BitString) (*e2sm_v2_ies.Cg
 		},
 	}, nil
 }
+
+// CreateNrCGIFromNci creates an NR CGI out of a 36 bit NCI. Unlike CreateNrCGI, it checks that the PLMN ID is
+// BCD encoded.
+func CreateNrCGIFromNci(plmnID plmnid.PlmnID, nci uint64) (*e2sm_v2_ies.Cgi, error) {
+
+	if err := plmnID.Validate(); err != nil {
+		return nil, err
+	}
+	nrCellID, err := cellid.NciToBitString(nci)
+	if err != nil {
+		return nil, err
+	}
+	return CreateNrCGI(plmnID, nrCellID)
+}
+
+// CreateEutraCGIFromEci creates an E-UTRA CGI out of a 28 bit ECI. Unlike CreateEutraCGI, it checks that the
+// PLMN ID is BCD encoded.
+func CreateEutraCGIFromEci(plmnID plmnid.PlmnID, eci uint32) (*e2sm_v2_ies.Cgi, error) {
+
+	if err := plmnID.Validate(); err != nil {
+		return nil, err
+	}
+	eutraCellID, err := cellid.EciToBitString(eci)
+	if err != nil {
+		return nil, err
+	}
+	return CreateEutraCGI(plmnID, eutraCellID)
+}
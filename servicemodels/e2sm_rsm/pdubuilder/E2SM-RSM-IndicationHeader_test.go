@@ -7,6 +7,7 @@ package pdubuilder
 import (
 	"encoding/hex"
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/encoder"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 	"gotest.tools/assert"
 	"testing"
@@ -63,3 +64,17 @@ func Test_E2SmRsmIndicationHeaderEutraCellID(t *testing.T) {
 	t.Logf("E2SM-RSM-IndicationHeader PER - decoded\n%v", result)
 	assert.DeepEqual(t, ih.String(), result.String())
 }
+
+func Test_CreateCGIFromNci(t *testing.T) {
+
+	plmnID, err := plmnid.FromMCCMNC("310", "410")
+	assert.NilError(t, err)
+
+	cgi, err := CreateNrCGIFromNci(plmnID, 0x000000001)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []byte{0x13, 0x00, 0x14}, cgi.GetNRCgi().GetPLmnidentity().GetValue())
+	assert.DeepEqual(t, []byte{0x00, 0x00, 0x00, 0x00, 0x10}, cgi.GetNRCgi().GetNRcellIdentity().GetValue().GetValue())
+
+	_, err = CreateNrCGIFromNci(plmnid.PlmnID{0x00, 0x01, 0x0F}, 0x000000001)
+	assert.ErrorContains(t, err, "not BCD encoded")
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rsmuekey converts the UE identities carried by E2SM-RSM to canonical UE keys
+package rsmuekey
+
+import (
+	"fmt"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/cellid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/plmnid"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+	e2sm_v2_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-v2-ies"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+)
+
+// FromUeIdentity returns the key of an E2SM-RSM UE-Identity
+func FromUeIdentity(ueID *e2sm_rsm_ies.UeIdentity) (uekey.Key, error) {
+	switch id := ueID.GetUeIdentity().(type) {
+	case *e2sm_rsm_ies.UeIdentity_CuUeF1ApId:
+		return uekey.New(uekey.TypeGnbCuUeF1apID, id.CuUeF1ApId.GetValue()), nil
+	case *e2sm_rsm_ies.UeIdentity_DuUeF1ApId:
+		return uekey.New(uekey.TypeGnbDuUeF1apID, id.DuUeF1ApId.GetValue()), nil
+	case *e2sm_rsm_ies.UeIdentity_RanUeNgapId:
+		return uekey.New(uekey.TypeRanUeNgapID, id.RanUeNgapId.GetValue()), nil
+	case *e2sm_rsm_ies.UeIdentity_AmfUeNgapId:
+		return uekey.New(uekey.TypeAmfUeNgapID, id.AmfUeNgapId.GetValue()), nil
+	case *e2sm_rsm_ies.UeIdentity_EnbUeS1ApId:
+		return uekey.New(uekey.TypeEnbUeS1apID, int64(id.EnbUeS1ApId.GetValue())), nil
+	}
+	return uekey.Key{}, fmt.Errorf("unexpected UeIdentity %v", ueID)
+}
+
+// FromUeid returns the key of the primary identity of an E2SM UEID: the AMF UE NGAP ID of gNB and ng-eNB UEs,
+// the F1AP, E1AP or W1AP ID allocated by the CU of gNB-DU, gNB-CU-UP and ng-eNB-DU UEs,
+// the X2AP ID allocated by the master eNB of en-gNB UEs and the MME UE S1AP ID of eNB UEs
+func FromUeid(ueid *e2sm_v2_ies.Ueid) (uekey.Key, error) {
+	keys, err := AllFromUeid(ueid)
+	if err != nil {
+		return uekey.Key{}, err
+	}
+	return keys[0], nil
+}
+
+// AllFromUeid returns the keys of all identities carried by an E2SM UEID, the primary identity first.
+// AMF UE NGAP IDs are not scoped by the GUAMI, so that they equal the keys returned by FromUeIdentity:
+// the keys of UEs of different AMFs with the same AMF UE NGAP ID are equal, see uekey.Key.
+func AllFromUeid(ueid *e2sm_v2_ies.Ueid) ([]uekey.Key, error) {
+	switch id := ueid.GetUeid().(type) {
+	case *e2sm_v2_ies.Ueid_GNbUeid:
+		keys := []uekey.Key{uekey.New(uekey.TypeAmfUeNgapID, id.GNbUeid.GetAmfUeNgapId().GetValue())}
+		for _, item := range id.GNbUeid.GetGNbCuUeF1ApIdList().GetValue() {
+			keys = append(keys, uekey.New(uekey.TypeGnbCuUeF1apID, item.GetGNbCuUeF1ApId().GetValue()))
+		}
+		for _, item := range id.GNbUeid.GetGNbCuCpUeE1ApIdList().GetValue() {
+			keys = append(keys, uekey.New(uekey.TypeGnbCuCpUeE1apID, item.GetGNbCuCpUeE1ApId().GetValue()))
+		}
+		return keys, nil
+	case *e2sm_v2_ies.Ueid_GNbDuUeid:
+		return []uekey.Key{uekey.New(uekey.TypeGnbCuUeF1apID, id.GNbDuUeid.GetGNbCuUeF1ApId().GetValue())}, nil
+	case *e2sm_v2_ies.Ueid_GNbCuUpUeid:
+		return []uekey.Key{uekey.New(uekey.TypeGnbCuCpUeE1apID, id.GNbCuUpUeid.GetGNbCuCpUeE1ApId().GetValue())}, nil
+	case *e2sm_v2_ies.Ueid_NgENbUeid:
+		keys := []uekey.Key{uekey.New(uekey.TypeAmfUeNgapID, id.NgENbUeid.GetAmfUeNgapId().GetValue())}
+		if id.NgENbUeid.GetNgENbCuUeW1ApId() != nil {
+			keys = append(keys, uekey.New(uekey.TypeNgEnbCuUeW1apID, id.NgENbUeid.GetNgENbCuUeW1ApId().GetValue()))
+		}
+		return keys, nil
+	case *e2sm_v2_ies.Ueid_NgENbDuUeid:
+		return []uekey.Key{uekey.New(uekey.TypeNgEnbCuUeW1apID, id.NgENbDuUeid.GetNgENbCuUeW1ApId().GetValue())}, nil
+	case *e2sm_v2_ies.Ueid_EnGNbUeid:
+		scope, err := globalEnbIDScope(id.EnGNbUeid.GetGlobalEnbId())
+		if err != nil {
+			return nil, err
+		}
+		keys := []uekey.Key{uekey.NewScoped(uekey.TypeMenbUeX2apID, scope, int64(id.EnGNbUeid.GetMENbUeX2ApId().GetValue()))}
+		if id.EnGNbUeid.GetGNbCuUeF1ApId() != nil {
+			keys = append(keys, uekey.New(uekey.TypeGnbCuUeF1apID, id.EnGNbUeid.GetGNbCuUeF1ApId().GetValue()))
+		}
+		for _, item := range id.EnGNbUeid.GetGNbCuCpUeE1ApIdList().GetValue() {
+			keys = append(keys, uekey.New(uekey.TypeGnbCuCpUeE1apID, item.GetGNbCuCpUeE1ApId().GetValue()))
+		}
+		return keys, nil
+	case *e2sm_v2_ies.Ueid_ENbUeid:
+		gummei := id.ENbUeid.GetGUmmei()
+		scope := fmt.Sprintf("%s:%x:%x", plmnid.PlmnID(gummei.GetPLmnIdentity().GetValue()),
+			gummei.GetMMeGroupId().GetValue(), gummei.GetMMeCode().GetValue())
+		return []uekey.Key{uekey.NewScoped(uekey.TypeMmeUeS1apID, scope, id.ENbUeid.GetMMeUeS1ApId().GetValue())}, nil
+	}
+	return nil, fmt.Errorf("unexpected Ueid %v", ueid)
+}
+
+// globalEnbIDScope formats a Global eNB ID as "MCC-MNC:eNB-ID:length", with the eNB ID in hexadecimal
+func globalEnbIDScope(globalEnbID *e2sm_v2_ies.GlobalEnbId) (string, error) {
+	var bs *asn1.BitString
+	switch enbID := globalEnbID.GetENbId(); {
+	case enbID.GetMacroENbId() != nil:
+		bs = enbID.GetMacroENbId()
+	case enbID.GetHomeENbId() != nil:
+		bs = enbID.GetHomeENbId()
+	case enbID.GetShortMacroENbId() != nil:
+		bs = enbID.GetShortMacroENbId()
+	default:
+		bs = enbID.GetLongMacroENbId()
+	}
+	id, err := cellid.BitStringToUint64(bs)
+	if err != nil {
+		return "", fmt.Errorf("invalid eNB ID: %v", err)
+	}
+	return fmt.Sprintf("%s:%x:%d", plmnid.PlmnID(globalEnbID.GetPLmnidentity().GetValue()), id, bs.GetLen()), nil
+}
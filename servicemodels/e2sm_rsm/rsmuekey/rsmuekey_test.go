@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package rsmuekey
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/pdubuilder"
+	e2sm_v2_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-v2-ies"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"gotest.tools/assert"
+)
+
+func TestFromUeIdentity(t *testing.T) {
+	key, err := FromUeIdentity(pdubuilder.CreateUeIDCuUeF1ApID(21))
+	assert.NilError(t, err)
+	assert.Equal(t, "GNB-CU-UE-F1AP-ID/21", key.String())
+	key, err = FromUeIdentity(pdubuilder.CreateUeIDDuUeF1ApID(11))
+	assert.NilError(t, err)
+	assert.Equal(t, "GNB-DU-UE-F1AP-ID/11", key.String())
+	key, err = FromUeIdentity(pdubuilder.CreateUeIDRanUeNgapID(101))
+	assert.NilError(t, err)
+	assert.Equal(t, "RAN-UE-NGAP-ID/101", key.String())
+	key, err = FromUeIdentity(pdubuilder.CreateUeIDAmfUeNgapID(7))
+	assert.NilError(t, err)
+	assert.Equal(t, "AMF-UE-NGAP-ID/7", key.String())
+	key, err = FromUeIdentity(pdubuilder.CreateUeIDEnbUeS1ApID(5))
+	assert.NilError(t, err)
+	assert.Equal(t, "ENB-UE-S1AP-ID/5", key.String())
+}
+
+func TestFromUeid(t *testing.T) {
+	guami := &e2sm_v2_ies.Guami{
+		PLmnidentity: &e2sm_v2_ies.PlmnIdentity{Value: []byte{0x13, 0x00, 0x14}},
+		AMfregionId:  &e2sm_v2_ies.AmfregionId{Value: &asn1.BitString{Value: []byte{0xab}, Len: 8}},
+		AMfsetId:     &e2sm_v2_ies.AmfsetId{Value: &asn1.BitString{Value: []byte{0xf0, 0x40}, Len: 10}},
+		AMfpointer:   &e2sm_v2_ies.Amfpointer{Value: &asn1.BitString{Value: []byte{0x28}, Len: 6}},
+	}
+	ueid := &e2sm_v2_ies.Ueid{
+		Ueid: &e2sm_v2_ies.Ueid_GNbUeid{
+			GNbUeid: &e2sm_v2_ies.UeidGnb{
+				AmfUeNgapId: &e2sm_v2_ies.AmfUeNgapId{Value: 7},
+				Guami:       guami,
+				GNbCuUeF1ApIdList: &e2sm_v2_ies.UeidGnbCuF1ApIdList{
+					Value: []*e2sm_v2_ies.UeidGnbCuCpF1ApIdItem{
+						{GNbCuUeF1ApId: &e2sm_v2_ies.GnbCuUeF1ApId{Value: 21}},
+					},
+				},
+			},
+		},
+	}
+	keys, err := AllFromUeid(ueid)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(keys))
+	assert.Equal(t, "AMF-UE-NGAP-ID/7", keys[0].String())
+	assert.Equal(t, "GNB-CU-UE-F1AP-ID/21", keys[1].String())
+
+	// The same UE seen through RSM, which carries no GUAMI, has the same key
+	rsmKey, err := FromUeIdentity(pdubuilder.CreateUeIDAmfUeNgapID(7))
+	assert.NilError(t, err)
+	primary, err := FromUeid(ueid)
+	assert.NilError(t, err)
+	assert.Assert(t, primary == rsmKey)
+	rsmKey, err = FromUeIdentity(pdubuilder.CreateUeIDCuUeF1ApID(21))
+	assert.NilError(t, err)
+	assert.Assert(t, keys[1].Equal(rsmKey))
+
+	key, err := FromUeid(&e2sm_v2_ies.Ueid{
+		Ueid: &e2sm_v2_ies.Ueid_EnGNbUeid{
+			EnGNbUeid: &e2sm_v2_ies.UeidEnGnb{
+				MENbUeX2ApId: &e2sm_v2_ies.EnbUeX2ApId{Value: 3},
+				GlobalEnbId: &e2sm_v2_ies.GlobalEnbId{
+					PLmnidentity: &e2sm_v2_ies.PlmnIdentity{Value: []byte{0x13, 0x00, 0x14}},
+					ENbId: &e2sm_v2_ies.EnbId{
+						EnbId: &e2sm_v2_ies.EnbId_MacroENbId{
+							MacroENbId: &asn1.BitString{Value: []byte{0xd4, 0xbc, 0x00}, Len: 20},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, "MENB-UE-X2AP-ID/310-410:d4bc0:20/3", key.String())
+
+	key, err = FromUeid(&e2sm_v2_ies.Ueid{
+		Ueid: &e2sm_v2_ies.Ueid_ENbUeid{
+			ENbUeid: &e2sm_v2_ies.UeidEnb{
+				MMeUeS1ApId: &e2sm_v2_ies.MmeUeS1ApId{Value: 9},
+				GUmmei: &e2sm_v2_ies.Gummei{
+					PLmnIdentity: &e2sm_v2_ies.PlmnIdentity{Value: []byte{0x13, 0x00, 0x14}},
+					MMeGroupId:   &e2sm_v2_ies.MmeGroupId{Value: []byte{0x01, 0x02}},
+					MMeCode:      &e2sm_v2_ies.MmeCode{Value: []byte{0x03}},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, "MME-UE-S1AP-ID/310-410:0102:03/9", key.String())
+
+	_, err = FromUeid(&e2sm_v2_ies.Ueid{})
+	assert.ErrorContains(t, err, "unexpected Ueid")
+}
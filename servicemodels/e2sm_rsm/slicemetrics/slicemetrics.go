@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slicemetrics folds E2SM-RSM indications into per-UE and per-slice time series of SliceMetrics.
+// Format 1 indications carry the metrics of a UE, format 2 indications carry the EMM events
+// which attach UEs to the node and detach them from it. The series of a UE are dropped when it detaches.
+package slicemetrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/rsmuekey"
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+)
+
+// SeriesKey identifies the series of one slice. SliceMetrics carry no slice ID:
+// a slice is identified by its index in the UlSlicingMetrics or DlSlicingMetrics list.
+type SeriesKey struct {
+	SliceType  e2sm_rsm_ies.SliceType
+	SliceIndex int
+}
+
+// Sample is one SliceMetrics reported at a given time
+type Sample struct {
+	Time time.Time
+	// UeKey is the key of the UE whose indication reported the metrics
+	UeKey   uekey.Key
+	Metrics *e2sm_rsm_ies.SliceMetrics
+}
+
+// Ue is a UE known to the store
+type Ue struct {
+	// Key is the key of the first identity the UE was known by
+	Key        uekey.Key
+	IDs        []*e2sm_rsm_ies.UeIdentity
+	EmmCase    e2sm_rsm_ies.Emmcase
+	Bearers    []*e2sm_rsm_ies.BearerId
+	LastUpdate time.Time
+	series     map[SeriesKey][]*Sample
+	aliasKeys  []uekey.Key
+}
+
+// Store holds the series of the UEs of one E2 node
+type Store struct {
+	maxSamples int
+	ues        map[uekey.Key]*Ue
+	aliases    map[uekey.Key]*Ue
+	slices     map[SeriesKey][]*Sample
+}
+
+// NewStore creates a store keeping at most maxSamples samples per series; maxSamples <= 0 keeps all samples
+func NewStore(maxSamples int) *Store {
+	return &Store{
+		maxSamples: maxSamples,
+		ues:        make(map[uekey.Key]*Ue),
+		aliases:    make(map[uekey.Key]*Ue),
+		slices:     make(map[SeriesKey][]*Sample),
+	}
+}
+
+// Add folds a decoded indication message received at the given time into the store
+func (s *Store) Add(t time.Time, message *e2sm_rsm_ies.E2SmRsmIndicationMessage) error {
+	switch msg := message.GetE2SmRsmIndicationMessage().(type) {
+	case *e2sm_rsm_ies.E2SmRsmIndicationMessage_IndicationMessageFormat1:
+		return s.addMetrics(t, msg.IndicationMessageFormat1)
+	case *e2sm_rsm_ies.E2SmRsmIndicationMessage_IndicationMessageFormat2:
+		return s.addEmmEvent(t, msg.IndicationMessageFormat2)
+	}
+	return fmt.Errorf("unexpected E2SmRsmIndicationMessage %v", message)
+}
+
+// Ue returns the UE known by the given identity
+func (s *Store) Ue(ueID *e2sm_rsm_ies.UeIdentity) (*Ue, bool) {
+	key, err := rsmuekey.FromUeIdentity(ueID)
+	if err != nil {
+		return nil, false
+	}
+	ue, ok := s.aliases[key]
+	return ue, ok
+}
+
+// Ues returns the attached UEs, ordered by key
+func (s *Store) Ues() []*Ue {
+	ues := make([]*Ue, 0, len(s.ues))
+	for _, ue := range s.ues {
+		ues = append(ues, ue)
+	}
+	sort.Slice(ues, func(i, j int) bool {
+		return ues[i].Key.String() < ues[j].Key.String()
+	})
+	return ues
+}
+
+// UeSeries returns the samples of a slice reported by a UE, oldest first
+func (s *Store) UeSeries(ueID *e2sm_rsm_ies.UeIdentity, sliceType e2sm_rsm_ies.SliceType, sliceIndex int) []*Sample {
+	ue, ok := s.Ue(ueID)
+	if !ok {
+		return nil
+	}
+	return ue.series[SeriesKey{SliceType: sliceType, SliceIndex: sliceIndex}]
+}
+
+// SliceSeries returns the samples of a slice reported by all attached UEs, oldest first
+func (s *Store) SliceSeries(sliceType e2sm_rsm_ies.SliceType, sliceIndex int) []*Sample {
+	return s.slices[SeriesKey{SliceType: sliceType, SliceIndex: sliceIndex}]
+}
+
+func (s *Store) addMetrics(t time.Time, format1 *e2sm_rsm_ies.E2SmRsmIndicationMessageFormat1) error {
+	ids := []*e2sm_rsm_ies.UeIdentity{format1.GetUeId()}
+	if format1.GetCuUeF1ApId() != nil {
+		ids = append(ids, &e2sm_rsm_ies.UeIdentity{
+			UeIdentity: &e2sm_rsm_ies.UeIdentity_CuUeF1ApId{CuUeF1ApId: format1.GetCuUeF1ApId()},
+		})
+	}
+	if format1.GetDuUeF1ApId() != nil {
+		ids = append(ids, &e2sm_rsm_ies.UeIdentity{
+			UeIdentity: &e2sm_rsm_ies.UeIdentity_DuUeF1ApId{DuUeF1ApId: format1.GetDuUeF1ApId()},
+		})
+	}
+	ue, err := s.attach(ids)
+	if err != nil {
+		return err
+	}
+	if format1.GetEmmCase() == e2sm_rsm_ies.Emmcase_EMMCASE_DETACHED {
+		s.detach(ue)
+		return nil
+	}
+	ue.EmmCase = format1.GetEmmCase()
+	ue.LastUpdate = t
+
+	for i, metrics := range format1.GetDlSlicingMetrics() {
+		s.append(t, ue, SeriesKey{SliceType: e2sm_rsm_ies.SliceType_SLICE_TYPE_DL_SLICE, SliceIndex: i}, metrics)
+	}
+	for i, metrics := range format1.GetUlSlicingMetrics() {
+		s.append(t, ue, SeriesKey{SliceType: e2sm_rsm_ies.SliceType_SLICE_TYPE_UL_SLICE, SliceIndex: i}, metrics)
+	}
+	return nil
+}
+
+func (s *Store) addEmmEvent(t time.Time, format2 *e2sm_rsm_ies.E2SmRsmIndicationMessageFormat2) error {
+	ids := preferredFirst(format2.GetUeIdlist(), format2.GetPrefferedUeIdtype())
+	if len(ids) == 0 {
+		return fmt.Errorf("E2SmRsmIndicationMessageFormat2 has no UE IDs")
+	}
+
+	switch format2.GetTriggerType() {
+	case e2sm_rsm_ies.RsmEmmTriggerType_RSM_EMM_TRIGGER_TYPE_UE_ATTACH,
+		e2sm_rsm_ies.RsmEmmTriggerType_RSM_EMM_TRIGGER_TYPE_HAND_IN_UE_ATTACH:
+		ue, err := s.attach(ids)
+		if err != nil {
+			return err
+		}
+		ue.EmmCase = e2sm_rsm_ies.Emmcase_EMMCASE_ATTACHED
+		ue.Bearers = format2.GetBearerId()
+		ue.LastUpdate = t
+	case e2sm_rsm_ies.RsmEmmTriggerType_RSM_EMM_TRIGGER_TYPE_UE_DETACH,
+		e2sm_rsm_ies.RsmEmmTriggerType_RSM_EMM_TRIGGER_TYPE_HAND_OUT_UE_ATTACH:
+		for _, id := range ids {
+			if ue, ok := s.Ue(id); ok {
+				s.detach(ue)
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected RsmEmmTriggerType %v", format2.GetTriggerType())
+	}
+	return nil
+}
+
+// attach returns the UE known by any of the identities, creating it if none is known,
+// and records the identities which are not yet known as aliases of the UE
+func (s *Store) attach(ids []*e2sm_rsm_ies.UeIdentity) (*Ue, error) {
+	keys := make([]uekey.Key, 0, len(ids))
+	var ue *Ue
+	for _, id := range ids {
+		key, err := rsmuekey.FromUeIdentity(id)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		if known, ok := s.aliases[key]; ok && ue == nil {
+			ue = known
+		}
+	}
+	if ue == nil {
+		ue = &Ue{
+			Key:    keys[0],
+			series: make(map[SeriesKey][]*Sample),
+		}
+		s.ues[ue.Key] = ue
+	}
+	for i, key := range keys {
+		// An identity already used by another UE, such as a reused F1AP ID, is not taken over
+		if _, ok := s.aliases[key]; !ok {
+			s.aliases[key] = ue
+			ue.aliasKeys = append(ue.aliasKeys, key)
+			ue.IDs = append(ue.IDs, ids[i])
+		}
+	}
+	return ue, nil
+}
+
+func (s *Store) detach(ue *Ue) {
+	ue.EmmCase = e2sm_rsm_ies.Emmcase_EMMCASE_DETACHED
+	for _, key := range ue.aliasKeys {
+		delete(s.aliases, key)
+	}
+	delete(s.ues, ue.Key)
+	for seriesKey, samples := range s.slices {
+		// The series returned by SliceSeries share the backing array, which must not be overwritten
+		kept := make([]*Sample, 0, len(samples))
+		for _, sample := range samples {
+			if sample.UeKey != ue.Key {
+				kept = append(kept, sample)
+			}
+		}
+		s.slices[seriesKey] = kept
+	}
+}
+
+func (s *Store) append(t time.Time, ue *Ue, key SeriesKey, metrics *e2sm_rsm_ies.SliceMetrics) {
+	sample := &Sample{
+		Time:    t,
+		UeKey:   ue.Key,
+		Metrics: metrics,
+	}
+	ue.series[key] = s.trim(append(ue.series[key], sample))
+	s.slices[key] = s.trim(append(s.slices[key], sample))
+}
+
+func (s *Store) trim(samples []*Sample) []*Sample {
+	if s.maxSamples > 0 && len(samples) > s.maxSamples {
+		return samples[len(samples)-s.maxSamples:]
+	}
+	return samples
+}
+
+// preferredFirst returns the UE identities with those of the preferred type first
+func preferredFirst(ids []*e2sm_rsm_ies.UeIdentity, preferred e2sm_rsm_ies.UeIdType) []*e2sm_rsm_ies.UeIdentity {
+	result := make([]*e2sm_rsm_ies.UeIdentity, 0, len(ids))
+	for _, id := range ids {
+		if ueIDType(id) == preferred {
+			result = append(result, id)
+		}
+	}
+	for _, id := range ids {
+		if ueIDType(id) != preferred {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func ueIDType(ueID *e2sm_rsm_ies.UeIdentity) e2sm_rsm_ies.UeIdType {
+	switch ueID.GetUeIdentity().(type) {
+	case *e2sm_rsm_ies.UeIdentity_CuUeF1ApId:
+		return e2sm_rsm_ies.UeIdType_UE_ID_TYPE_CU_UE_F1_AP_ID
+	case *e2sm_rsm_ies.UeIdentity_DuUeF1ApId:
+		return e2sm_rsm_ies.UeIdType_UE_ID_TYPE_DU_UE_F1_AP_ID
+	case *e2sm_rsm_ies.UeIdentity_RanUeNgapId:
+		return e2sm_rsm_ies.UeIdType_UE_ID_TYPE_RAN_UE_NGAP_ID
+	case *e2sm_rsm_ies.UeIdentity_AmfUeNgapId:
+		return e2sm_rsm_ies.UeIdType_UE_ID_TYPE_AMF_UE_NGAP_ID
+	}
+	return e2sm_rsm_ies.UeIdType_UE_ID_TYPE_ENB_UE_S1_AP_ID
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slicemetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/pdubuilder"
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+	"gotest.tools/assert"
+)
+
+func createMetrics(t *testing.T, prb ...int32) []*e2sm_rsm_ies.SliceMetrics {
+	metrics := make([]*e2sm_rsm_ies.SliceMetrics, 0, len(prb))
+	for _, p := range prb {
+		m, err := pdubuilder.CreateSliceMetrics(p, 1, 5, 12)
+		assert.NilError(t, err)
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+func createEmmEvent(t *testing.T, triggerType e2sm_rsm_ies.RsmEmmTriggerType, ueIDs ...*e2sm_rsm_ies.UeIdentity) *e2sm_rsm_ies.E2SmRsmIndicationMessage {
+	drbID, err := pdubuilder.CreateDrbIDfourG(1, 9)
+	assert.NilError(t, err)
+	message, err := pdubuilder.CreateE2SmRsmIndicationMessageFormat2(triggerType, ueIDs, pdubuilder.CreateUeIDtypeRanUeNgapID(),
+		[]*e2sm_rsm_ies.BearerId{pdubuilder.CreateBearerIDdrb(drbID)})
+	assert.NilError(t, err)
+	return message
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(2)
+	t0 := time.Unix(1600000000, 0)
+	dl := pdubuilder.CreateSliceTypeDL()
+	ul := pdubuilder.CreateSliceTypeUL()
+
+	// The attach event lists the DU F1AP ID first, but the RAN UE NGAP ID is preferred
+	ue1DuID := pdubuilder.CreateUeIDDuUeF1ApID(11)
+	ue1NgapID := pdubuilder.CreateUeIDRanUeNgapID(101)
+	assert.NilError(t, store.Add(t0, createEmmEvent(t, pdubuilder.CreateRsmEmmTriggerTypeUeAttach(), ue1DuID, ue1NgapID)))
+	ue1, ok := store.Ue(ue1DuID)
+	assert.Assert(t, ok)
+	assert.Equal(t, "RAN-UE-NGAP-ID/101", ue1.Key.String())
+	assert.Equal(t, 1, len(ue1.Bearers))
+
+	// Metrics reported under the CU F1AP ID are correlated through the DU F1AP ID
+	for i := int32(0); i < 3; i++ {
+		message, err := pdubuilder.CreateE2SmRsmIndicationMessageFormat1(pdubuilder.CreateUeIDCuUeF1ApID(21), 21, 11,
+			pdubuilder.CreateEmmCaseAttach(), createMetrics(t, 10+i), createMetrics(t, 20+i, 30+i))
+		assert.NilError(t, err)
+		assert.NilError(t, store.Add(t0.Add(time.Duration(i)*time.Second), message))
+	}
+	ue2ID := pdubuilder.CreateUeIDEnbUeS1ApID(5)
+	message, err := pdubuilder.CreateE2SmRsmIndicationMessageFormat1(ue2ID, 22, 12,
+		pdubuilder.CreateEmmCaseAttach(), createMetrics(t, 40), createMetrics(t, 50))
+	assert.NilError(t, err)
+	assert.NilError(t, store.Add(t0.Add(3*time.Second), message))
+
+	ues := store.Ues()
+	assert.Equal(t, 2, len(ues))
+	assert.Equal(t, "ENB-UE-S1AP-ID/5", ues[0].Key.String())
+	assert.Equal(t, "RAN-UE-NGAP-ID/101", ues[1].Key.String())
+	assert.Equal(t, 3, len(ues[1].IDs))
+	assert.Equal(t, t0.Add(2*time.Second), ues[1].LastUpdate)
+
+	series := store.UeSeries(pdubuilder.CreateUeIDCuUeF1ApID(21), dl, 1)
+	assert.Equal(t, 2, len(series))
+	assert.Equal(t, int32(31), series[0].Metrics.GetPrbUtilization())
+	assert.Equal(t, int32(32), series[1].Metrics.GetPrbUtilization())
+	assert.Equal(t, t0.Add(2*time.Second), series[1].Time)
+	assert.Equal(t, 2, len(store.UeSeries(ue1NgapID, ul, 0)))
+	assert.Equal(t, 0, len(store.UeSeries(ue1NgapID, ul, 1)))
+
+	series = store.SliceSeries(ul, 0)
+	assert.Equal(t, 2, len(series))
+	assert.Equal(t, "RAN-UE-NGAP-ID/101", series[0].UeKey.String())
+	assert.Equal(t, "ENB-UE-S1AP-ID/5", series[1].UeKey.String())
+
+	// Detached UEs leave every series, but not the series already returned
+	held := series
+	assert.NilError(t, store.Add(t0.Add(4*time.Second), createEmmEvent(t, pdubuilder.CreateRsmEmmTriggerTypeUeDetach(), ue1NgapID)))
+	_, ok = store.Ue(ue1DuID)
+	assert.Assert(t, !ok)
+	assert.Equal(t, 0, len(store.UeSeries(ue1NgapID, dl, 0)))
+	series = store.SliceSeries(ul, 0)
+	assert.Equal(t, 1, len(series))
+	assert.Equal(t, "ENB-UE-S1AP-ID/5", series[0].UeKey.String())
+	assert.Equal(t, 0, len(store.SliceSeries(dl, 1)))
+	assert.Equal(t, "RAN-UE-NGAP-ID/101", held[0].UeKey.String())
+	assert.Equal(t, "ENB-UE-S1AP-ID/5", held[1].UeKey.String())
+
+	// Format 1 can also report the detachment
+	message, err = pdubuilder.CreateE2SmRsmIndicationMessageFormat1(ue2ID, 22, 12,
+		pdubuilder.CreateEmmCaseDetach(), createMetrics(t, 40), createMetrics(t, 50))
+	assert.NilError(t, err)
+	assert.NilError(t, store.Add(t0.Add(5*time.Second), message))
+	assert.Equal(t, 0, len(store.Ues()))
+	assert.Equal(t, 0, len(store.SliceSeries(ul, 0)))
+
+	assert.ErrorContains(t, store.Add(t0, &e2sm_rsm_ies.E2SmRsmIndicationMessage{}), "unexpected E2SmRsmIndicationMessage")
+}
@@ -0,0 +1,352 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slicestate keeps an in-memory model of the slices configured on an E2 node through E2SM-RSM control messages.
+// Control messages are checked against the node slicing capability advertised in the RAN function description
+// and against the current model before they are applied, so that conflicts are reported before a message is sent.
+package slicestate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_common/uekey"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/capability"
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/rsmuekey"
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+)
+
+// SliceKey identifies a slice: DL and UL slices have separate ID spaces
+type SliceKey struct {
+	ID   int64
+	Type e2sm_rsm_ies.SliceType
+}
+
+// Slice is a slice created on the node
+type Slice struct {
+	SliceKey
+	Description string
+	Parameters  *e2sm_rsm_ies.SliceParameters
+}
+
+// BearerAssociation is the slice association of one bearer of a UE.
+// A slice ID of 0 stands for the default slice of the node.
+type BearerAssociation struct {
+	UeID      *e2sm_rsm_ies.UeIdentity
+	BearerID  *e2sm_rsm_ies.BearerId
+	DlSliceID int64
+	UlSliceID int64
+}
+
+// UeAssociation is the slice association of the bearers of a UE, ordered by DRB ID
+type UeAssociation struct {
+	UeID    *e2sm_rsm_ies.UeIdentity
+	Bearers []*BearerAssociation
+}
+
+// bearerKey identifies a bearer within a UE by its DRB ID
+type bearerKey struct {
+	fiveG bool
+	drbID int32
+}
+
+func (k bearerKey) String() string {
+	if k.fiveG {
+		return fmt.Sprintf("5G DRB %d", k.drbID)
+	}
+	return fmt.Sprintf("4G DRB %d", k.drbID)
+}
+
+func newBearerKey(bearerID *e2sm_rsm_ies.BearerId) (bearerKey, error) {
+	drbID := bearerID.GetDrbId()
+	switch {
+	case drbID.GetFourGdrbId() != nil:
+		return bearerKey{drbID: drbID.GetFourGdrbId().GetValue()}, nil
+	case drbID.GetFiveGdrbId() != nil:
+		return bearerKey{fiveG: true, drbID: drbID.GetFiveGdrbId().GetValue()}, nil
+	}
+	return bearerKey{}, fmt.Errorf("unexpected BearerId %v", bearerID)
+}
+
+// Node is the slicing state of one E2 node
+type Node struct {
+	capability *e2sm_rsm_ies.NodeSlicingCapabilityItem
+	slices     map[SliceKey]*Slice
+	// ues holds the associations of the bearers of each UE: a SliceAssociate only replaces the
+	// associations of the bearers it lists
+	ues map[uekey.Key]map[bearerKey]*BearerAssociation
+}
+
+// NewNode creates an empty slicing state for a node with the given capability.
+// Maximum numbers of slices or UEs per slice which are not positive are not enforced.
+func NewNode(capability *e2sm_rsm_ies.NodeSlicingCapabilityItem) *Node {
+	return &Node{
+		capability: capability,
+		slices:     make(map[SliceKey]*Slice),
+		ues:        make(map[uekey.Key]map[bearerKey]*BearerAssociation),
+	}
+}
+
+// Capability returns the node slicing capability the state is validated against
+func (n *Node) Capability() *e2sm_rsm_ies.NodeSlicingCapabilityItem {
+	return n.capability
+}
+
+// Slice returns the slice with the given ID and type
+func (n *Node) Slice(sliceID int64, sliceType e2sm_rsm_ies.SliceType) (*Slice, bool) {
+	slice, ok := n.slices[SliceKey{ID: sliceID, Type: sliceType}]
+	return slice, ok
+}
+
+// Slices returns the slices of the given type, ordered by ID
+func (n *Node) Slices(sliceType e2sm_rsm_ies.SliceType) []*Slice {
+	slices := make([]*Slice, 0)
+	for key, slice := range n.slices {
+		if key.Type == sliceType {
+			slices = append(slices, slice)
+		}
+	}
+	sort.Slice(slices, func(i, j int) bool {
+		return slices[i].ID < slices[j].ID
+	})
+	return slices
+}
+
+// UeAssociation returns the associations of the bearers of a UE, if any of them has been associated to slices
+func (n *Node) UeAssociation(ueID *e2sm_rsm_ies.UeIdentity) (*UeAssociation, bool) {
+	key, err := rsmuekey.FromUeIdentity(ueID)
+	if err != nil {
+		return nil, false
+	}
+	bearers, ok := n.ues[key]
+	if !ok {
+		return nil, false
+	}
+	return newUeAssociation(bearers), true
+}
+
+// SliceUes returns the associations of the UEs which have at least one bearer associated to a slice
+func (n *Node) SliceUes(sliceID int64, sliceType e2sm_rsm_ies.SliceType) []*UeAssociation {
+	keys := make([]uekey.Key, 0)
+	for key, bearers := range n.ues {
+		if isAssociated(bearers, sliceID, sliceType) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	associations := make([]*UeAssociation, 0, len(keys))
+	for _, key := range keys {
+		associations = append(associations, newUeAssociation(n.ues[key]))
+	}
+	return associations
+}
+
+// Check reports the first conflict the control message would raise, without applying it.
+// The message is first checked against the current state, then against the node slicing capability.
+func (n *Node) Check(header *e2sm_rsm_ies.E2SmRsmControlHeader, message *e2sm_rsm_ies.E2SmRsmControlMessage) error {
+	var err error
+	switch msg := message.GetE2SmRsmControlMessage().(type) {
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceCreate:
+		err = n.checkSliceCreate(msg.SliceCreate)
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceUpdate:
+		err = n.checkSliceUpdate(msg.SliceUpdate)
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceDelete:
+		err = n.checkSliceDelete(msg.SliceDelete)
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceAssociate:
+		err = n.checkSliceAssociate(msg.SliceAssociate)
+	default:
+		err = fmt.Errorf("unexpected E2SmRsmControlMessage %v", message)
+	}
+	if err != nil {
+		return err
+	}
+
+	violations, err := capability.CheckItem(n.capability, header, message, capability.Usage{
+		DlSlices: len(n.Slices(e2sm_rsm_ies.SliceType_SLICE_TYPE_DL_SLICE)),
+		UlSlices: len(n.Slices(e2sm_rsm_ies.SliceType_SLICE_TYPE_UL_SLICE)),
+	})
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return violations[0]
+	}
+	return nil
+}
+
+// Apply checks the control message and, if it raises no conflict, applies it to the state
+func (n *Node) Apply(header *e2sm_rsm_ies.E2SmRsmControlHeader, message *e2sm_rsm_ies.E2SmRsmControlMessage) error {
+	if err := n.Check(header, message); err != nil {
+		return err
+	}
+
+	switch msg := message.GetE2SmRsmControlMessage().(type) {
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceCreate:
+		n.setSlice(msg.SliceCreate)
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceUpdate:
+		n.setSlice(msg.SliceUpdate)
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceDelete:
+		delete(n.slices, SliceKey{ID: msg.SliceDelete.GetSliceId().GetValue(), Type: msg.SliceDelete.GetSliceType()})
+	case *e2sm_rsm_ies.E2SmRsmControlMessage_SliceAssociate:
+		key, err := rsmuekey.FromUeIdentity(msg.SliceAssociate.GetUeId())
+		if err != nil {
+			return err
+		}
+		bearers, ok := n.ues[key]
+		if !ok {
+			bearers = make(map[bearerKey]*BearerAssociation)
+			n.ues[key] = bearers
+		}
+		for _, bearerID := range msg.SliceAssociate.GetBearerId() {
+			bearer, err := newBearerKey(bearerID)
+			if err != nil {
+				return err
+			}
+			bearers[bearer] = &BearerAssociation{
+				UeID:      msg.SliceAssociate.GetUeId(),
+				BearerID:  bearerID,
+				DlSliceID: msg.SliceAssociate.GetDownLinkSliceId().GetValue(),
+				UlSliceID: msg.SliceAssociate.GetUplinkSliceId().GetValue(),
+			}
+		}
+	}
+	return nil
+}
+
+func (n *Node) setSlice(config *e2sm_rsm_ies.SliceConfig) {
+	key := SliceKey{ID: config.GetSliceId().GetValue(), Type: config.GetSliceType()}
+	n.slices[key] = &Slice{
+		SliceKey:    key,
+		Description: config.GetSliceDescription(),
+		Parameters:  config.GetSliceConfigParameters(),
+	}
+}
+
+func (n *Node) checkSliceConfig(config *e2sm_rsm_ies.SliceConfig) error {
+	if config.GetSliceId() == nil {
+		return fmt.Errorf("SliceConfig has no SliceID")
+	}
+	if config.GetSliceConfigParameters() == nil {
+		return fmt.Errorf("SliceConfig of %v slice %d has no SliceParameters", config.GetSliceType(), config.GetSliceId().GetValue())
+	}
+	return nil
+}
+
+func (n *Node) checkSliceCreate(config *e2sm_rsm_ies.SliceConfig) error {
+	if err := n.checkSliceConfig(config); err != nil {
+		return err
+	}
+	sliceID := config.GetSliceId().GetValue()
+	if _, ok := n.Slice(sliceID, config.GetSliceType()); ok {
+		return fmt.Errorf("%v slice %d already exists", config.GetSliceType(), sliceID)
+	}
+	return nil
+}
+
+func (n *Node) checkSliceUpdate(config *e2sm_rsm_ies.SliceConfig) error {
+	if err := n.checkSliceConfig(config); err != nil {
+		return err
+	}
+	sliceID := config.GetSliceId().GetValue()
+	if _, ok := n.Slice(sliceID, config.GetSliceType()); !ok {
+		return fmt.Errorf("%v slice %d does not exist", config.GetSliceType(), sliceID)
+	}
+	return nil
+}
+
+func (n *Node) checkSliceDelete(sliceDelete *e2sm_rsm_ies.SliceDelete) error {
+	sliceID := sliceDelete.GetSliceId().GetValue()
+	if _, ok := n.Slice(sliceID, sliceDelete.GetSliceType()); !ok {
+		return fmt.Errorf("%v slice %d does not exist", sliceDelete.GetSliceType(), sliceID)
+	}
+	if ues := n.SliceUes(sliceID, sliceDelete.GetSliceType()); len(ues) > 0 {
+		return fmt.Errorf("%v slice %d still has %d UEs associated", sliceDelete.GetSliceType(), sliceID, len(ues))
+	}
+	return nil
+}
+
+func (n *Node) checkSliceAssociate(sliceAssociate *e2sm_rsm_ies.SliceAssociate) error {
+	key, err := rsmuekey.FromUeIdentity(sliceAssociate.GetUeId())
+	if err != nil {
+		return err
+	}
+	if len(sliceAssociate.GetBearerId()) == 0 {
+		return fmt.Errorf("SliceAssociate of UE %s has no bearers", key)
+	}
+	bearers := make(map[bearerKey]bool)
+	for _, bearerID := range sliceAssociate.GetBearerId() {
+		bearer, err := newBearerKey(bearerID)
+		if err != nil {
+			return err
+		}
+		if bearers[bearer] {
+			return fmt.Errorf("SliceAssociate of UE %s lists the %s bearer more than once", key, bearer)
+		}
+		bearers[bearer] = true
+	}
+	if err := n.checkAssociatedSlice(key, sliceAssociate.GetDownLinkSliceId().GetValue(), e2sm_rsm_ies.SliceType_SLICE_TYPE_DL_SLICE); err != nil {
+		return err
+	}
+	return n.checkAssociatedSlice(key, sliceAssociate.GetUplinkSliceId().GetValue(), e2sm_rsm_ies.SliceType_SLICE_TYPE_UL_SLICE)
+}
+
+func (n *Node) checkAssociatedSlice(key uekey.Key, sliceID int64, sliceType e2sm_rsm_ies.SliceType) error {
+	if sliceID == 0 {
+		return nil
+	}
+	if _, ok := n.Slice(sliceID, sliceType); !ok {
+		return fmt.Errorf("UE %s cannot be associated to %v slice %d, which does not exist", key, sliceType, sliceID)
+	}
+	max := n.capability.GetMaxNumberOfUesPerSlice()
+	if max <= 0 {
+		return nil
+	}
+	// A UE which already has a bearer on the slice does not count twice
+	if bearers, ok := n.ues[key]; ok && isAssociated(bearers, sliceID, sliceType) {
+		return nil
+	}
+	if ues := n.SliceUes(sliceID, sliceType); len(ues) >= int(max) {
+		return fmt.Errorf("%v slice %d already has the maximum number of %d UEs", sliceType, sliceID, max)
+	}
+	return nil
+}
+
+func newUeAssociation(bearers map[bearerKey]*BearerAssociation) *UeAssociation {
+	keys := make([]bearerKey, 0, len(bearers))
+	for key := range bearers {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fiveG != keys[j].fiveG {
+			return !keys[i].fiveG
+		}
+		return keys[i].drbID < keys[j].drbID
+	})
+	association := &UeAssociation{
+		Bearers: make([]*BearerAssociation, 0, len(keys)),
+	}
+	for _, key := range keys {
+		association.Bearers = append(association.Bearers, bearers[key])
+	}
+	association.UeID = association.Bearers[0].UeID
+	return association
+}
+
+func isAssociated(bearers map[bearerKey]*BearerAssociation, sliceID int64, sliceType e2sm_rsm_ies.SliceType) bool {
+	for _, bearer := range bearers {
+		if associatedSliceID(bearer, sliceType) == sliceID {
+			return true
+		}
+	}
+	return false
+}
+
+func associatedSliceID(association *BearerAssociation, sliceType e2sm_rsm_ies.SliceType) int64 {
+	if sliceType == e2sm_rsm_ies.SliceType_SLICE_TYPE_UL_SLICE {
+		return association.UlSliceID
+	}
+	return association.DlSliceID
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slicestate
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/pdubuilder"
+	e2sm_rsm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rsm/v1/e2sm-rsm-ies"
+	"gotest.tools/assert"
+)
+
+func createNode(t *testing.T, commands ...e2sm_rsm_ies.E2SmRsmCommand) *Node {
+	supportedConfig := make([]*e2sm_rsm_ies.SupportedSlicingConfigItem, 0)
+	for _, command := range commands {
+		supportedConfig = append(supportedConfig, pdubuilder.CreateSupportedSlicingConfigItem(command))
+	}
+	capability, err := pdubuilder.CreateSlicingCapabilityItem(2, 1, pdubuilder.CreateSlicingTypeDynamic(), 1, supportedConfig)
+	assert.NilError(t, err)
+	return NewNode(capability)
+}
+
+func sliceCreate(sliceID int64, sliceType e2sm_rsm_ies.SliceType) (*e2sm_rsm_ies.E2SmRsmControlHeader, *e2sm_rsm_ies.E2SmRsmControlMessage) {
+	config := pdubuilder.CreateSliceConfig(sliceID, pdubuilder.CreateSliceParameters(pdubuilder.CreateSchedulerTypeRoundRobin()), sliceType)
+	return pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceCreate()),
+		pdubuilder.CreateE2SmRsmControlMessageSliceCreate(config)
+}
+
+func sliceAssociate(t *testing.T, ueID *e2sm_rsm_ies.UeIdentity, dlSliceID int64, drbIDs ...int32) (*e2sm_rsm_ies.E2SmRsmControlHeader, *e2sm_rsm_ies.E2SmRsmControlMessage) {
+	if len(drbIDs) == 0 {
+		drbIDs = []int32{1}
+	}
+	bearerIDs := make([]*e2sm_rsm_ies.BearerId, 0, len(drbIDs))
+	for _, id := range drbIDs {
+		drbID, err := pdubuilder.CreateDrbIDfourG(id, 9)
+		assert.NilError(t, err)
+		bearerIDs = append(bearerIDs, pdubuilder.CreateBearerIDdrb(drbID))
+	}
+	sliceAssociate, err := pdubuilder.CreateSliceAssociate(ueID, bearerIDs, dlSliceID)
+	assert.NilError(t, err)
+	return pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandUeAssociate()),
+		pdubuilder.CreateE2SmRsmControlMessageSliceAssociate(sliceAssociate)
+}
+
+func TestNode_Slices(t *testing.T) {
+	node := createNode(t, pdubuilder.CreateE2SmRsmCommandSliceCreate(), pdubuilder.CreateE2SmRsmCommandSliceUpdate(),
+		pdubuilder.CreateE2SmRsmCommandSliceDelete())
+	dl := pdubuilder.CreateSliceTypeDL()
+	ul := pdubuilder.CreateSliceTypeUL()
+
+	assert.NilError(t, node.Apply(sliceCreate(2, dl)))
+	assert.NilError(t, node.Apply(sliceCreate(1, dl)))
+	assert.NilError(t, node.Apply(sliceCreate(1, ul)))
+	assert.ErrorContains(t, node.Apply(sliceCreate(1, dl)), "SLICE_TYPE_DL_SLICE slice 1 already exists")
+	assert.ErrorContains(t, node.Apply(sliceCreate(3, dl)), "maximum number of 2 SLICE_TYPE_DL_SLICE slices")
+	assert.ErrorContains(t, node.Apply(sliceCreate(2, ul)), "maximum number of 1 SLICE_TYPE_UL_SLICE slices")
+
+	slices := node.Slices(dl)
+	assert.Equal(t, 2, len(slices))
+	assert.Equal(t, int64(1), slices[0].ID)
+	assert.Equal(t, int64(2), slices[1].ID)
+
+	params := pdubuilder.CreateSliceParameters(pdubuilder.CreateSchedulerTypeProportionallyFair()).SetWeight(30)
+	update := pdubuilder.CreateE2SmRsmControlMessageSliceUpdate(pdubuilder.CreateSliceConfig(2, params, dl).SetSliceDescription("video"))
+	updateHeader := pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceUpdate())
+	assert.NilError(t, node.Apply(updateHeader, update))
+	slice, ok := node.Slice(2, dl)
+	assert.Assert(t, ok)
+	assert.Equal(t, "video", slice.Description)
+	assert.Equal(t, e2sm_rsm_ies.SchedulerType_SCHEDULER_TYPE_PROPORTIONALLY_FAIR, slice.Parameters.GetSchedulerType())
+	assert.Equal(t, int32(30), slice.Parameters.GetWeight())
+
+	// Header and message must carry the same command
+	assert.ErrorContains(t, node.Check(pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceCreate()), update),
+		"does not match control message")
+
+	deleteHeader := pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceDelete())
+	assert.NilError(t, node.Apply(deleteHeader, pdubuilder.CreateE2SmRsmControlMessageSliceDelete(2, dl)))
+	_, ok = node.Slice(2, dl)
+	assert.Assert(t, !ok)
+	assert.ErrorContains(t, node.Apply(deleteHeader, pdubuilder.CreateE2SmRsmControlMessageSliceDelete(2, dl)),
+		"SLICE_TYPE_DL_SLICE slice 2 does not exist")
+
+	// UE association is not supported by the node
+	assert.ErrorContains(t, node.Check(sliceAssociate(t, pdubuilder.CreateUeIDDuUeF1ApID(1), 1)),
+		"E2_SM_RSM_COMMAND_UE_ASSOCIATE is not in the supported slicing configurations")
+}
+
+func TestNode_UeAssociation(t *testing.T) {
+	node := createNode(t, pdubuilder.CreateE2SmRsmCommandSliceCreate(), pdubuilder.CreateE2SmRsmCommandSliceDelete(),
+		pdubuilder.CreateE2SmRsmCommandUeAssociate())
+	dl := pdubuilder.CreateSliceTypeDL()
+	assert.NilError(t, node.Apply(sliceCreate(1, dl)))
+	assert.NilError(t, node.Apply(sliceCreate(2, dl)))
+
+	ue1 := pdubuilder.CreateUeIDDuUeF1ApID(21)
+	ue2 := pdubuilder.CreateUeIDRanUeNgapID(22)
+	assert.ErrorContains(t, node.Apply(sliceAssociate(t, ue1, 3)), "SLICE_TYPE_DL_SLICE slice 3, which does not exist")
+	assert.NilError(t, node.Apply(sliceAssociate(t, ue1, 1)))
+	// Re-associating a UE to its own slice does not count twice
+	assert.NilError(t, node.Apply(sliceAssociate(t, ue1, 1)))
+	assert.ErrorContains(t, node.Apply(sliceAssociate(t, ue2, 1)), "SLICE_TYPE_DL_SLICE slice 1 already has the maximum number of 1 UEs")
+	assert.NilError(t, node.Apply(sliceAssociate(t, ue2, 2)))
+
+	association, ok := node.UeAssociation(ue1)
+	assert.Assert(t, ok)
+	assert.Equal(t, 1, len(association.Bearers))
+	assert.Equal(t, int64(1), association.Bearers[0].DlSliceID)
+	assert.Equal(t, int64(0), association.Bearers[0].UlSliceID)
+	assert.Equal(t, 1, len(node.SliceUes(2, dl)))
+
+	// Bearers are associated separately: DRB 2 of UE 1 joins slice 2, DRB 1 stays on slice 1
+	assert.ErrorContains(t, node.Apply(sliceAssociate(t, ue1, 2, 2, 2)), "lists the 4G DRB 2 bearer more than once")
+	assert.ErrorContains(t, node.Apply(sliceAssociate(t, ue1, 2, 2)), "SLICE_TYPE_DL_SLICE slice 2 already has the maximum number of 1 UEs")
+	assert.NilError(t, node.Apply(sliceAssociate(t, ue2, 2, 1, 2)))
+	association, ok = node.UeAssociation(ue2)
+	assert.Assert(t, ok)
+	assert.Equal(t, 2, len(association.Bearers))
+	assert.NilError(t, node.Apply(sliceAssociate(t, ue2, 0, 2)))
+	association, ok = node.UeAssociation(ue2)
+	assert.Assert(t, ok)
+	assert.Equal(t, int64(2), association.Bearers[0].DlSliceID)
+	assert.Equal(t, int64(0), association.Bearers[1].DlSliceID)
+	assert.Equal(t, 1, len(node.SliceUes(2, dl)))
+
+	deleteHeader := pdubuilder.CreateE2SmRsmControlHeader(pdubuilder.CreateE2SmRsmCommandSliceDelete())
+	assert.ErrorContains(t, node.Apply(deleteHeader, pdubuilder.CreateE2SmRsmControlMessageSliceDelete(1, dl)),
+		"SLICE_TYPE_DL_SLICE slice 1 still has 1 UEs associated")
+	// Moving the UE to the default slice frees slice 1
+	assert.NilError(t, node.Apply(sliceAssociate(t, ue1, 0)))
+	assert.NilError(t, node.Apply(deleteHeader, pdubuilder.CreateE2SmRsmControlMessageSliceDelete(1, dl)))
+}